@@ -0,0 +1,97 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aquaproj/aqua/pkg/checksum"
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/cosign"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/signature"
+	"github.com/aquaproj/aqua/pkg/slsa"
+	"github.com/sirupsen/logrus"
+)
+
+type CosignVerifier interface {
+	Verify(ctx context.Context, logE *logrus.Entry, param *cosign.ParamVerify) error
+}
+
+type SLSAVerifier interface {
+	Verify(ctx context.Context, logE *logrus.Entry, param *slsa.ParamVerify) (*slsa.Result, error)
+}
+
+type SignatureVerifier interface {
+	Verify(ctx context.Context, logE *logrus.Entry, param *signature.ParamVerify) error
+}
+
+// Verifier verifies checksum files and assets with Cosign, SLSA Provenance, and signatures.
+// It is shared by `aqua install` and `aqua update-checksum` so that both verify downloaded files in the same way.
+type Verifier struct {
+	cosignVerifier    CosignVerifier
+	slsaVerifier      SLSAVerifier
+	signatureVerifier SignatureVerifier
+}
+
+func New(cosignVerifier CosignVerifier, slsaVerifier SLSAVerifier, signatureVerifier SignatureVerifier) *Verifier {
+	return &Verifier{
+		cosignVerifier:    cosignVerifier,
+		slsaVerifier:      slsaVerifier,
+		signatureVerifier: signatureVerifier,
+	}
+}
+
+// VerifyChecksumFile verifies a checksum file with checksum.cosign and checksum.signature.
+// It must be called before checksums are extracted from the checksum file.
+func (verifier *Verifier) VerifyChecksumFile(ctx context.Context, logE *logrus.Entry, rt *runtime.Runtime, pkg *config.Package, checksumFile []byte) error {
+	if cos := pkg.PackageInfo.Checksum.Cosign; cos.GetEnabled() {
+		if err := verifier.VerifyWithCosign(ctx, logE, rt, pkg, cos, bytes.NewReader(checksumFile)); err != nil {
+			return err
+		}
+	}
+
+	if pkg.PackageInfo.Checksum.Signature != nil {
+		logE.Info("verify a signature of a checksum file")
+		if err := verifier.signatureVerifier.Verify(ctx, logE, &signature.ParamVerify{
+			Runtime:      rt,
+			Package:      pkg,
+			ChecksumFile: checksumFile,
+		}); err != nil {
+			return fmt.Errorf("verify a signature of a checksum file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (verifier *Verifier) VerifyWithCosign(ctx context.Context, logE *logrus.Entry, rt *runtime.Runtime, pkg *config.Package, cos *registry.Cosign, artifact io.Reader) error {
+	logE.Info("verify a signature with Cosign")
+	if err := verifier.cosignVerifier.Verify(ctx, logE, &cosign.ParamVerify{
+		Runtime:  rt,
+		Package:  pkg,
+		Cosign:   cos,
+		Artifact: artifact,
+	}); err != nil {
+		return fmt.Errorf("verify a signature with Cosign: %w", err)
+	}
+	return nil
+}
+
+func (verifier *Verifier) VerifySLSAProvenance(ctx context.Context, logE *logrus.Entry, rt *runtime.Runtime, pkg *config.Package, artifact io.Reader) (*checksum.SLSAProvenance, error) {
+	logE.Info("verify a package with SLSA Provenance")
+	result, err := verifier.slsaVerifier.Verify(ctx, logE, &slsa.ParamVerify{
+		Runtime:        rt,
+		Package:        pkg,
+		SLSAProvenance: pkg.PackageInfo.SLSAProvenance,
+		Artifact:       artifact,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify a package with SLSA Provenance: %w", err)
+	}
+	return &checksum.SLSAProvenance{
+		SourceURI: result.SourceURI,
+		BuilderID: result.BuilderID,
+	}, nil
+}
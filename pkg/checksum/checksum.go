@@ -1,16 +1,29 @@
 package checksum
 
 import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"strings"
 
 	"github.com/aquaproj/aqua/pkg/config/registry"
-	"github.com/codingsince1985/checksum"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
+)
+
+var (
+	errAlgorithmIsRequired  = errors.New("algorithm is required")
+	errUnsupportedAlgorithm = errors.New("unsupported algorithm")
 )
 
 func NewCalculator() *Calculator {
@@ -19,52 +32,117 @@ func NewCalculator() *Calculator {
 
 type Calculator struct{}
 
-func (calc *Calculator) Calculate(fs afero.Fs, filename, algorithm string) (string, error) {
+// NewHash returns a hash of the algorithm.
+func NewHash(algorithm string) (hash.Hash, error) {
 	switch algorithm {
 	case "md5":
-		return checksum.MD5sum(filename) //nolint:wrapcheck
+		return md5.New(), nil //nolint:gosec
+	case "sha1":
+		return sha1.New(), nil //nolint:gosec
 	case "sha256":
-		return checksum.SHA256sum(filename) //nolint:wrapcheck
+		return sha256.New(), nil
+	case "sha384":
+		return sha512.New384(), nil
 	case "sha512":
-		return calculateSHA512(fs, filename)
+		return sha512.New(), nil
+	case "sha3-256":
+		return sha3.New256(), nil
+	case "blake2b":
+		h, err := blake2b.New512(nil)
+		if err != nil {
+			return nil, fmt.Errorf("initialize blake2b: %w", err)
+		}
+		return h, nil
+	case "blake3":
+		return blake3.New(32, nil), nil //nolint:gomnd
 	case "":
-		return "", errors.New("algorithm is required")
+		return nil, errAlgorithmIsRequired
 	default:
-		return "", errors.New("unsupported algorithm")
+		return nil, logerr.WithFields(errUnsupportedAlgorithm, logrus.Fields{ //nolint:wrapcheck
+			"algorithm": algorithm,
+		})
 	}
 }
 
-func CalculateReader(file io.Reader, algorithm string) (string, error) {
-	switch algorithm {
-	case "md5":
-		return checksum.MD5sumReader(file) //nolint:wrapcheck
-	case "sha256":
-		return checksum.SHA256sumReader(file) //nolint:wrapcheck
-	case "sha512":
-		return calculateSHA512Reader(file)
-	case "":
-		return "", errors.New("algorithm is required")
-	default:
-		return "", errors.New("unsupported algorithm")
+func (calc *Calculator) Calculate(fs afero.Fs, filename, algorithm string) (string, error) {
+	file, err := fs.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("open a file: %w", err)
 	}
+	defer file.Close()
+	return CalculateReader(file, algorithm)
 }
 
-func calculateSHA512Reader(file io.Reader) (string, error) {
-	byt, err := io.ReadAll(file)
-	if err != nil {
-		return "", fmt.Errorf("read a file: %w", err)
+// Sum reads the file and calculates checksums of multiple algorithms at once.
+// The returned map's keys are algorithms.
+func (calc *Calculator) Sum(file io.Reader, algorithms []string) (map[string]string, error) {
+	hashes := make(map[string]hash.Hash, len(algorithms))
+	writers := make([]io.Writer, 0, len(algorithms))
+	for _, algorithm := range algorithms {
+		if _, ok := hashes[algorithm]; ok {
+			continue
+		}
+		h, err := NewHash(algorithm)
+		if err != nil {
+			return nil, err
+		}
+		hashes[algorithm] = h
+		writers = append(writers, h)
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), file); err != nil {
+		return nil, fmt.Errorf("read a file: %w", err)
+	}
+	sums := make(map[string]string, len(hashes))
+	for algorithm, h := range hashes {
+		sums[algorithm] = hex.EncodeToString(h.Sum(nil))
 	}
-	sum := sha512.Sum512(byt)
-	return hex.EncodeToString(sum[:]), nil
+	return sums, nil
 }
 
-func calculateSHA512(fs afero.Fs, filename string) (string, error) {
-	byt, err := afero.ReadFile(fs, filename)
+func CalculateReader(file io.Reader, algorithm string) (string, error) {
+	h, err := NewHash(algorithm)
 	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(h, file); err != nil {
 		return "", fmt.Errorf("read a file: %w", err)
 	}
-	sum := sha512.Sum512(byt)
-	return hex.EncodeToString(sum[:]), nil
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// GetSignatureConfigFromFilename returns the configuration of a detached signature of the checksum file.
+// checksumFile is the asset name of the checksum file, and assetNames are all asset names of the release.
+// A public key can't be detected, so it must be set by hand.
+func GetSignatureConfigFromFilename(checksumFile string, assetNames []string, version string) *registry.ChecksumSignature {
+	names := make(map[string]struct{}, len(assetNames))
+	for _, assetName := range assetNames {
+		names[assetName] = struct{}{}
+	}
+	for _, sig := range []struct {
+		suffix string
+		typ    string
+	}{
+		{suffix: ".minisig", typ: "minisign"},
+		{suffix: ".asc", typ: "gpg"},
+		{suffix: ".sig", typ: "gpg"},
+	} {
+		filename := checksumFile + sig.suffix
+		if _, ok := names[filename]; !ok {
+			continue
+		}
+		if sig.suffix == ".sig" {
+			// If a certificate exists, the signature was created by Cosign.
+			if _, ok := names[checksumFile+".pem"]; ok {
+				continue
+			}
+		}
+		s := convertChecksumFileName(filename, version)
+		return &registry.ChecksumSignature{
+			Type:  sig.typ,
+			Asset: &s,
+		}
+	}
+	return nil
 }
 
 func convertChecksumFileName(filename, version string) string {
@@ -75,33 +153,24 @@ func convertChecksumFileName(filename, version string) string {
 
 func GetChecksumConfigFromFilename(filename, version string) *registry.Checksum {
 	s := strings.ToLower(filename)
-	for _, suffix := range []string{"sig", "asc"} {
+	for _, suffix := range []string{"sig", "asc", "minisig"} {
 		if strings.HasSuffix(s, "."+suffix) {
 			return nil
 		}
 	}
+	// The format of the checksum file is detected automatically.
 	if strings.Contains(s, "sha512") {
 		return &registry.Checksum{
-			Type:       "github_release",
-			FileFormat: "regexp",
-			Algorithm:  "sha512",
-			Asset:      convertChecksumFileName(filename, version),
-			Pattern: &registry.ChecksumPattern{
-				Checksum: `^(\b[A-Fa-f0-9]{128}\b)`,
-				File:     `^\b[A-Fa-f0-9]{128}\b\s+(\S+)$`,
-			},
+			Type:      "github_release",
+			Algorithm: "sha512",
+			Asset:     convertChecksumFileName(filename, version),
 		}
 	}
 	if strings.Contains(s, "sha256") || strings.Contains(s, "checksum") {
 		return &registry.Checksum{
-			Type:       "github_release",
-			FileFormat: "regexp",
-			Algorithm:  "sha256",
-			Asset:      convertChecksumFileName(filename, version),
-			Pattern: &registry.ChecksumPattern{
-				Checksum: `^(\b[A-Fa-f0-9]{64}\b)`,
-				File:     `^\b[A-Fa-f0-9]{64}\b\s+(\S+)$`,
-			},
+			Type:      "github_release",
+			Algorithm: "sha256",
+			Asset:     convertChecksumFileName(filename, version),
 		}
 	}
 	return nil
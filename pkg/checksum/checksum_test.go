@@ -1,9 +1,12 @@
 package checksum_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/aquaproj/aqua/pkg/checksum"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/afero"
 )
 
@@ -26,6 +29,62 @@ func TestCalculator_Calculate(t *testing.T) {
 			isErr:     true,
 			algorithm: "foo",
 		},
+		{
+			name:      "md5",
+			filename:  "foo.txt",
+			content:   "hello",
+			algorithm: "md5",
+			checksum:  "5d41402abc4b2a76b9719d911017c592",
+		},
+		{
+			name:      "sha1",
+			filename:  "foo.txt",
+			content:   "hello",
+			algorithm: "sha1",
+			checksum:  "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d",
+		},
+		{
+			name:      "sha256",
+			filename:  "foo.txt",
+			content:   "hello",
+			algorithm: "sha256",
+			checksum:  "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			name:      "sha384",
+			filename:  "foo.txt",
+			content:   "hello",
+			algorithm: "sha384",
+			checksum:  "59e1748777448c69de6b800d7a33bbfb9ff1b463e44354c3553bcdb9c666fa90125a3c79f90397bdf5f6a13de828684f",
+		},
+		{
+			name:      "sha512",
+			filename:  "foo.txt",
+			content:   "hello",
+			algorithm: "sha512",
+			checksum:  "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043",
+		},
+		{
+			name:      "sha3-256",
+			filename:  "foo.txt",
+			content:   "hello",
+			algorithm: "sha3-256",
+			checksum:  "3338be694f50c5f338814986cdf0686453a888b84f424d792af4b9202398f392",
+		},
+		{
+			name:      "blake2b",
+			filename:  "foo.txt",
+			content:   "hello",
+			algorithm: "blake2b",
+			checksum:  "e4cfa39a3d37be31c59609e807970799caa68a19bfaa15135f165085e01d41a65ba1e1b146aeb6bd0092b49eac214c103ccfa3a365954bbbe52f74a2b3620c94",
+		},
+		{
+			name:      "blake3",
+			filename:  "foo.txt",
+			content:   "hello",
+			algorithm: "blake3",
+			checksum:  "ea8f163db38682925e4491c5e58d4bb3506ef8c14eb78a86e908c5624a67200f",
+		},
 	}
 	calculator := &checksum.Calculator{}
 	for _, d := range data {
@@ -52,3 +111,99 @@ func TestCalculator_Calculate(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculator_Sum(t *testing.T) {
+	t.Parallel()
+	calculator := &checksum.Calculator{}
+	sums, err := calculator.Sum(strings.NewReader("hello"), []string{"sha256", "sha512", "sha256"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(sums, map[string]string{
+		"sha256": "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		"sha512": "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043",
+	}); diff != "" {
+		t.Fatal(diff)
+	}
+	if _, err := calculator.Sum(strings.NewReader("hello"), []string{"foo"}); err == nil {
+		t.Fatal("error must occur")
+	}
+}
+
+func TestGetSignatureConfigFromFilename(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name         string
+		checksumFile string
+		assetNames   []string
+		exp          *registry.ChecksumSignature
+	}{
+		{
+			name:         "gpg",
+			checksumFile: "terraform_1.3.6_SHA256SUMS",
+			assetNames: []string{
+				"terraform_1.3.6_linux_amd64.zip",
+				"terraform_1.3.6_SHA256SUMS",
+				"terraform_1.3.6_SHA256SUMS.sig",
+			},
+			exp: &registry.ChecksumSignature{
+				Type:  "gpg",
+				Asset: strP("terraform_{{trimV .Version}}_SHA256SUMS.sig"),
+			},
+		},
+		{
+			name:         "armored gpg",
+			checksumFile: "SHA256SUMS",
+			assetNames: []string{
+				"SHA256SUMS",
+				"SHA256SUMS.asc",
+			},
+			exp: &registry.ChecksumSignature{
+				Type:  "gpg",
+				Asset: strP("SHA256SUMS.asc"),
+			},
+		},
+		{
+			name:         "minisign",
+			checksumFile: "SHA256SUMS",
+			assetNames: []string{
+				"SHA256SUMS",
+				"SHA256SUMS.minisig",
+			},
+			exp: &registry.ChecksumSignature{
+				Type:  "minisign",
+				Asset: strP("SHA256SUMS.minisig"),
+			},
+		},
+		{
+			name:         "cosign",
+			checksumFile: "checksums.txt",
+			assetNames: []string{
+				"checksums.txt",
+				"checksums.txt.pem",
+				"checksums.txt.sig",
+			},
+		},
+		{
+			name:         "no signature",
+			checksumFile: "checksums.txt",
+			assetNames: []string{
+				"checksums.txt",
+			},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			sig := checksum.GetSignatureConfigFromFilename(d.checksumFile, d.assetNames, "v1.3.6")
+			if diff := cmp.Diff(d.exp, sig); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func strP(s string) *string {
+	return &s
+}
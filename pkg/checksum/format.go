@@ -0,0 +1,166 @@
+package checksum
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	FileFormatRegexp = "regexp"
+	FileFormatRaw    = "raw"
+	// FileFormatGNU is the format of GNU coreutils such as sha256sum.
+	// e.g. 89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101  nova_3.2.0_darwin_arm64.tar.gz
+	FileFormatGNU = "gnu"
+	// FileFormatBSD is the format of BSD and `sha256sum --tag`.
+	// e.g. SHA256 (nova_3.2.0_darwin_arm64.tar.gz) = 89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101
+	FileFormatBSD = "bsd"
+	// FileFormatJSON is a JSON object whose keys are file names.
+	// Values are checksums or objects whose keys are algorithms.
+	// e.g. {"nova_3.2.0_darwin_arm64.tar.gz": "89f744a8..."}
+	FileFormatJSON = "json"
+	// FileFormatGoReleaser is artifacts.json of GoReleaser.
+	FileFormatGoReleaser = "goreleaser"
+)
+
+var (
+	gnuPattern = regexp.MustCompile(`^([A-Fa-f0-9]{32,128})\s+\*?(\S.*)$`)
+	bsdPattern = regexp.MustCompile(`^([A-Za-z0-9-]+)\s*\((.+)\)\s*=\s*([A-Fa-f0-9]{32,128})$`)
+	rawPattern = regexp.MustCompile(`^[A-Fa-f0-9]{32,128}$`)
+)
+
+// DetectFileFormat detects the format of a checksum file.
+// If the format can't be detected, an empty string is returned.
+func DetectFileFormat(content string) string {
+	content = strings.TrimSpace(content)
+	switch {
+	case strings.HasPrefix(content, "["):
+		return FileFormatGoReleaser
+	case strings.HasPrefix(content, "{"):
+		return FileFormatJSON
+	}
+	lines := checksumLines(content)
+	if len(lines) == 0 {
+		return ""
+	}
+	switch line := lines[0]; {
+	case bsdPattern.MatchString(line):
+		return FileFormatBSD
+	case gnuPattern.MatchString(line):
+		return FileFormatGNU
+	case len(lines) == 1 && rawPattern.MatchString(line):
+		return FileFormatRaw
+	}
+	return ""
+}
+
+// checksumLines returns lines except for empty lines and comments.
+func checksumLines(content string) []string {
+	lines := strings.Split(content, "\n")
+	arr := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		arr = append(arr, line)
+	}
+	return arr
+}
+
+// normalizeAlgorithm normalizes an algorithm name to compare it.
+// e.g. SHA-256 => sha256
+func normalizeAlgorithm(algorithm string) string {
+	return strings.ReplaceAll(strings.ToLower(algorithm), "-", "")
+}
+
+func parseGNU(content string) map[string]string {
+	lines := checksumLines(content)
+	m := make(map[string]string, len(lines))
+	for _, line := range lines {
+		match := gnuPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		m[path.Base(match[2])] = match[1]
+	}
+	return m
+}
+
+// parseBSD parses a checksum file of BSD format.
+// A BSD checksum file can include checksums of multiple algorithms,
+// so algorithm is required and checksums of other algorithms are ignored.
+func parseBSD(content, algorithm string) (map[string]string, error) {
+	if algorithm == "" {
+		return nil, errAlgorithmIsRequired
+	}
+	lines := checksumLines(content)
+	m := make(map[string]string, len(lines))
+	for _, line := range lines {
+		match := bsdPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if normalizeAlgorithm(match[1]) != normalizeAlgorithm(algorithm) {
+			continue
+		}
+		m[path.Base(match[2])] = match[3]
+	}
+	return m, nil
+}
+
+func parseJSON(content, algorithm string) (map[string]string, error) {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("parse a checksum file as JSON: %w", err)
+	}
+	m := make(map[string]string, len(raw))
+	for file, v := range raw {
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			m[path.Base(file)] = s
+			continue
+		}
+		checksums := map[string]string{}
+		if err := json.Unmarshal(v, &checksums); err != nil {
+			return nil, fmt.Errorf("parse a checksum file as JSON: %w", err)
+		}
+		for algo, chksum := range checksums {
+			if (algorithm == "" && len(checksums) == 1) || normalizeAlgorithm(algo) == normalizeAlgorithm(algorithm) {
+				m[path.Base(file)] = chksum
+				break
+			}
+		}
+	}
+	return m, nil
+}
+
+type goreleaserArtifact struct {
+	Name  string `json:"name"`
+	Extra struct {
+		Checksum string `json:"Checksum"`
+	} `json:"extra"`
+}
+
+// parseGoReleaser parses artifacts.json of GoReleaser.
+// Checksums are formatted as <algorithm>:<checksum>.
+func parseGoReleaser(content, algorithm string) (map[string]string, error) {
+	var artifacts []*goreleaserArtifact
+	if err := json.Unmarshal([]byte(content), &artifacts); err != nil {
+		return nil, fmt.Errorf("parse artifacts.json of GoReleaser: %w", err)
+	}
+	m := make(map[string]string, len(artifacts))
+	for _, artifact := range artifacts {
+		algo, chksum, ok := strings.Cut(artifact.Extra.Checksum, ":")
+		if !ok {
+			continue
+		}
+		if algorithm != "" && normalizeAlgorithm(algo) != normalizeAlgorithm(algorithm) {
+			continue
+		}
+		m[artifact.Name] = chksum
+	}
+	return m, nil
+}
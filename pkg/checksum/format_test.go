@@ -0,0 +1,59 @@
+package checksum_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/checksum"
+)
+
+func TestDetectFileFormat(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name    string
+		content string
+		exp     string
+	}{
+		{
+			name:    "empty",
+			content: "",
+		},
+		{
+			name:    "raw",
+			content: "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101\n",
+			exp:     "raw",
+		},
+		{
+			name:    "gnu",
+			content: "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101  nova_3.2.0_darwin_arm64.tar.gz\n",
+			exp:     "gnu",
+		},
+		{
+			name:    "bsd",
+			content: "SHA256 (nova_3.2.0_darwin_arm64.tar.gz) = 89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101\n",
+			exp:     "bsd",
+		},
+		{
+			name:    "json",
+			content: `{"nova_3.2.0_darwin_arm64.tar.gz": "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101"}`,
+			exp:     "json",
+		},
+		{
+			name:    "goreleaser",
+			content: `[{"name": "nova_3.2.0_darwin_arm64.tar.gz"}]`,
+			exp:     "goreleaser",
+		},
+		{
+			name:    "unknown",
+			content: "hello",
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if f := checksum.DetectFileFormat(d.content); f != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, f)
+			}
+		})
+	}
+}
@@ -27,10 +27,34 @@ func (parser *FileParser) ParseChecksumFile(content string, pkg *config.Package)
 	return m, s, nil
 }
 
+// parseChecksumFile parses a checksum file.
+// If file_format isn't set, the format is detected automatically.
 func (parser *FileParser) parseChecksumFile(content string, pkg *config.Package) (map[string]string, string, error) {
-	switch pkg.PackageInfo.Checksum.FileFormat { //nolint:gocritic
-	case "regexp":
+	chk := pkg.PackageInfo.Checksum
+	format := chk.FileFormat
+	if format == "" {
+		if chk.Pattern != nil {
+			format = FileFormatRegexp
+		} else {
+			format = DetectFileFormat(content)
+		}
+	}
+	switch format {
+	case FileFormatRegexp:
 		return parser.parseRegex(content, pkg)
+	case FileFormatRaw:
+		return nil, strings.TrimSpace(content), nil
+	case FileFormatGNU:
+		return parseGNU(content), "", nil
+	case FileFormatBSD:
+		m, err := parseBSD(content, chk.Algorithm)
+		return m, "", err
+	case FileFormatJSON:
+		m, err := parseJSON(content, chk.Algorithm)
+		return m, "", err
+	case FileFormatGoReleaser:
+		m, err := parseGoReleaser(content, chk.Algorithm)
+		return m, "", err
 	}
 	return nil, "", errUnknownChecksumFileFormat
 }
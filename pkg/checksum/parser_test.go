@@ -61,6 +61,115 @@ func TestFileParser_ParseChecksumFile(t *testing.T) { //nolint:funlen
 			},
 			s: "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101",
 		},
+		{
+			name:    "raw",
+			content: "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101\n",
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Checksum: &registry.Checksum{
+						FileFormat: "raw",
+					},
+				},
+			},
+			s: "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101",
+		},
+		{
+			name: "gnu",
+			content: `89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101  nova_3.2.0_darwin_arm64.tar.gz
+c6ce28981a1fb9acb13ee091b5f3de8eb244a67dc99aff1d106985c1e94c72c6 *dist/nova_3.2.0_linux_amd64.tar.gz`,
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Checksum: &registry.Checksum{
+						FileFormat: "gnu",
+					},
+				},
+			},
+			m: map[string]string{
+				"nova_3.2.0_darwin_arm64.tar.gz": "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101",
+				"nova_3.2.0_linux_amd64.tar.gz":  "c6ce28981a1fb9acb13ee091b5f3de8eb244a67dc99aff1d106985c1e94c72c6",
+			},
+		},
+		{
+			name: "bsd",
+			content: `SHA256 (nova_3.2.0_darwin_arm64.tar.gz) = 89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101
+MD5 (nova_3.2.0_darwin_arm64.tar.gz) = 0123456789abcdef0123456789abcdef`,
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Checksum: &registry.Checksum{
+						FileFormat: "bsd",
+						Algorithm:  "sha256",
+					},
+				},
+			},
+			m: map[string]string{
+				"nova_3.2.0_darwin_arm64.tar.gz": "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101",
+			},
+		},
+		{
+			name: "bsd without algorithm",
+			content: `SHA256 (nova_3.2.0_darwin_arm64.tar.gz) = 89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101
+MD5 (nova_3.2.0_darwin_arm64.tar.gz) = 0123456789abcdef0123456789abcdef`,
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Checksum: &registry.Checksum{
+						FileFormat: "bsd",
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "json",
+			content: `{
+  "nova_3.2.0_darwin_arm64.tar.gz": "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101",
+  "nova_3.2.0_linux_amd64.tar.gz": {"sha256": "c6ce28981a1fb9acb13ee091b5f3de8eb244a67dc99aff1d106985c1e94c72c6", "md5": "0123456789abcdef0123456789abcdef"}
+}`,
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Checksum: &registry.Checksum{
+						FileFormat: "json",
+						Algorithm:  "sha256",
+					},
+				},
+			},
+			m: map[string]string{
+				"nova_3.2.0_darwin_arm64.tar.gz": "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101",
+				"nova_3.2.0_linux_amd64.tar.gz":  "c6ce28981a1fb9acb13ee091b5f3de8eb244a67dc99aff1d106985c1e94c72c6",
+			},
+		},
+		{
+			name: "goreleaser",
+			content: `[
+  {"name": "nova_3.2.0_darwin_arm64.tar.gz", "extra": {"Checksum": "sha256:89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101"}},
+  {"name": "checksums.txt", "extra": {}}
+]`,
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Checksum: &registry.Checksum{
+						FileFormat: "goreleaser",
+						Algorithm:  "sha256",
+					},
+				},
+			},
+			m: map[string]string{
+				"nova_3.2.0_darwin_arm64.tar.gz": "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101",
+			},
+		},
+		{
+			name: "detect format",
+			content: `# checksums
+89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101  nova_3.2.0_darwin_arm64.tar.gz`,
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Checksum: &registry.Checksum{
+						Algorithm: "sha256",
+					},
+				},
+			},
+			m: map[string]string{
+				"nova_3.2.0_darwin_arm64.tar.gz": "89f744a88dad0e73866d06e79afccd5476152770c70101361566b234b0722101",
+			},
+		},
 	}
 	parser := &checksum.FileParser{}
 	for _, d := range data {
@@ -10,31 +10,89 @@ import (
 	"github.com/spf13/afero"
 )
 
+// Checksums is a set of checksums.
+// Checksums of multiple algorithms can be recorded per ID,
+// so that the algorithm can be migrated without losing the verification.
 type Checksums struct {
-	m       map[string]*Checksum
+	m       map[string][]*Checksum
 	rwmutex *sync.RWMutex
 	changed bool
 }
 
 func New() *Checksums {
 	return &Checksums{
-		m:       map[string]*Checksum{},
+		m:       map[string][]*Checksum{},
 		rwmutex: &sync.RWMutex{},
 	}
 }
 
+// Get returns a checksum of the ID.
+// If checksums of multiple algorithms are recorded, the first one is returned.
 func (chksums *Checksums) Get(key string) *Checksum {
 	chksums.rwmutex.RLock()
-	chk := chksums.m[key]
+	defer chksums.rwmutex.RUnlock()
+	if arr := chksums.m[key]; len(arr) != 0 {
+		return arr[0]
+	}
+	return nil
+}
+
+// GetAll returns checksums of all algorithms of the ID.
+func (chksums *Checksums) GetAll(key string) []*Checksum {
+	if chksums == nil {
+		return nil
+	}
+	chksums.rwmutex.RLock()
+	arr := chksums.m[key]
 	chksums.rwmutex.RUnlock()
-	return chk
+	return append([]*Checksum(nil), arr...)
 }
 
+// GetByAlgorithm returns a checksum of the ID and the algorithm.
+func (chksums *Checksums) GetByAlgorithm(key, algorithm string) *Checksum {
+	chksums.rwmutex.RLock()
+	defer chksums.rwmutex.RUnlock()
+	for _, chk := range chksums.m[key] {
+		if chk.Algorithm == algorithm {
+			return chk
+		}
+	}
+	return nil
+}
+
+// Set records a checksum.
+// If a checksum of the same algorithm is already recorded, it is replaced.
 func (chksums *Checksums) Set(key string, chk *Checksum) {
 	chksums.rwmutex.Lock()
-	chksums.m[key] = chk
+	defer chksums.rwmutex.Unlock()
 	chksums.changed = true
-	chksums.rwmutex.Unlock()
+	arr := chksums.m[key]
+	for i, a := range arr {
+		if a.Algorithm == chk.Algorithm {
+			arr[i] = chk
+			return
+		}
+	}
+	chksums.m[key] = append(arr, chk)
+}
+
+// Prune removes checksums whose IDs aren't included in ids, and returns removed IDs.
+func (chksums *Checksums) Prune(ids map[string]struct{}) []string {
+	chksums.rwmutex.Lock()
+	defer chksums.rwmutex.Unlock()
+	var removed []string
+	for id := range chksums.m {
+		if _, ok := ids[id]; ok {
+			continue
+		}
+		delete(chksums.m, id)
+		removed = append(removed, id)
+	}
+	if len(removed) != 0 {
+		chksums.changed = true
+	}
+	sort.Strings(removed)
+	return removed
 }
 
 type checksumsJSON struct {
@@ -45,6 +103,37 @@ type Checksum struct {
 	ID        string `json:"id"`
 	Checksum  string `json:"checksum"`
 	Algorithm string `json:"algorithm"`
+	// Metadata records how the asset was verified.
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+type Metadata struct {
+	SLSAProvenance *SLSAProvenance `json:"slsa_provenance,omitempty"`
+}
+
+// SLSAProvenance records that the asset was verified with SLSA Provenance.
+// If it is recorded, the verification is skipped.
+type SLSAProvenance struct {
+	SourceURI string `json:"source_uri"`
+	BuilderID string `json:"builder_id"`
+}
+
+func (chk *Checksum) GetSLSAProvenance() *SLSAProvenance {
+	if chk == nil || chk.Metadata == nil {
+		return nil
+	}
+	return chk.Metadata.SLSAProvenance
+}
+
+// SetSLSAProvenance sets SLSA Provenance to a new Metadata,
+// so that Metadata shared with copies of the checksum isn't modified.
+func (chk *Checksum) SetSLSAProvenance(sp *SLSAProvenance) {
+	metadata := &Metadata{}
+	if chk.Metadata != nil {
+		*metadata = *chk.Metadata
+	}
+	metadata.SLSAProvenance = sp
+	chk.Metadata = metadata
 }
 
 func (chksums *Checksums) ReadFile(fs afero.Fs, p string) error {
@@ -62,9 +151,9 @@ func (chksums *Checksums) ReadFile(fs afero.Fs, p string) error {
 	if err := json.NewDecoder(f).Decode(chkJSON); err != nil {
 		return fmt.Errorf("parse a checksum file as JSON: %w", err)
 	}
-	m := make(map[string]*Checksum, len(chkJSON.Checksums))
+	m := make(map[string][]*Checksum, len(chkJSON.Checksums))
 	for _, chk := range chkJSON.Checksums {
-		m[chk.ID] = chk
+		m[chk.ID] = append(m[chk.ID], chk)
 	}
 	chksums.m = m
 	return nil
@@ -82,10 +171,13 @@ func (chksums *Checksums) UpdateFile(fs afero.Fs, p string) error {
 	encoder := json.NewEncoder(f)
 	encoder.SetIndent("", "  ")
 	arr := make([]*Checksum, 0, len(chksums.m))
-	for _, chk := range chksums.m {
-		arr = append(arr, chk)
+	for _, chks := range chksums.m {
+		arr = append(arr, chks...)
 	}
 	sort.Slice(arr, func(i, j int) bool {
+		if arr[i].ID == arr[j].ID {
+			return arr[i].Algorithm < arr[j].Algorithm
+		}
 		return arr[i].ID < arr[j].ID
 	})
 	chkJSON := &checksumsJSON{
@@ -54,6 +54,74 @@ func TestChecksums_Get(t *testing.T) {
 	}
 }
 
+func TestChecksums_Set(t *testing.T) {
+	t.Parallel()
+	checksums := checksum.New()
+	checksums.Set("foo", &checksum.Checksum{
+		ID:        "foo",
+		Checksum:  "sha256-old",
+		Algorithm: "sha256",
+	})
+	checksums.Set("foo", &checksum.Checksum{
+		ID:        "foo",
+		Checksum:  "sha512",
+		Algorithm: "sha512",
+	})
+	checksums.Set("foo", &checksum.Checksum{
+		ID:        "foo",
+		Checksum:  "sha256",
+		Algorithm: "sha256",
+	})
+	if diff := cmp.Diff(checksums.GetAll("foo"), []*checksum.Checksum{
+		{
+			ID:        "foo",
+			Checksum:  "sha256",
+			Algorithm: "sha256",
+		},
+		{
+			ID:        "foo",
+			Checksum:  "sha512",
+			Algorithm: "sha512",
+		},
+	}); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(checksums.GetByAlgorithm("foo", "sha512"), &checksum.Checksum{
+		ID:        "foo",
+		Checksum:  "sha512",
+		Algorithm: "sha512",
+	}); diff != "" {
+		t.Fatal(diff)
+	}
+	if chk := checksums.GetByAlgorithm("foo", "md5"); chk != nil {
+		t.Fatalf("checksum must be nil: %+v", chk)
+	}
+}
+
+func TestChecksums_Prune(t *testing.T) {
+	t.Parallel()
+	checksums := checksum.New()
+	for _, id := range []string{"foo", "bar", "baz"} {
+		checksums.Set(id, &checksum.Checksum{
+			ID:        id,
+			Checksum:  "xxx",
+			Algorithm: "sha256",
+		})
+	}
+	removed := checksums.Prune(map[string]struct{}{
+		"foo": {},
+	})
+	if diff := cmp.Diff(removed, []string{"bar", "baz"}); diff != "" {
+		t.Fatal(diff)
+	}
+	if checksums.Get("foo") == nil {
+		t.Fatal("foo must not be removed")
+	}
+	if checksums.Get("bar") != nil {
+		t.Fatal("bar must be removed")
+	}
+}
+
 func TestChecksums_ReadFile(t *testing.T) {
 	t.Parallel()
 	data := []struct {
@@ -1,8 +1,12 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/util"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,6 +26,21 @@ if command -v aqua &> /dev/null; then source <(aqua completion bash); fi
 .zprofile
 
 if command -v aqua &> /dev/null; then source <(aqua completion zsh); fi
+
+"aqua completion packages" outputs a script to load man pages and shell completions of packages.
+Man pages and shell completions are linked into $AQUA_ROOT_DIR/share by "aqua i".
+
+.bash_profile
+
+if command -v aqua &> /dev/null; then source <(aqua completion packages bash); fi
+
+.zshrc (before compinit)
+
+if command -v aqua &> /dev/null; then source <(aqua completion packages zsh); fi
+
+config.fish
+
+aqua completion packages fish | source
 `,
 		Subcommands: []*cli.Command{
 			{
@@ -34,6 +53,12 @@ if command -v aqua &> /dev/null; then source <(aqua completion zsh); fi
 				Usage:  "Output shell completion script for zsh",
 				Action: runner.zshCompletionAction,
 			},
+			{
+				Name:      "packages",
+				Usage:     "Output a script to load man pages and shell completions of packages",
+				ArgsUsage: "<bash|zsh|fish>",
+				Action:    runner.packagesCompletionAction,
+			},
 		},
 	}
 }
@@ -87,3 +112,32 @@ _cli_zsh_autocomplete() {
 compdef _cli_zsh_autocomplete aqua`)
 	return nil
 }
+
+var errUnsupportedShell = errors.New("the shell is unsupported. Please specify bash, zsh, or fish")
+
+func (runner *Runner) packagesCompletionAction(c *cli.Context) error {
+	rootDir := config.GetRootDir(osenv.New())
+	manDir := util.QuoteShell(config.GetManDir(rootDir))
+	shell := c.Args().First()
+	completionDir := config.GetCompletionDir(rootDir, shell)
+	switch shell {
+	case "bash":
+		fmt.Fprintf(runner.Stdout, `export MANPATH=%s:"${MANPATH:-}"
+for f in %s/*; do
+  [ -f "$f" ] && . "$f"
+done
+`, manDir, util.QuoteShell(completionDir))
+	case "zsh":
+		fmt.Fprintf(runner.Stdout, `export MANPATH=%s:"${MANPATH:-}"
+fpath=(%s $fpath)
+`, manDir, util.QuoteShell(completionDir))
+	case "fish":
+		fmt.Fprintf(runner.Stdout, `set -q MANPATH; or set -gx MANPATH ''
+set -gx --prepend MANPATH %s
+set -g --prepend fish_complete_path %s
+`, manDir, util.QuoteShell(completionDir))
+	default:
+		return errUnsupportedShell
+	}
+	return nil
+}
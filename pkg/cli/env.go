@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/controller"
+	"github.com/urfave/cli/v2"
+)
+
+func (runner *Runner) newEnvCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "env",
+		Usage: "Output environment variables of packages",
+		Description: `Output environment variables of packages as a shell script.
+
+Some tools require environment variables depending on the install path of the package.
+Registries can define them with the field "envs",
+and aqua exec sets them when the command of the package is executed.
+aqua env outputs environment variables of packages in configuration files found in the current directory and global configuration files.
+
+e.g.
+$ aqua env
+export GOROOT='/home/foo/.local/share/aquaproj-aqua/pkgs/http/golang.org/dl/go1.20.0.linux-amd64.tar.gz/go'
+
+You can set them in the current shell.
+
+$ eval "$(aqua env)"
+
+Configuration files must be trusted by "aqua policy allow" in advance.
+Note that this is a breaking change. aqua used to accept any configuration file.
+If you want to keep the previous behavior, please set the environment variable AQUA_TRUST_MODE=permissive.
+
+$ export AQUA_TRUST_MODE=permissive
+`,
+		Action: runner.envAction,
+	}
+}
+
+func (runner *Runner) envAction(c *cli.Context) error {
+	tracer, err := startTrace(c.String("trace"))
+	if err != nil {
+		return err
+	}
+	defer tracer.Stop()
+
+	cpuProfiler, err := startCPUProfile(c.String("cpu-profile"))
+	if err != nil {
+		return err
+	}
+	defer cpuProfiler.Stop()
+
+	param := &config.Param{}
+	if err := runner.setParam(c, "env", param); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeEnvCommandController(c.Context, param, http.DefaultClient, runner.Runtime)
+	return ctrl.Output(c.Context, runner.LogE, param) //nolint:wrapcheck
+}
@@ -30,7 +30,14 @@ When you execute the command installed by aqua, "aqua exec" is executed internal
 e.g.
 $ aqua exec -- gh version
 gh version 2.4.0 (2021-12-21)
-https://github.com/cli/cli/releases/tag/v2.4.0`,
+https://github.com/cli/cli/releases/tag/v2.4.0
+
+Configuration files must be trusted by "aqua policy allow" in advance.
+Note that this is a breaking change. aqua used to accept any configuration file.
+If you want to keep the previous behavior, please set the environment variable AQUA_TRUST_MODE=permissive.
+
+$ export AQUA_TRUST_MODE=permissive
+`,
 		Action:    runner.execAction,
 		ArgsUsage: `<executed command> [<arg> ...]`,
 	}
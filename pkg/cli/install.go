@@ -34,6 +34,28 @@ You can filter installed packages with package tags.
 e.g.
 $ aqua i -t foo # Install only packages having a tag "foo"
 $ aqua i --exclude-tags foo # Install only packages not having a tag "foo"
+
+If "--prune-links" option is set, aqua removes links of commands which aren't provided by
+configuration files found in the current directory and global configuration files.
+Links of man pages, shell completions, and data in $AQUA_ROOT_DIR/share which aren't provided by them are also removed.
+Note that links are shared among all projects, so commands of other projects are also removed.
+If "--dry-run" option is set, aqua outputs orphaned links but doesn't remove them.
+
+$ aqua i --prune-links --dry-run
+
+The directory $AQUA_ROOT_DIR/bin is shared among all projects.
+If "--project-bin" option is set, aqua also creates shims of commands in the directory ".aqua/bin"
+next to each configuration file found in the current directory.
+Shims are bound to the configuration file, and shims of commands which are removed from the configuration file are removed.
+You can add the directory to the environment variable PATH with "aqua shell-env".
+
+$ aqua i --project-bin
+
+Configuration files must be trusted by "aqua policy allow" in advance.
+Note that this is a breaking change. aqua used to accept any configuration file.
+If you want to keep the previous behavior, please set the environment variable AQUA_TRUST_MODE=permissive.
+
+$ export AQUA_TRUST_MODE=permissive
 `,
 		Action: runner.installAction,
 		Flags: []cli.Flag{
@@ -60,6 +82,18 @@ $ aqua i --exclude-tags foo # Install only packages not having a tag "foo"
 				Name:  "exclude-tags",
 				Usage: "exclude installed packages with tags",
 			},
+			&cli.BoolFlag{
+				Name:  "prune-links",
+				Usage: "remove links of commands which aren't provided by configuration files",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "output orphaned links but don't remove them",
+			},
+			&cli.BoolFlag{
+				Name:  "project-bin",
+				Usage: "create shims in the project-local bin directory .aqua/bin",
+			},
 		},
 	}
 }
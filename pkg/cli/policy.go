@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/controller"
+	"github.com/urfave/cli/v2"
+)
+
+func (runner *Runner) newPolicyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "policy",
+		Usage: "Manage policies",
+		Subcommands: []*cli.Command{
+			runner.newPolicyAllowCommand(),
+			runner.newPolicyCheckCommand(),
+		},
+	}
+}
+
+func (runner *Runner) newPolicyAllowCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "allow",
+		Usage:     "Trust configuration files",
+		ArgsUsage: `[<configuration file path> ...]`,
+		Description: `Trust configuration files.
+
+aqua which, exec, and install refuse configuration files which aren't trusted,
+because anyone can change aqua.yaml to install malicious packages from a local registry.
+aqua policy allow trusts the current content of configuration files, imported files, and local registries.
+If any of them is changed, you have to review and trust the configuration file again.
+
+If no file is specified, configuration files found in the current directory are trusted.
+
+e.g.
+$ aqua policy allow
+$ aqua policy allow aqua.yaml
+
+The environment variable AQUA_TRUST_MODE changes the behavior.
+
+- strict (default): configuration files which aren't trusted are refused
+- permissive: all configuration files are trusted
+
+The default mode is strict, which is a breaking change.
+If you want to keep the previous behavior, please set AQUA_TRUST_MODE=permissive.
+The global configuration files are always trusted.`,
+		Action: runner.policyAllowAction,
+	}
+}
+
+func (runner *Runner) policyAllowAction(c *cli.Context) error {
+	tracer, err := startTrace(c.String("trace"))
+	if err != nil {
+		return err
+	}
+	defer tracer.Stop()
+
+	cpuProfiler, err := startCPUProfile(c.String("cpu-profile"))
+	if err != nil {
+		return err
+	}
+	defer cpuProfiler.Stop()
+
+	param := &config.Param{}
+	if err := runner.setParam(c, "policy-allow", param); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeAllowPolicyCommandController(c.Context, param)
+	return ctrl.Allow(c.Context, runner.LogE, param) //nolint:wrapcheck
+}
+
+func (runner *Runner) newPolicyCheckCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "check",
+		Usage: "Check if packages violate policies",
+		Description: `Check if packages in configuration files violate policies.
+
+Packages in configuration files found in the current directory are evaluated against policy files set by AQUA_POLICY_CONFIG,
+and the report is outputted as JSON.
+
+e.g.
+$ aqua policy check
+{
+  "violations": [
+    {
+      "config_file_path": "/home/foo/workspace/aqua.yaml",
+      "package_name": "suzuki-shunsuke/tfcmt",
+      "package_version": "v3.4.1",
+      "registry": "standard",
+      "message": "this package isn't allowed",
+      "policy_files": [
+        "/home/foo/aqua-policy.yaml"
+      ],
+      "audit": false
+    }
+  ]
+}
+
+If any package violates policies, the command fails.
+Violations of policy files in the audit mode ("mode: audit") are reported but the command doesn't fail.`,
+		Action: runner.policyCheckAction,
+	}
+}
+
+func (runner *Runner) policyCheckAction(c *cli.Context) error {
+	tracer, err := startTrace(c.String("trace"))
+	if err != nil {
+		return err
+	}
+	defer tracer.Stop()
+
+	cpuProfiler, err := startCPUProfile(c.String("cpu-profile"))
+	if err != nil {
+		return err
+	}
+	defer cpuProfiler.Stop()
+
+	param := &config.Param{}
+	if err := runner.setParam(c, "policy-check", param); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeCheckPolicyCommandController(c.Context, param, http.DefaultClient, runner.Runtime)
+	return ctrl.Check(c.Context, runner.LogE, param) //nolint:wrapcheck
+}
@@ -62,6 +62,12 @@ func (runner *Runner) setParam(c *cli.Context, commandName string, param *config
 	param.GlobalConfigFilePaths = finder.ParseGlobalConfigFilePaths(os.Getenv("AQUA_GLOBAL_CONFIG"))
 	param.Deep = c.Bool("deep")
 	param.Pin = c.Bool("pin")
+	param.Download = c.Bool("download")
+	param.Reinstall = c.Bool("reinstall")
+	param.Prune = c.Bool("prune")
+	param.PruneLinks = c.Bool("prune-links")
+	param.DryRun = c.Bool("dry-run")
+	param.ProjectBin = c.Bool("project-bin")
 	wd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("get the current directory: %w", err)
@@ -69,6 +75,7 @@ func (runner *Runner) setParam(c *cli.Context, commandName string, param *config
 	param.PWD = wd
 	param.ProgressBar = os.Getenv("AQUA_PROGRESS_BAR") == "true"
 	param.PolicyConfigFilePaths = policy.ParseEnv(os.Getenv("AQUA_POLICY_CONFIG"))
+	param.TrustMode = os.Getenv("AQUA_TRUST_MODE")
 	param.Tags = parseTags(strings.Split(c.String("tags"), ","))
 	param.ExcludedTags = parseTags(strings.Split(c.String("exclude-tags"), ","))
 	return nil
@@ -133,6 +140,10 @@ func (runner *Runner) Run(ctx context.Context, args ...string) error {
 			runner.newVersionCommand(),
 			runner.newCpCommand(),
 			runner.newUpdateChecksumCommand(),
+			runner.newVerifyCommand(),
+			runner.newPolicyCommand(),
+			runner.newShellEnvCommand(),
+			runner.newEnvCommand(),
 		},
 	}
 
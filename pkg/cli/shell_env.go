@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/controller"
+	"github.com/urfave/cli/v2"
+)
+
+func (runner *Runner) newShellEnvCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "shell-env",
+		Usage: "Output a shell script to add project-local bin directories to PATH",
+		Description: `Output a shell script to add project-local bin directories to the environment variable PATH.
+
+Project-local bin directories are created by "aqua i --project-bin".
+The directory ".aqua/bin" next to each configuration file found in the current directory is added to PATH,
+and the directory of the nearest configuration file comes first.
+Directories which don't exist are ignored.
+
+e.g.
+$ aqua shell-env
+export PATH='/home/foo/workspace/bar/.aqua/bin':"$PATH"
+
+You can use this with direnv. Please add the following code to .envrc.
+
+eval "$(aqua shell-env)"
+
+Configuration files must be trusted by "aqua policy allow" in advance.
+If you want to trust all configuration files, please set the environment variable AQUA_TRUST_MODE=permissive.
+`,
+		Action: runner.shellEnvAction,
+	}
+}
+
+func (runner *Runner) shellEnvAction(c *cli.Context) error {
+	tracer, err := startTrace(c.String("trace"))
+	if err != nil {
+		return err
+	}
+	defer tracer.Stop()
+
+	cpuProfiler, err := startCPUProfile(c.String("cpu-profile"))
+	if err != nil {
+		return err
+	}
+	defer cpuProfiler.Stop()
+
+	param := &config.Param{}
+	if err := runner.setParam(c, "shell-env", param); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeShellEnvCommandController(c.Context, param)
+	return ctrl.Output(c.Context, runner.LogE, param) //nolint:wrapcheck
+}
@@ -21,7 +21,11 @@ func (runner *Runner) newUpdateChecksumCommand() *cli.Command {
 			},
 			&cli.BoolFlag{
 				Name:  "deep",
-				Usage: "If a package's checksum configuration is disabled, download the asset and calculate the checksum",
+				Usage: "If a package's checksum can't be got from the checksum file, download the asset and calculate the checksum",
+			},
+			&cli.BoolFlag{
+				Name:  "prune",
+				Usage: "Remove checksums of packages which aren't in the configuration file",
 			},
 		},
 		Description: `Create or Update .aqua-checksums.json.
@@ -35,10 +39,16 @@ please set "-a" option.
 
 $ aqua update-checksum -a
 
-By default, aqua update-checksum doesn't add checksums if the package's checksum configuration is disabled.
+aqua update-checksum adds checksums of all platforms supported by packages.
+By default, aqua update-checksum doesn't add checksums if they can't be got from checksum files,
+for example if the package's checksum configuration is disabled, and reports platforms whose checksums are missing.
 If -deep option is set, aqua update-checksum downloads assets and calculate checksums.
 
 $ aqua update-checksum -deep
+
+If -prune option is set, aqua update-checksum removes checksums of packages which aren't in the configuration file.
+
+$ aqua update-checksum -prune
 `,
 		Action: runner.updateChecksumAction,
 	}
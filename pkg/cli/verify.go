@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/controller"
+	"github.com/urfave/cli/v2"
+)
+
+func (runner *Runner) newVerifyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "Verify installed packages",
+		Description: `Verify installed packages according to the configuration files.
+
+aqua records checksums of executables when packages are installed.
+aqua verify re-calculates checksums of installed executables and compares them with recorded checksums to detect the tampering and corruption.
+
+e.g.
+$ aqua verify
+
+Packages which were installed before aqua records checksums of executables can't be verified.
+Please reinstall them.
+
+If "--download" option is set, aqua downloads assets and compares checksums with aqua-checksums.json.
+
+$ aqua verify --download
+
+If "--reinstall" option is set, corrupted packages are reinstalled.
+
+$ aqua verify --reinstall
+
+By default aqua doesn't verify packages in the global configuration.
+If you want to verify packages in the global configuration too,
+please set "-a" option.
+
+$ aqua verify -a
+`,
+		Action: runner.verifyAction,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "all",
+				Aliases: []string{"a"},
+				Usage:   "verify all aqua configuration packages",
+			},
+			&cli.BoolFlag{
+				Name:  "download",
+				Usage: "download assets and compare checksums with aqua-checksums.json",
+			},
+			&cli.BoolFlag{
+				Name:  "reinstall",
+				Usage: "reinstall corrupted packages",
+			},
+		},
+	}
+}
+
+func (runner *Runner) verifyAction(c *cli.Context) error {
+	tracer, err := startTrace(c.String("trace"))
+	if err != nil {
+		return err
+	}
+	defer tracer.Stop()
+
+	cpuProfiler, err := startCPUProfile(c.String("cpu-profile"))
+	if err != nil {
+		return err
+	}
+	defer cpuProfiler.Stop()
+
+	param := &config.Param{}
+	if err := runner.setParam(c, "verify", param); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeVerifyCommandController(c.Context, param, http.DefaultClient, runner.Runtime)
+	return ctrl.Verify(c.Context, runner.LogE, param) //nolint:wrapcheck
+}
@@ -2,11 +2,13 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/aquaproj/aqua/pkg/controller"
+	"github.com/aquaproj/aqua/pkg/domain"
 	"github.com/urfave/cli/v2"
 )
 
@@ -29,8 +31,30 @@ If the command isn't found, exits with non zero exit code.
 
 $ aqua which foo
 FATA[0000] aqua failed                                   aqua_version=0.8.6 error="command is not found" exe_name=foo program=aqua
+
+If "--verbose" option is set, aqua also outputs the configuration file, the package,
+and environment variables and arguments which aqua exec applies to the command.
+
+$ aqua which --verbose golangci-lint
+/home/foo/.aqua/pkgs/github_release/github.com/golangci/golangci-lint/v1.50.0/golangci-lint-1.50.0-linux-amd64.tar.gz/golangci-lint-1.50.0-linux-amd64/golangci-lint
+config_file_path: /home/foo/workspace/aqua.yaml
+package: golangci/golangci-lint@v1.50.0
+env: GOLANGCI_LINT_CACHE=/home/foo/workspace/.cache
+args_prefix: --config=/home/foo/workspace/.golangci.yml
+
+Configuration files must be trusted by "aqua policy allow" in advance.
+Note that this is a breaking change. aqua used to accept any configuration file.
+If you want to keep the previous behavior, please set the environment variable AQUA_TRUST_MODE=permissive.
+
+$ export AQUA_TRUST_MODE=permissive
 `,
 		Action: runner.whichAction,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "verbose",
+				Usage: "output the configuration file, the package, and environment variables and arguments applied to the command",
+			},
+		},
 	}
 }
 
@@ -61,5 +85,22 @@ func (runner *Runner) whichAction(c *cli.Context) error {
 		return err //nolint:wrapcheck
 	}
 	fmt.Fprintln(os.Stdout, which.ExePath)
+	if c.Bool("verbose") {
+		outputWhichVerbose(os.Stdout, which)
+	}
 	return nil
 }
+
+func outputWhichVerbose(w io.Writer, which *domain.FindResult) {
+	if which.Package == nil {
+		return
+	}
+	fmt.Fprintln(w, "config_file_path: "+which.ConfigFilePath)
+	fmt.Fprintln(w, "package: "+which.Package.Package.Name+"@"+which.Package.Package.Version)
+	for _, env := range which.Envs {
+		fmt.Fprintln(w, "env: "+env)
+	}
+	for _, arg := range which.ArgsPrefix {
+		fmt.Fprintln(w, "args_prefix: "+arg)
+	}
+}
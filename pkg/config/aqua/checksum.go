@@ -16,16 +16,74 @@ func (cfg *Config) RequireChecksum() bool {
 
 type Checksum struct {
 	Enabled *bool `json:"enabled,omitempty"`
-	// RequireChecksumInAdvance bool               `yaml:"require_checksum_in_advance" json:"-"`
+	// RequireChecksumInAdvance requires that checksums are recorded in aqua-checksums.json before packages are downloaded.
+	// Checksums aren't got from checksum files and aren't calculated at the installation.
+	RequireChecksumInAdvance bool `yaml:"require_checksum_in_advance" json:"require_checksum_in_advance,omitempty"`
 	// CreateJSON               bool               `yaml:"create_json" json:"-"`
 	RequireChecksum bool `yaml:"require_checksum" json:"require_checksum,omitempty"`
-	// SaveCalculatedChecksum   bool               `yaml:"save_calculated_checksum" json:"-"`
-	// Excludes                 []*ChekcsumExclude `json:"-"`
+	// SaveCalculatedChecksum is whether checksums calculated from downloaded assets are saved to aqua-checksums.json.
+	// By default, they are saved.
+	SaveCalculatedChecksum *bool `yaml:"save_calculated_checksum" json:"save_calculated_checksum,omitempty"`
+	// Excludes are packages excluded from checksum verification.
+	Excludes []*ChecksumExclude `json:"excludes,omitempty"`
 }
 
-// type ChekcsumExclude struct {
-// 	Name     string   `json:"name,omitempty"`
-// 	Registry string   `json:"registry,omitempty"`
-// 	Version  string   `json:"version,omitempty"`
-// 	Envs     []string `json:"envs,omitempty"`
-// }
+// ChecksumExclude is a condition to exclude packages from checksum verification.
+// Empty fields match any packages.
+type ChecksumExclude struct {
+	Name     string `json:"name,omitempty"`
+	Registry string `json:"registry,omitempty"`
+	Version  string `json:"version,omitempty"`
+	// Envs are platforms such as darwin, amd64, and linux/arm64.
+	Envs []string `json:"envs,omitempty"`
+}
+
+func (chk *Checksum) GetRequireChecksumInAdvance() bool {
+	if chk == nil {
+		return false
+	}
+	return chk.RequireChecksumInAdvance
+}
+
+func (chk *Checksum) GetSaveCalculatedChecksum() bool {
+	if chk == nil || chk.SaveCalculatedChecksum == nil {
+		return true
+	}
+	return *chk.SaveCalculatedChecksum
+}
+
+// Excluded returns true if the package is excluded from checksum verification.
+func (chk *Checksum) Excluded(pkg *Package, goos, goarch string) bool {
+	if chk == nil {
+		return false
+	}
+	for _, exclude := range chk.Excludes {
+		if exclude.Match(pkg, goos, goarch) {
+			return true
+		}
+	}
+	return false
+}
+
+func (exclude *ChecksumExclude) Match(pkg *Package, goos, goarch string) bool {
+	if exclude.Name != "" && exclude.Name != pkg.Name {
+		return false
+	}
+	if exclude.Registry != "" && exclude.Registry != pkg.Registry {
+		return false
+	}
+	if exclude.Version != "" && exclude.Version != pkg.Version {
+		return false
+	}
+	if exclude.Envs == nil {
+		return true
+	}
+	env := goos + "/" + goarch
+	for _, e := range exclude.Envs {
+		switch e {
+		case goos, goarch, env, "all":
+			return true
+		}
+	}
+	return false
+}
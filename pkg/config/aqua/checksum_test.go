@@ -0,0 +1,92 @@
+package aqua_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+)
+
+func TestChecksum_Excluded(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	pkg := &aqua.Package{
+		Name:     "cli/cli",
+		Version:  "v2.0.0",
+		Registry: "standard",
+	}
+	data := []struct {
+		name     string
+		checksum *aqua.Checksum
+		exp      bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:     "no exclude",
+			checksum: &aqua.Checksum{},
+		},
+		{
+			name: "name matches",
+			checksum: &aqua.Checksum{
+				Excludes: []*aqua.ChecksumExclude{
+					{
+						Name: "cli/cli",
+					},
+				},
+			},
+			exp: true,
+		},
+		{
+			name: "name doesn't match",
+			checksum: &aqua.Checksum{
+				Excludes: []*aqua.ChecksumExclude{
+					{
+						Name: "suzuki-shunsuke/tfcmt",
+					},
+				},
+			},
+		},
+		{
+			name: "version doesn't match",
+			checksum: &aqua.Checksum{
+				Excludes: []*aqua.ChecksumExclude{
+					{
+						Name:    "cli/cli",
+						Version: "v1.0.0",
+					},
+				},
+			},
+		},
+		{
+			name: "env matches",
+			checksum: &aqua.Checksum{
+				Excludes: []*aqua.ChecksumExclude{
+					{
+						Registry: "standard",
+						Envs:     []string{"windows", "linux/arm64"},
+					},
+				},
+			},
+			exp: true,
+		},
+		{
+			name: "env doesn't match",
+			checksum: &aqua.Checksum{
+				Excludes: []*aqua.ChecksumExclude{
+					{
+						Envs: []string{"windows", "linux/amd64"},
+					},
+				},
+			},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if f := d.checksum.Excluded(pkg, "linux", "arm64"); f != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, f)
+			}
+		})
+	}
+}
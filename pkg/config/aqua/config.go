@@ -12,6 +12,10 @@ type Package struct {
 	Version  string   `validate:"required" yaml:",omitempty" json:"version,omitempty"`
 	Import   string   `yaml:",omitempty" json:"import,omitempty"`
 	Tags     []string `yaml:",omitempty" json:"tags,omitempty"`
+	// Env and ArgsPrefix are applied when commands of the package are executed by aqua exec.
+	// Values can refer to the directory of the configuration file as ConfigDir.
+	Env        map[string]string `yaml:",omitempty" json:"env,omitempty"`
+	ArgsPrefix []string          `yaml:"args_prefix,omitempty" json:"args_prefix,omitempty" jsonschema:"example=--config={{.ConfigDir}}/.golangci.yml"`
 }
 
 func (pkg *Package) UnmarshalYAML(unmarshal func(interface{}) error) error {
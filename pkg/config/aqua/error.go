@@ -8,4 +8,5 @@ var (
 	errRepoOwnerIsRequired = errors.New("repo_owner is required")
 	errRepoNameIsRequired  = errors.New("repo_name is required")
 	errRefIsRequired       = errors.New("ref is required for github_content registry")
+	errInvalidGitHubHost   = errors.New("github_host must be a hostname such as ghe.example.com")
 )
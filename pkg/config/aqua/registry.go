@@ -9,12 +9,13 @@ import (
 )
 
 type Registry struct {
-	Name      string `validate:"required" json:"name,omitempty"`
-	Type      string `validate:"required" json:"type,omitempty" jsonschema:"enum=standard,enum=local,enum=github_content"`
-	RepoOwner string `yaml:"repo_owner" json:"repo_owner,omitempty"`
-	RepoName  string `yaml:"repo_name" json:"repo_name,omitempty"`
-	Ref       string `json:"ref,omitempty"`
-	Path      string `validate:"required" json:"path,omitempty"`
+	Name       string `validate:"required" json:"name,omitempty"`
+	Type       string `validate:"required" json:"type,omitempty" jsonschema:"enum=standard,enum=local,enum=github_content"`
+	RepoOwner  string `yaml:"repo_owner" json:"repo_owner,omitempty"`
+	RepoName   string `yaml:"repo_name" json:"repo_name,omitempty"`
+	GitHubHost string `yaml:"github_host" json:"github_host,omitempty" jsonschema:"example=ghe.example.com"`
+	Ref        string `json:"ref,omitempty"`
+	Path       string `validate:"required" json:"path,omitempty"`
 }
 
 const (
@@ -53,6 +54,11 @@ func (registry *Registry) validateGitHubContent() error {
 	if registry.Ref == "" {
 		return errRefIsRequired
 	}
+	if registry.GitHubHost != "" && !util.IsHost(registry.GitHubHost) {
+		return logerr.WithFields(errInvalidGitHubHost, logrus.Fields{ //nolint:wrapcheck
+			"github_host": registry.GitHubHost,
+		})
+	}
 	return nil
 }
 
@@ -86,7 +92,16 @@ func (registry *Registry) GetFilePath(rootDir, cfgFilePath string) (string, erro
 	case RegistryTypeLocal:
 		return util.Abs(filepath.Dir(cfgFilePath), registry.Path), nil
 	case RegistryTypeGitHubContent:
-		return filepath.Join(rootDir, "registries", registry.Type, "github.com", registry.RepoOwner, registry.RepoName, registry.Ref, registry.Path), nil
+		return filepath.Join(rootDir, "registries", registry.Type, registry.GetGitHubHost(), registry.RepoOwner, registry.RepoName, registry.Ref, registry.Path), nil
 	}
 	return "", errInvalidRegistryType
 }
+
+// GetGitHubHost returns the host of GitHub.
+// If github_host isn't set, github.com is returned.
+func (registry *Registry) GetGitHubHost() string {
+	if registry.GitHubHost == "" {
+		return "github.com"
+	}
+	return registry.GitHubHost
+}
@@ -23,6 +23,18 @@ func TestRegistry_Validate(t *testing.T) { //nolint:funlen
 				Type:      "github_content",
 			},
 		},
+		{
+			title: "github_host is invalid",
+			registry: &aqua.Registry{
+				RepoOwner:  "aquaproj",
+				RepoName:   "aqua-registry",
+				Ref:        "v0.8.0",
+				Path:       "foo.yaml",
+				Type:       "github_content",
+				GitHubHost: "../../x",
+			},
+			isErr: true,
+		},
 		{
 			title: "github_content repo_owner is required",
 			registry: &aqua.Registry{
@@ -21,9 +21,9 @@ func (cpkg *Package) GetChecksumID(rt *runtime.Runtime) (string, error) {
 	pkg := cpkg.Package
 	switch pkgInfo.Type {
 	case PkgInfoTypeGitHubArchive, PkgInfoTypeGo:
-		return path.Join(pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version), nil
+		return path.Join(pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version), nil
 	case PkgInfoTypeGitHubContent, PkgInfoTypeGitHubRelease:
-		return path.Join(pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version, assetName), nil
+		return path.Join(pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version, assetName), nil
 	case PkgInfoTypeHTTP:
 		uS, err := cpkg.RenderURL(rt)
 		if err != nil {
@@ -60,9 +60,9 @@ func (cpkg *Package) GetChecksumIDFromAsset(asset string) (string, error) {
 	pkg := cpkg.Package
 	switch pkgInfo.Type {
 	case PkgInfoTypeGitHubArchive, PkgInfoTypeGo:
-		return path.Join(pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version), nil
+		return path.Join(pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version), nil
 	case PkgInfoTypeGitHubContent, PkgInfoTypeGitHubRelease:
-		return path.Join(pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version, asset), nil
+		return path.Join(pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version, asset), nil
 	case PkgInfoTypeHTTP:
 		rt, err := cpkg.getRuntimeFromAsset(asset)
 		if err != nil {
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/template"
+)
+
+// RenderDownloadedFileName renders the asset name of a file such as a signature and a certificate.
+// The asset name of the package can be referred as {{.Asset}}.
+func (cpkg *Package) RenderDownloadedFileName(file *registry.DownloadedFile, rt *runtime.Runtime) (string, error) {
+	if file.Asset == nil {
+		return "", nil
+	}
+	return cpkg.renderDownloadedFile(*file.Asset, rt)
+}
+
+// RenderDownloadedFileURL renders the URL of a file such as a signature and a certificate.
+func (cpkg *Package) RenderDownloadedFileURL(file *registry.DownloadedFile, rt *runtime.Runtime) (string, error) {
+	if file.URL == nil {
+		return "", nil
+	}
+	return cpkg.renderDownloadedFile(*file.URL, rt)
+}
+
+func (cpkg *Package) renderDownloadedFile(s string, rt *runtime.Runtime) (string, error) {
+	pkgInfo := cpkg.PackageInfo
+	pkg := cpkg.Package
+	asset, err := cpkg.RenderAsset(rt)
+	if err != nil {
+		return "", err
+	}
+	return template.Execute(s, map[string]interface{}{ //nolint:wrapcheck
+		"Version": pkg.Version,
+		"GOOS":    rt.GOOS,
+		"GOARCH":  rt.GOARCH,
+		"OS":      replace(rt.GOOS, pkgInfo.GetReplacements()),
+		"Arch":    getArch(pkgInfo.GetRosetta2(), pkgInfo.GetReplacements(), rt),
+		"Format":  pkgInfo.GetFormat(),
+		"Asset":   asset,
+	})
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/template"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+var (
+	errEnvNameIsRequired  = errors.New("the name of the environment variable is required")
+	errInvalidEnvName     = errors.New("the name of the environment variable is invalid")
+	errEnvNameIsForbidden = errors.New("the environment variable can't be set by registries")
+	envNamePattern        = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// validateEnvName validates the name of an environment variable.
+// The name is outputted to shell scripts by aqua env, so only letters, digits, and underscores are allowed.
+func validateEnvName(name string) error {
+	if name == "" {
+		return errEnvNameIsRequired
+	}
+	if !envNamePattern.MatchString(name) {
+		return logerr.WithFields(errInvalidEnvName, logrus.Fields{ //nolint:wrapcheck
+			"env_name": name,
+		})
+	}
+	return nil
+}
+
+// isForbiddenEnv returns true if the environment variable changes how commands and libraries are looked up.
+// Registries can't set them because they affect all commands in the shell.
+func isForbiddenEnv(name string) bool {
+	switch name {
+	case "PATH", "LD_PRELOAD", "LD_LIBRARY_PATH", "LD_AUDIT":
+		return true
+	}
+	return strings.HasPrefix(name, "DYLD_")
+}
+
+// RenderEnvs renders environment variables of the package in the format "<name>=<value>".
+// Values can refer to the install path of the package as PkgPath in addition to variables of asset.
+func (cpkg *Package) RenderEnvs(rootDir string, rt *runtime.Runtime) ([]string, error) {
+	pkgInfo := cpkg.PackageInfo
+	if len(pkgInfo.Envs) == 0 {
+		return nil, nil
+	}
+	pkgPath, err := cpkg.GetPkgPath(rootDir, rt)
+	if err != nil {
+		return nil, fmt.Errorf("get the package install path: %w", err)
+	}
+	envs := make([]string, 0, len(pkgInfo.Envs))
+	for _, env := range pkgInfo.Envs {
+		if err := validateEnvName(env.Name); err != nil {
+			return nil, err
+		}
+		if isForbiddenEnv(env.Name) {
+			return nil, logerr.WithFields(errEnvNameIsForbidden, logrus.Fields{ //nolint:wrapcheck
+				"env_name": env.Name,
+			})
+		}
+		s, err := cpkg.renderPkgTemplate(env.Value, pkgPath, rt)
+		if err != nil {
+			return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"env_name": env.Name,
+			})
+		}
+		envs = append(envs, env.Name+"="+s)
+	}
+	return envs, nil
+}
+
+// RenderConfigEnvs renders env of the package in the configuration file in the format "<name>=<value>".
+// Environment variables are sorted by name.
+func (cpkg *Package) RenderConfigEnvs(cfgFilePath string) ([]string, error) {
+	pkg := cpkg.Package
+	if len(pkg.Env) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(pkg.Env))
+	for name := range pkg.Env {
+		if err := validateEnvName(name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	envs := make([]string, len(names))
+	for i, name := range names {
+		s, err := renderConfigTemplate(pkg.Env[name], cfgFilePath)
+		if err != nil {
+			return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"env_name": name,
+			})
+		}
+		envs[i] = name + "=" + s
+	}
+	return envs, nil
+}
+
+// RenderArgsPrefix renders args_prefix of the package in the configuration file.
+func (cpkg *Package) RenderArgsPrefix(cfgFilePath string) ([]string, error) {
+	argsPrefix := cpkg.Package.ArgsPrefix
+	if len(argsPrefix) == 0 {
+		return nil, nil
+	}
+	args := make([]string, len(argsPrefix))
+	for i, arg := range argsPrefix {
+		s, err := renderConfigTemplate(arg, cfgFilePath)
+		if err != nil {
+			return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"arg": arg,
+			})
+		}
+		args[i] = s
+	}
+	return args, nil
+}
+
+// renderPkgTemplate renders a template with variables of asset and the install path of the package as PkgPath.
+func (cpkg *Package) renderPkgTemplate(s, pkgPath string, rt *runtime.Runtime) (string, error) {
+	pkgInfo := cpkg.PackageInfo
+	replacements := pkgInfo.GetReplacements()
+	tpl, err := template.Compile(s)
+	if err != nil {
+		return "", fmt.Errorf("parse a template: %w", err)
+	}
+	rendered, err := template.ExecuteTemplate(tpl, map[string]interface{}{
+		"Version": cpkg.Package.Version,
+		"GOOS":    rt.GOOS,
+		"GOARCH":  rt.GOARCH,
+		"OS":      replace(rt.GOOS, replacements),
+		"Arch":    getArch(pkgInfo.GetRosetta2(), replacements, rt),
+		"Format":  pkgInfo.GetFormat(),
+		"PkgPath": pkgPath,
+	})
+	if err != nil {
+		return "", fmt.Errorf("render a template: %w", err)
+	}
+	return rendered, nil
+}
+
+func renderConfigTemplate(s, cfgFilePath string) (string, error) {
+	tpl, err := template.Compile(s)
+	if err != nil {
+		return "", fmt.Errorf("parse a template: %w", err)
+	}
+	rendered, err := template.ExecuteTemplate(tpl, map[string]interface{}{
+		"ConfigDir": filepath.Dir(cfgFilePath),
+	})
+	if err != nil {
+		return "", fmt.Errorf("render a template: %w", err)
+	}
+	return rendered, nil
+}
@@ -0,0 +1,170 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPackage_RenderEnvs(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title string
+		exp   []string
+		pkg   *config.Package
+		isErr bool
+	}{
+		{
+			title: "no env",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "golang",
+					RepoName:  "go",
+					Asset:     stringP("go{{trimV .Version}}.{{.OS}}-{{.Arch}}.tar.gz"),
+				},
+			},
+		},
+		{
+			title: "normal",
+			exp: []string{
+				"GOROOT=/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/golang/go/v1.0.0/go1.0.0.linux-amd64.tar.gz/go",
+				"GO_VERSION=1.0.0",
+			},
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "golang",
+					RepoName:  "go",
+					Asset:     stringP("go{{trimV .Version}}.{{.OS}}-{{.Arch}}.tar.gz"),
+					Envs: []*registry.Env{
+						{
+							Name:  "GOROOT",
+							Value: "{{.PkgPath}}/go",
+						},
+						{
+							Name:  "GO_VERSION",
+							Value: "{{trimV .Version}}",
+						},
+					},
+				},
+			},
+		},
+		{
+			title: "name is empty",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "golang",
+					RepoName:  "go",
+					Asset:     stringP("go{{trimV .Version}}.{{.OS}}-{{.Arch}}.tar.gz"),
+					Envs: []*registry.Env{
+						{
+							Value: "{{.PkgPath}}/go",
+						},
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			title: "name is invalid",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "golang",
+					RepoName:  "go",
+					Asset:     stringP("go{{trimV .Version}}.{{.OS}}-{{.Arch}}.tar.gz"),
+					Envs: []*registry.Env{
+						{
+							Name:  "GOROOT=foo; echo",
+							Value: "{{.PkgPath}}/go",
+						},
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			title: "PATH is forbidden",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "golang",
+					RepoName:  "go",
+					Asset:     stringP("go{{trimV .Version}}.{{.OS}}-{{.Arch}}.tar.gz"),
+					Envs: []*registry.Env{
+						{
+							Name:  "PATH",
+							Value: "{{.PkgPath}}/go",
+						},
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			title: "DYLD_ is forbidden",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "golang",
+					RepoName:  "go",
+					Asset:     stringP("go{{trimV .Version}}.{{.OS}}-{{.Arch}}.tar.gz"),
+					Envs: []*registry.Env{
+						{
+							Name:  "DYLD_INSERT_LIBRARIES",
+							Value: "{{.PkgPath}}/go",
+						},
+					},
+				},
+			},
+			isErr: true,
+		},
+	}
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			envs, err := d.pkg.RenderEnvs("/home/foo/.local/share/aquaproj-aqua", rt)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, envs); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
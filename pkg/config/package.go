@@ -171,6 +171,7 @@ type Param struct {
 	PWD                   string
 	InsertFile            string
 	LogColor              string
+	TrustMode             string
 	Dest                  string
 	HomeDir               string
 	MaxParallelism        int
@@ -186,6 +187,12 @@ type Param struct {
 	Deep                  bool
 	SkipLink              bool
 	Pin                   bool
+	Download              bool
+	Reinstall             bool
+	Prune                 bool
+	PruneLinks            bool
+	DryRun                bool
+	ProjectBin            bool
 	PolicyConfigFilePaths []string
 }
 
@@ -320,13 +327,13 @@ func (cpkg *Package) GetPkgPath(rootDir string, rt *runtime.Runtime) (string, er
 	}
 	switch pkgInfo.Type {
 	case PkgInfoTypeGitHubArchive:
-		return filepath.Join(rootDir, "pkgs", pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version), nil
+		return filepath.Join(rootDir, "pkgs", pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version), nil
 	case PkgInfoTypeGo:
-		return filepath.Join(rootDir, "pkgs", pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version, "src"), nil
+		return filepath.Join(rootDir, "pkgs", pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version, "src"), nil
 	case PkgInfoTypeGoInstall:
 		return filepath.Join(rootDir, "pkgs", pkgInfo.GetType(), pkgInfo.GetPath(), pkg.Version, "bin"), nil
 	case PkgInfoTypeGitHubContent, PkgInfoTypeGitHubRelease:
-		return filepath.Join(rootDir, "pkgs", pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version, assetName), nil
+		return filepath.Join(rootDir, "pkgs", pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Version, assetName), nil
 	case PkgInfoTypeHTTP:
 		uS, err := cpkg.RenderURL(rt)
 		if err != nil {
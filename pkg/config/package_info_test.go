@@ -196,6 +196,23 @@ func TestPackageInfo_GetPkgPath(t *testing.T) { //nolint:funlen
 				},
 			},
 		},
+		{
+			title: "github_release on GitHub Enterprise Server",
+			exp:   "/tmp/aqua/pkgs/github_release/ghe.example.com/aquaproj/aqua/v0.7.7/aqua.tar.gz",
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Type:       "github_release",
+					RepoOwner:  "aquaproj",
+					RepoName:   "aqua",
+					GitHubHost: "ghe.example.com",
+					Asset:      stringP("aqua.{{.Format}}"),
+					Format:     "tar.gz",
+				},
+				Package: &aqua.Package{
+					Version: "v0.7.7",
+				},
+			},
+		},
 		{
 			title: "http",
 			exp:   "/tmp/aqua/pkgs/http/example.com/foo-1.0.0.zip",
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+var errPostInstallCommandIsRequired = errors.New("the command of post_install is required")
+
+// RenderPostInstall renders post_install of the package.
+// pkgPath is the install directory of the package, and workDir is the directory where hooks are run.
+// The command and arguments can refer to pkgPath as PkgPath in addition to variables of asset.
+// If the command is a relative path such as ./foo, it is converted to the path in workDir,
+// because the package is extracted in workDir while hooks are run.
+func (cpkg *Package) RenderPostInstall(pkgPath, workDir string, rt *runtime.Runtime) ([]*registry.PostInstall, error) {
+	pkgInfo := cpkg.PackageInfo
+	if len(pkgInfo.PostInstall) == 0 {
+		return nil, nil
+	}
+	hooks := make([]*registry.PostInstall, len(pkgInfo.PostInstall))
+	for i, hook := range pkgInfo.PostInstall {
+		if hook.Command == "" {
+			return nil, errPostInstallCommandIsRequired
+		}
+		command, err := cpkg.renderPkgTemplate(hook.Command, pkgPath, rt)
+		if err != nil {
+			return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"post_install_command": hook.Command,
+			})
+		}
+		if !filepath.IsAbs(command) && strings.ContainsAny(command, `/\`) {
+			command = filepath.Join(workDir, command)
+		}
+		args := make([]string, len(hook.Args))
+		for j, arg := range hook.Args {
+			s, err := cpkg.renderPkgTemplate(arg, pkgPath, rt)
+			if err != nil {
+				return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+					"post_install_command": hook.Command,
+					"arg":                  arg,
+				})
+			}
+			args[j] = s
+		}
+		hooks[i] = &registry.PostInstall{
+			Command: command,
+			Args:    args,
+		}
+	}
+	return hooks, nil
+}
@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPackage_RenderPostInstall(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title string
+		exp   []*registry.PostInstall
+		pkg   *config.Package
+		isErr bool
+	}{
+		{
+			title: "no post_install",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "foo",
+					RepoName:  "bar",
+					Asset:     stringP("bar_{{.OS}}_{{.Arch}}.tar.gz"),
+				},
+			},
+		},
+		{
+			title: "normal",
+			exp: []*registry.PostInstall{
+				{
+					Command: "xattr",
+					Args:    []string{"-c", "/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/foo/bar/v1.0.0/bar_linux_amd64.tar.gz/bar"},
+				},
+				{
+					Command: "/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/foo/bar/v1.0.0/.bar_linux_amd64.tar.gz.tmp-123/bar",
+					Args:    []string{"init", "1.0.0"},
+				},
+			},
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "foo",
+					RepoName:  "bar",
+					Asset:     stringP("bar_{{.OS}}_{{.Arch}}.tar.gz"),
+					PostInstall: []*registry.PostInstall{
+						{
+							Command: "xattr",
+							Args:    []string{"-c", "{{.PkgPath}}/bar"},
+						},
+						{
+							Command: "./bar",
+							Args:    []string{"init", "{{trimV .Version}}"},
+						},
+					},
+				},
+			},
+		},
+		{
+			title: "command is empty",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "foo",
+					RepoName:  "bar",
+					Asset:     stringP("bar_{{.OS}}_{{.Arch}}.tar.gz"),
+					PostInstall: []*registry.PostInstall{
+						{
+							Args: []string{"init"},
+						},
+					},
+				},
+			},
+			isErr: true,
+		},
+	}
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			hooks, err := d.pkg.RenderPostInstall("/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/foo/bar/v1.0.0/bar_linux_amd64.tar.gz", "/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/foo/bar/v1.0.0/.bar_linux_amd64.tar.gz.tmp-123", rt)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, hooks); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
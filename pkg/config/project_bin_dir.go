@@ -0,0 +1,9 @@
+package config
+
+import "path/filepath"
+
+// GetProjectBinDir returns the project-local bin directory of the configuration file.
+// e.g. /home/foo/workspace/bar/aqua.yaml => /home/foo/workspace/bar/.aqua/bin
+func GetProjectBinDir(cfgFilePath string) string {
+	return filepath.Join(filepath.Dir(cfgFilePath), ".aqua", "bin")
+}
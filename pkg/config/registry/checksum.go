@@ -1,14 +1,17 @@
 package registry
 
 type Checksum struct {
-	Type         string           `json:"type,omitempty"`
-	Asset        string           `json:"asset,omitempty"`
-	URL          string           `json:"url,omitempty"`
-	FileFormat   string           `yaml:"file_format" json:"file_format,omitempty"`
-	Algorithm    string           `json:"algorithm,omitempty"`
-	Pattern      *ChecksumPattern `json:"pattern,omitempty"`
-	Enabled      *bool            `json:"enabled,omitempty"`
-	Replacements Replacements     `json:"replacements,omitempty"`
+	Type  string `json:"type,omitempty"`
+	Asset string `json:"asset,omitempty"`
+	URL   string `json:"url,omitempty"`
+	// FileFormat is detected automatically if it is empty.
+	FileFormat   string             `yaml:"file_format,omitempty" json:"file_format,omitempty" jsonschema:"enum=regexp,enum=raw,enum=gnu,enum=bsd,enum=json,enum=goreleaser"`
+	Algorithm    string             `json:"algorithm,omitempty" jsonschema:"enum=md5,enum=sha1,enum=sha256,enum=sha384,enum=sha512,enum=sha3-256,enum=blake2b,enum=blake3"`
+	Pattern      *ChecksumPattern   `json:"pattern,omitempty"`
+	Enabled      *bool              `json:"enabled,omitempty"`
+	Replacements Replacements       `json:"replacements,omitempty"`
+	Cosign       *Cosign            `json:"cosign,omitempty"`
+	Signature    *ChecksumSignature `json:"signature,omitempty"`
 }
 
 type ChecksumPattern struct {
@@ -39,3 +42,13 @@ func (chk *Checksum) GetAlgorithm() string {
 	}
 	return chk.Algorithm
 }
+
+// ChecksumSignature is a detached signature of a checksum file.
+// The signature is downloaded in the same way as the checksum file.
+type ChecksumSignature struct {
+	Type  string  `validate:"required" json:"type" jsonschema:"enum=gpg,enum=minisign"`
+	Asset *string `json:"asset,omitempty" yaml:",omitempty"`
+	URL   *string `json:"url,omitempty" yaml:",omitempty"`
+	// PublicKey is an armored GPG public key or a minisign public key.
+	PublicKey string `yaml:"public_key,omitempty" json:"public_key,omitempty"`
+}
@@ -0,0 +1,38 @@
+package registry
+
+// Cosign is the configuration to verify a file signed by Cosign.
+// If PublicKey is set, the signature is verified with the public key.
+// The public key must be written in the registry because a key downloaded from the same release as the package can be replaced together with the package.
+// Otherwise the signature is verified with the certificate issued by Sigstore (keyless signing).
+// The signature is verified only when the checksum of the file isn't known yet.
+// https://docs.sigstore.dev/cosign/verify/
+type Cosign struct {
+	Enabled                   *bool           `json:"enabled,omitempty"`
+	Signature                 *DownloadedFile `json:"signature,omitempty"`
+	Certificate               *DownloadedFile `json:"certificate,omitempty"`
+	PublicKey                 string          `yaml:"public_key,omitempty" json:"public_key,omitempty"`
+	Bundle                    *DownloadedFile `json:"bundle,omitempty"`
+	CertificateIdentity       string          `yaml:"certificate_identity,omitempty" json:"certificate_identity,omitempty" jsonschema:"example=https://github.com/aquaproj/aqua/.github/workflows/release.yaml@refs/heads/main"`
+	CertificateIdentityRegexp string          `yaml:"certificate_identity_regexp,omitempty" json:"certificate_identity_regexp,omitempty"`
+	CertificateOIDCIssuer     string          `yaml:"certificate_oidc_issuer,omitempty" json:"certificate_oidc_issuer,omitempty" jsonschema:"example=https://token.actions.githubusercontent.com"`
+}
+
+func (cos *Cosign) GetEnabled() bool {
+	if cos == nil {
+		return false
+	}
+	if cos.Enabled == nil {
+		return true
+	}
+	return *cos.Enabled
+}
+
+// DownloadedFile is a file such as a signature and a certificate downloaded to verify a package.
+// If RepoOwner and RepoName are empty, the package's repository is used.
+type DownloadedFile struct {
+	Type      string  `validate:"required" json:"type" jsonschema:"enum=github_release,enum=http"`
+	RepoOwner string  `yaml:"repo_owner,omitempty" json:"repo_owner,omitempty"`
+	RepoName  string  `yaml:"repo_name,omitempty" json:"repo_name,omitempty"`
+	Asset     *string `json:"asset,omitempty" yaml:",omitempty"`
+	URL       *string `json:"url,omitempty" yaml:",omitempty"`
+}
@@ -0,0 +1,9 @@
+package registry
+
+// Env is an environment variable which is set when commands of the package are executed.
+// The value is a template which can refer to the install path and the version of the package.
+// The name must match ^[A-Za-z_][A-Za-z0-9_]*$, and variables such as PATH, LD_PRELOAD, and DYLD_* can't be set.
+type Env struct {
+	Name  string `validate:"required" json:"name" jsonschema:"example=GOROOT,example=JAVA_HOME"`
+	Value string `json:"value" jsonschema:"example={{.PkgPath}}/go"`
+}
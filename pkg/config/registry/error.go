@@ -10,4 +10,5 @@ var (
 	errAssetRequired            = errors.New("github_release package requires asset")
 	errURLRequired              = errors.New("http package requires url")
 	errInvalidPackageType       = errors.New("package type is invalid")
+	errInvalidGitHubHost        = errors.New("github_host must be a hostname such as ghe.example.com")
 )
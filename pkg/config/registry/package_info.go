@@ -4,8 +4,11 @@ import (
 	"path"
 
 	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/util"
 	"github.com/iancoleman/orderedmap"
 	"github.com/invopop/jsonschema"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
 const (
@@ -22,6 +25,7 @@ type PackageInfo struct {
 	Type               string             `validate:"required" json:"type" jsonschema:"enum=github_release,enum=github_content,enum=github_archive,enum=http,enum=go,enum=go_install"`
 	RepoOwner          string             `yaml:"repo_owner,omitempty" json:"repo_owner,omitempty"`
 	RepoName           string             `yaml:"repo_name,omitempty" json:"repo_name,omitempty"`
+	GitHubHost         string             `yaml:"github_host,omitempty" json:"github_host,omitempty" jsonschema:"example=ghe.example.com"`
 	Asset              *string            `json:"asset,omitempty" yaml:",omitempty"`
 	Path               *string            `json:"path,omitempty" yaml:",omitempty"`
 	Format             string             `json:"format,omitempty" jsonschema:"example=tar.gz,example=raw,example=zip" yaml:",omitempty"`
@@ -44,6 +48,13 @@ type PackageInfo struct {
 	WindowsExt         string             `json:"windows_ext,omitempty" yaml:"windows_ext,omitempty"`
 	SearchWords        []string           `json:"search_words,omitempty" yaml:"search_words,omitempty"`
 	Checksum           *Checksum          `json:"checksum,omitempty"`
+	Cosign             *Cosign            `json:"cosign,omitempty"`
+	SLSAProvenance     *SLSAProvenance    `yaml:"slsa_provenance,omitempty" json:"slsa_provenance,omitempty"`
+	Envs               []*Env             `json:"envs,omitempty" yaml:",omitempty"`
+	Man                []*ShareFile       `json:"man,omitempty" yaml:",omitempty"`
+	Completions        []*Completion      `json:"completions,omitempty" yaml:",omitempty"`
+	Data               []*ShareFile       `json:"data,omitempty" yaml:",omitempty"`
+	PostInstall        []*PostInstall     `yaml:"post_install,omitempty" json:"post_install,omitempty"`
 }
 
 func (pkgInfo *PackageInfo) Copy() *PackageInfo {
@@ -52,6 +63,7 @@ func (pkgInfo *PackageInfo) Copy() *PackageInfo {
 		Type:               pkgInfo.Type,
 		RepoOwner:          pkgInfo.RepoOwner,
 		RepoName:           pkgInfo.RepoName,
+		GitHubHost:         pkgInfo.GitHubHost,
 		Asset:              pkgInfo.Asset,
 		Path:               pkgInfo.Path,
 		Format:             pkgInfo.Format,
@@ -73,6 +85,13 @@ func (pkgInfo *PackageInfo) Copy() *PackageInfo {
 		CompleteWindowsExt: pkgInfo.CompleteWindowsExt,
 		WindowsExt:         pkgInfo.WindowsExt,
 		Checksum:           pkgInfo.Checksum,
+		Cosign:             pkgInfo.Cosign,
+		SLSAProvenance:     pkgInfo.SLSAProvenance,
+		Envs:               pkgInfo.Envs,
+		Man:                pkgInfo.Man,
+		Completions:        pkgInfo.Completions,
+		Data:               pkgInfo.Data,
+		PostInstall:        pkgInfo.PostInstall,
 	}
 	return pkg
 }
@@ -136,6 +155,27 @@ func (pkgInfo *PackageInfo) overrideVersion(child *VersionOverride) *PackageInfo
 	if child.Checksum != nil {
 		pkg.Checksum = child.Checksum
 	}
+	if child.Cosign != nil {
+		pkg.Cosign = child.Cosign
+	}
+	if child.SLSAProvenance != nil {
+		pkg.SLSAProvenance = child.SLSAProvenance
+	}
+	if child.Envs != nil {
+		pkg.Envs = child.Envs
+	}
+	if child.Man != nil {
+		pkg.Man = child.Man
+	}
+	if child.Completions != nil {
+		pkg.Completions = child.Completions
+	}
+	if child.Data != nil {
+		pkg.Data = child.Data
+	}
+	if child.PostInstall != nil {
+		pkg.PostInstall = child.PostInstall
+	}
 	return pkg
 }
 
@@ -185,6 +225,22 @@ func (pkgInfo *PackageInfo) OverrideByRuntime(rt *runtime.Runtime) { //nolint:cy
 		pkgInfo.Checksum = ov.Checksum
 	}
 
+	if ov.Cosign != nil {
+		pkgInfo.Cosign = ov.Cosign
+	}
+
+	if ov.SLSAProvenance != nil {
+		pkgInfo.SLSAProvenance = ov.SLSAProvenance
+	}
+
+	if ov.Envs != nil {
+		pkgInfo.Envs = ov.Envs
+	}
+
+	if ov.PostInstall != nil {
+		pkgInfo.PostInstall = ov.PostInstall
+	}
+
 	if ov.CompleteWindowsExt != nil {
 		pkgInfo.CompleteWindowsExt = ov.CompleteWindowsExt
 	}
@@ -217,6 +273,13 @@ type VersionOverride struct {
 	CompleteWindowsExt *bool             `json:"complete_windows_ext,omitempty" yaml:"complete_windows_ext,omitempty"`
 	WindowsExt         string            `json:"windows_ext,omitempty" yaml:"windows_ext,omitempty"`
 	Checksum           *Checksum         `json:"checksum,omitempty"`
+	Cosign             *Cosign           `json:"cosign,omitempty"`
+	SLSAProvenance     *SLSAProvenance   `yaml:"slsa_provenance,omitempty" json:"slsa_provenance,omitempty"`
+	Envs               []*Env            `yaml:",omitempty" json:"envs,omitempty"`
+	Man                []*ShareFile      `yaml:",omitempty" json:"man,omitempty"`
+	Completions        []*Completion     `yaml:",omitempty" json:"completions,omitempty"`
+	Data               []*ShareFile      `yaml:",omitempty" json:"data,omitempty"`
+	PostInstall        []*PostInstall    `yaml:"post_install,omitempty" json:"post_install,omitempty"`
 }
 
 type Alias struct {
@@ -319,6 +382,15 @@ func (pkgInfo *PackageInfo) GetType() string {
 	return pkgInfo.Type
 }
 
+// GetGitHubHost returns the host of GitHub.
+// If github_host isn't set, github.com is returned.
+func (pkgInfo *PackageInfo) GetGitHubHost() string {
+	if pkgInfo.GitHubHost == "" {
+		return "github.com"
+	}
+	return pkgInfo.GitHubHost
+}
+
 func (pkgInfo *PackageInfo) GetReplacements() Replacements {
 	return pkgInfo.Replacements
 }
@@ -349,6 +421,11 @@ func (pkgInfo *PackageInfo) Validate() error { //nolint:cyclop
 	if pkgInfo.GetName() == "" {
 		return errPkgNameIsRequired
 	}
+	if pkgInfo.GitHubHost != "" && !util.IsHost(pkgInfo.GitHubHost) {
+		return logerr.WithFields(errInvalidGitHubHost, logrus.Fields{ //nolint:wrapcheck
+			"github_host": pkgInfo.GitHubHost,
+		})
+	}
 	switch pkgInfo.Type {
 	case PkgInfoTypeGitHubArchive, PkgInfoTypeGo:
 		if !pkgInfo.HasRepo() {
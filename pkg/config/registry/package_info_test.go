@@ -273,6 +273,16 @@ func TestPackageInfo_Validate(t *testing.T) { //nolint:funlen
 			pkgInfo: &registry.PackageInfo{},
 			isErr:   true,
 		},
+		{
+			title: "github_host is invalid",
+			pkgInfo: &registry.PackageInfo{
+				Type:       registry.PkgInfoTypeGitHubArchive,
+				RepoOwner:  "suzuki-shunsuke",
+				RepoName:   "ci-info",
+				GitHubHost: "../../x",
+			},
+			isErr: true,
+		},
 		{
 			title: "repo is required",
 			pkgInfo: &registry.PackageInfo{
@@ -0,0 +1,16 @@
+package registry
+
+// PostInstall is a command which is executed once after the package is installed.
+// The command and arguments are templates which can refer to the install path and the version of the package.
+// Hooks are run in a temporal directory before it is renamed to the install directory.
+// The install path refers to the final install directory, so hooks can record it,
+// but it doesn't exist yet while hooks are run.
+// Files of the package must be referred with relative paths such as ./foo,
+// because hooks are run with the temporal directory as the working directory
+// and the relative command is searched in the temporal directory.
+// Only a few environment variables such as PATH and HOME are passed to the command,
+// but the command isn't sandboxed and can access the file system and the network.
+type PostInstall struct {
+	Command string   `validate:"required" json:"command" jsonschema:"example=xattr,example=sh"`
+	Args    []string `json:"args,omitempty" yaml:",omitempty"`
+}
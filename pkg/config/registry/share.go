@@ -0,0 +1,19 @@
+package registry
+
+// ShareFile is a non-executable file in the package such as a man page and plugin data.
+// The file is linked into $AQUA_ROOT_DIR/share.
+type ShareFile struct {
+	// Name is the link name. The section of a man page is determined by the extension of the name.
+	Name string `validate:"required" json:"name" jsonschema:"example=gh.1"`
+	// Src is the file path in the package. If Src is empty, Name is used.
+	Src string `json:"src,omitempty" yaml:",omitempty" jsonschema:"example=share/man/man1/gh.1"`
+}
+
+// Completion is a shell completion script in the package.
+type Completion struct {
+	Shell string `validate:"required" json:"shell" jsonschema:"enum=bash,enum=zsh,enum=fish"`
+	// Name is the link name. e.g. gh (bash), _gh (zsh), gh.fish (fish)
+	Name string `validate:"required" json:"name"`
+	// Src is the file path in the package. If Src is empty, Name is used.
+	Src string `json:"src,omitempty" yaml:",omitempty"`
+}
@@ -0,0 +1,39 @@
+package registry
+
+// SLSAProvenance is the configuration to verify a package with SLSA Provenance.
+// https://slsa.dev/provenance/v0.2
+type SLSAProvenance struct {
+	Enabled   *bool   `json:"enabled,omitempty"`
+	Type      string  `validate:"required" json:"type" jsonschema:"enum=github_release,enum=http"`
+	RepoOwner string  `yaml:"repo_owner,omitempty" json:"repo_owner,omitempty"`
+	RepoName  string  `yaml:"repo_name,omitempty" json:"repo_name,omitempty"`
+	Asset     *string `json:"asset,omitempty" yaml:",omitempty"`
+	URL       *string `json:"url,omitempty" yaml:",omitempty"`
+	// SourceURI is the repository where the package was built.
+	// If SourceURI is empty, the package's repository is used.
+	SourceURI *string `yaml:"source_uri,omitempty" json:"source_uri,omitempty" jsonschema:"example=github.com/aquaproj/aqua"`
+	// BuilderID is the builder which created the provenance.
+	// If BuilderID is empty, builders of slsa-github-generator are trusted.
+	BuilderID string `yaml:"builder_id,omitempty" json:"builder_id,omitempty" jsonschema:"example=https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml"`
+}
+
+func (sp *SLSAProvenance) GetEnabled() bool {
+	if sp == nil {
+		return false
+	}
+	if sp.Enabled == nil {
+		return true
+	}
+	return *sp.Enabled
+}
+
+// ToDownloadedFile returns the provenance file.
+func (sp *SLSAProvenance) ToDownloadedFile() *DownloadedFile {
+	return &DownloadedFile{
+		Type:      sp.Type,
+		RepoOwner: sp.RepoOwner,
+		RepoName:  sp.RepoName,
+		Asset:     sp.Asset,
+		URL:       sp.URL,
+	}
+}
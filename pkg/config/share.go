@@ -0,0 +1,151 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+var (
+	errInvalidManPageName = errors.New("the name of the man page must have the section as the extension. e.g. gh.1")
+	errUnsupportedShell   = errors.New("the shell of the completion isn't supported")
+	errInvalidShareName   = errors.New("the name of the man page, shell completion, or data must be a file name without path separators")
+)
+
+// ShareLink is a link from $AQUA_ROOT_DIR/share to a non-executable file in the package.
+type ShareLink struct {
+	// Path is the path of the link.
+	Path string
+	// Dest is the absolute path of the file in the package.
+	Dest string
+}
+
+func GetManDir(rootDir string) string {
+	return filepath.Join(rootDir, "share", "man")
+}
+
+// GetCompletionDir returns the directory of shell completions.
+// If the shell isn't supported, an empty string is returned.
+func GetCompletionDir(rootDir, shell string) string {
+	switch shell {
+	case "bash":
+		return filepath.Join(rootDir, "share", "bash-completion", "completions")
+	case "zsh":
+		return filepath.Join(rootDir, "share", "zsh", "site-functions")
+	case "fish":
+		return filepath.Join(rootDir, "share", "fish", "vendor_completions.d")
+	default:
+		return ""
+	}
+}
+
+func GetDataDir(rootDir string) string {
+	return filepath.Join(rootDir, "share", "data")
+}
+
+// GetShareLinks returns links of man pages, shell completions, and data of the package.
+// Links point to files in the package directory, so links are updated when the package is upgraded.
+func (cpkg *Package) GetShareLinks(rootDir string, rt *runtime.Runtime) ([]*ShareLink, error) {
+	pkgInfo := cpkg.PackageInfo
+	if len(pkgInfo.Man) == 0 && len(pkgInfo.Completions) == 0 && len(pkgInfo.Data) == 0 {
+		return nil, nil
+	}
+	pkgPath, err := cpkg.GetPkgPath(rootDir, rt)
+	if err != nil {
+		return nil, fmt.Errorf("get the package install path: %w", err)
+	}
+	links := make([]*ShareLink, 0, len(pkgInfo.Man)+len(pkgInfo.Completions)+len(pkgInfo.Data))
+	for _, man := range pkgInfo.Man {
+		if err := validateShareName(man.Name); err != nil {
+			return nil, err
+		}
+		section := getManSection(man.Name)
+		if section == "" {
+			return nil, logerr.WithFields(errInvalidManPageName, logrus.Fields{ //nolint:wrapcheck
+				"man": man.Name,
+			})
+		}
+		dest, err := cpkg.renderShareSrc(pkgPath, man.Name, man.Src, rt)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, &ShareLink{
+			Path: filepath.Join(GetManDir(rootDir), "man"+section, man.Name),
+			Dest: dest,
+		})
+	}
+	for _, completion := range pkgInfo.Completions {
+		if err := validateShareName(completion.Name); err != nil {
+			return nil, err
+		}
+		dir := GetCompletionDir(rootDir, completion.Shell)
+		if dir == "" {
+			return nil, logerr.WithFields(errUnsupportedShell, logrus.Fields{ //nolint:wrapcheck
+				"shell": completion.Shell,
+			})
+		}
+		dest, err := cpkg.renderShareSrc(pkgPath, completion.Name, completion.Src, rt)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, &ShareLink{
+			Path: filepath.Join(dir, completion.Name),
+			Dest: dest,
+		})
+	}
+	for _, data := range pkgInfo.Data {
+		if err := validateShareName(data.Name); err != nil {
+			return nil, err
+		}
+		dest, err := cpkg.renderShareSrc(pkgPath, data.Name, data.Src, rt)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, &ShareLink{
+			Path: filepath.Join(GetDataDir(rootDir), data.Name),
+			Dest: dest,
+		})
+	}
+	return links, nil
+}
+
+// validateShareName validates the name of the link.
+// Links must be created in the directory of each kind, so the name must not be a path such as ../../bin/foo.
+func validateShareName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return logerr.WithFields(errInvalidShareName, logrus.Fields{ //nolint:wrapcheck
+			"name": name,
+		})
+	}
+	return nil
+}
+
+func (cpkg *Package) renderShareSrc(pkgPath, name, src string, rt *runtime.Runtime) (string, error) {
+	if src == "" {
+		return filepath.Join(pkgPath, name), nil
+	}
+	s, err := cpkg.RenderSrc(&registry.File{
+		Name: name,
+		Src:  src,
+	}, rt)
+	if err != nil {
+		return "", fmt.Errorf("render the template src: %w", err)
+	}
+	return filepath.Join(pkgPath, s), nil
+}
+
+// getManSection returns the section of the man page.
+// e.g. gh.1 => 1, gh.1.gz => 1, foo.3p => 3p
+func getManSection(name string) string {
+	section := strings.TrimPrefix(filepath.Ext(strings.TrimSuffix(name, ".gz")), ".")
+	if section == "" || section[0] < '1' || section[0] > '9' {
+		return ""
+	}
+	return section
+}
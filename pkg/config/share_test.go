@@ -0,0 +1,175 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPackage_GetShareLinks(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	pkgPath := "/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/cli/cli/v2.20.0/gh_2.20.0_linux_amd64.tar.gz"
+	data := []struct {
+		title   string
+		pkgInfo *registry.PackageInfo
+		exp     []*config.ShareLink
+		isErr   bool
+	}{
+		{
+			title: "no file",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Asset:     stringP("gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz"),
+			},
+		},
+		{
+			title: "normal",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Asset:     stringP("gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz"),
+				Man: []*registry.ShareFile{
+					{
+						Name: "gh.1",
+						Src:  "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}/share/man/man1/gh.1",
+					},
+				},
+				Completions: []*registry.Completion{
+					{
+						Shell: "zsh",
+						Name:  "_gh",
+						Src:   "completions/_gh",
+					},
+					{
+						Shell: "bash",
+						Name:  "gh",
+					},
+				},
+				Data: []*registry.ShareFile{
+					{
+						Name: "gh-plugins",
+						Src:  "plugins",
+					},
+				},
+			},
+			exp: []*config.ShareLink{
+				{
+					Path: "/home/foo/.local/share/aquaproj-aqua/share/man/man1/gh.1",
+					Dest: pkgPath + "/gh_2.20.0_linux_amd64/share/man/man1/gh.1",
+				},
+				{
+					Path: "/home/foo/.local/share/aquaproj-aqua/share/zsh/site-functions/_gh",
+					Dest: pkgPath + "/completions/_gh",
+				},
+				{
+					Path: "/home/foo/.local/share/aquaproj-aqua/share/bash-completion/completions/gh",
+					Dest: pkgPath + "/gh",
+				},
+				{
+					Path: "/home/foo/.local/share/aquaproj-aqua/share/data/gh-plugins",
+					Dest: pkgPath + "/plugins",
+				},
+			},
+		},
+		{
+			title: "man page without section",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Asset:     stringP("gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz"),
+				Man: []*registry.ShareFile{
+					{
+						Name: "gh",
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			title: "unsupported shell",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Asset:     stringP("gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz"),
+				Completions: []*registry.Completion{
+					{
+						Shell: "powershell",
+						Name:  "gh.ps1",
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			title: "data name is a path",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Asset:     stringP("gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz"),
+				Data: []*registry.ShareFile{
+					{
+						Name: "../../bin/aqua-proxy",
+						Src:  "gh",
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			title: "completion name is a parent directory",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Asset:     stringP("gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz"),
+				Completions: []*registry.Completion{
+					{
+						Shell: "zsh",
+						Name:  "..",
+					},
+				},
+			},
+			isErr: true,
+		},
+	}
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			pkg := &config.Package{
+				Package: &aqua.Package{
+					Name:    "cli/cli",
+					Version: "v2.20.0",
+				},
+				PackageInfo: d.pkgInfo,
+			}
+			links, err := pkg.GetShareLinks("/home/foo/.local/share/aquaproj-aqua", rt)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, links); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package allowpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	finder "github.com/aquaproj/aqua/pkg/config-finder"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+type Controller struct {
+	configFinder ConfigFinder
+	trustStore   TrustStore
+}
+
+type TrustStore interface {
+	Allow(cfgFilePath string) error
+}
+
+func New(configFinder ConfigFinder, trustStore TrustStore) *Controller {
+	return &Controller{
+		configFinder: configFinder,
+		trustStore:   trustStore,
+	}
+}
+
+// Allow trusts configuration files.
+// If no file is specified, configuration files found by ConfigFinder are trusted.
+func (ctrl *Controller) Allow(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	cfgFilePaths := param.Args
+	if len(cfgFilePaths) == 0 {
+		cfgFilePaths = ctrl.configFinder.Finds(param.PWD, param.ConfigFilePath)
+	}
+	if len(cfgFilePaths) == 0 {
+		return finder.ErrConfigFileNotFound
+	}
+	for _, cfgFilePath := range cfgFilePaths {
+		if err := ctrl.trustStore.Allow(cfgFilePath); err != nil {
+			return fmt.Errorf("trust the configuration file: %w", logerr.WithFields(err, logrus.Fields{
+				"config_file_path": cfgFilePath,
+			}))
+		}
+		logE.WithField("config_file_path", cfgFilePath).Info("the configuration file is trusted")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package allowpolicy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	reader "github.com/aquaproj/aqua/pkg/config-reader"
+	"github.com/aquaproj/aqua/pkg/controller/allowpolicy"
+	"github.com/aquaproj/aqua/pkg/trust"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestController_Allow(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		files map[string]string
+		found []string
+		param *config.Param
+		isErr bool
+	}{
+		{
+			name: "normal",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v3.90.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.4.1
+`,
+			},
+			found: []string{"/home/foo/workspace/aqua.yaml"},
+			param: &config.Param{
+				PWD:     "/home/foo/workspace",
+				RootDir: "/home/foo/.local/share/aquaproj-aqua",
+			},
+		},
+		{
+			name: "configuration file isn't found",
+			param: &config.Param{
+				PWD:     "/home/foo/workspace",
+				RootDir: "/home/foo/.local/share/aquaproj-aqua",
+			},
+			isErr: true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			for name, body := range d.files {
+				if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			store := trust.NewStore(fs, d.param, reader.New(fs, d.param))
+			ctrl := allowpolicy.New(&allowpolicy.MockConfigFinder{
+				Files: d.found,
+			}, store)
+			if err := ctrl.Allow(ctx, logE, d.param); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			for _, cfgFilePath := range d.found {
+				if err := store.Check(cfgFilePath); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package checkpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+type Controller struct {
+	stdout             io.Writer
+	configFinder       ConfigFinder
+	configReader       domain.ConfigReader
+	registryInstaller  domain.RegistryInstaller
+	policyConfigReader domain.PolicyConfigReader
+	policyChecker      PolicyChecker
+	runtime            *runtime.Runtime
+}
+
+type PolicyChecker interface {
+	Check(param *policy.ParamValidatePackage) ([]*policy.Violation, error)
+}
+
+func New(configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, policyConfigReader domain.PolicyConfigReader, policyChecker PolicyChecker, rt *runtime.Runtime) *Controller {
+	return &Controller{
+		stdout:             os.Stdout,
+		configFinder:       configFinder,
+		configReader:       configReader,
+		registryInstaller:  registInstaller,
+		policyConfigReader: policyConfigReader,
+		policyChecker:      policyChecker,
+		runtime:            rt,
+	}
+}
+
+// Report is outputted by `aqua policy check`.
+type Report struct {
+	Violations []*policy.Violation `json:"violations"`
+}
+
+// Check evaluates packages in configuration files against policy files and outputs the report as JSON.
+// It returns an error if any package violates policies which aren't in the audit mode.
+func (ctrl *Controller) Check(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	policyCfgs, err := ctrl.policyConfigReader.Read(ctx, logE, param.PolicyConfigFilePaths)
+	if err != nil {
+		return fmt.Errorf("read policy files: %w", err)
+	}
+
+	report := &Report{
+		Violations: []*policy.Violation{},
+	}
+	failed := false
+	for _, cfgFilePath := range ctrl.configFinder.Finds(param.PWD, param.ConfigFilePath) {
+		logE := logE.WithField("config_file_path", cfgFilePath)
+		violations, err := ctrl.check(ctx, logE, cfgFilePath, policyCfgs)
+		if err != nil {
+			logerr.WithError(logE, err).Error("check packages")
+			failed = true
+		}
+		report.Violations = append(report.Violations, violations...)
+	}
+
+	encoder := json.NewEncoder(ctrl.stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("output the report as JSON: %w", err)
+	}
+
+	if failed {
+		return errCheckFailure
+	}
+	for _, violation := range report.Violations {
+		if !violation.Audit {
+			return errPolicyViolated
+		}
+	}
+	return nil
+}
+
+func (ctrl *Controller) check(ctx context.Context, logE *logrus.Entry, cfgFilePath string, policyCfgs []*policy.Config) ([]*policy.Violation, error) {
+	cfg := &aqua.Config{}
+	if err := ctrl.configReader.Read(cfgFilePath, cfg); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	registryContents, err := ctrl.registryInstaller.InstallRegistries(ctx, cfg, cfgFilePath, logE)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	pkgs, failed := config.ListPackages(logE, cfg, ctrl.runtime, registryContents)
+	violations := []*policy.Violation{}
+	for _, pkg := range pkgs {
+		vs, err := ctrl.policyChecker.Check(&policy.ParamValidatePackage{
+			Pkg:           pkg,
+			PolicyConfigs: policyCfgs,
+		})
+		if err != nil {
+			logerr.WithError(logE, err).WithFields(logrus.Fields{
+				"package_name":    pkg.Package.Name,
+				"package_version": pkg.Package.Version,
+				"registry":        pkg.Package.Registry,
+			}).Error("evaluate policies")
+			failed = true
+			continue
+		}
+		for _, violation := range vs {
+			violation.ConfigFilePath = cfgFilePath
+			violations = append(violations, violation)
+		}
+	}
+	if failed {
+		return violations, errCheckFailure
+	}
+	return violations, nil
+}
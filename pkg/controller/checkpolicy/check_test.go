@@ -0,0 +1,140 @@
+package checkpolicy_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	reader "github.com/aquaproj/aqua/pkg/config-reader"
+	"github.com/aquaproj/aqua/pkg/controller/checkpolicy"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/download"
+	registry "github.com/aquaproj/aqua/pkg/install-registry"
+	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestController_Check(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	files := map[string]string{
+		"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: local
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+  registry: local
+`,
+		"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+	}
+	localRegistry := &policy.Registry{
+		Name: "local",
+		Type: "local",
+		Path: "/home/foo/workspace/registry.yaml",
+	}
+	data := []struct {
+		name       string
+		policyCfgs []*policy.Config
+		isErr      bool
+	}{
+		{
+			name: "allowed",
+			policyCfgs: []*policy.Config{
+				{
+					Path: "/home/foo/aqua-policy.yaml",
+					YAML: &policy.ConfigYAML{
+						Packages: []*policy.Package{
+							{
+								RegistryName: "local",
+								Registry:     localRegistry,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "not allowed",
+			policyCfgs: []*policy.Config{
+				{
+					Path: "/home/foo/aqua-policy.yaml",
+					YAML: &policy.ConfigYAML{
+						Packages: []*policy.Package{
+							{
+								Name:         "suzuki-shunsuke/tfcmt",
+								RegistryName: "local",
+								Registry:     localRegistry,
+							},
+						},
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "audit",
+			policyCfgs: []*policy.Config{
+				{
+					Path: "/home/foo/aqua-policy.yaml",
+					YAML: &policy.ConfigYAML{
+						Mode: policy.ModeAudit,
+						Packages: []*policy.Package{
+							{
+								Name:         "suzuki-shunsuke/tfcmt",
+								RegistryName: "local",
+								Registry:     localRegistry,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	registryDownloader := download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			param := &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+			}
+			fs := afero.NewMemMapFs()
+			for name, body := range files {
+				if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ctrl := checkpolicy.New(&checkpolicy.MockConfigFinder{
+				Files: []string{"/home/foo/workspace/aqua.yaml"},
+			}, reader.New(fs, param), registry.New(param, registryDownloader, fs, &domain.MockLocker{}), &domain.MockPolicyConfigReader{
+				Cfgs: d.policyCfgs,
+			}, policy.NewChecker(), rt)
+			if err := ctrl.Check(ctx, logE, param); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package checkpolicy
+
+import "errors"
+
+var (
+	errCheckFailure   = errors.New("it failed to check some packages")
+	errPolicyViolated = errors.New("some packages violate policies")
+)
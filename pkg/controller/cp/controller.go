@@ -67,7 +67,7 @@ func (ctrl *Controller) Copy(ctx context.Context, logE *logrus.Entry, param *con
 
 	ctrl.packageInstaller.SetCopyDir("")
 
-	policyCfgs, err := ctrl.policyConfigReader.Read(param.PolicyConfigFilePaths)
+	policyCfgs, err := ctrl.policyConfigReader.Read(ctx, logE, param.PolicyConfigFilePaths)
 	if err != nil {
 		return fmt.Errorf("read policy files: %w", err)
 	}
@@ -12,7 +12,6 @@ type PackageInstaller interface {
 	InstallPackages(ctx context.Context, logE *logrus.Entry, param *domain.ParamInstallPackages) error
 	SetCopyDir(copyDir string)
 	Copy(dest, src string) error
-	WaitExe(ctx context.Context, logE *logrus.Entry, exePath string) error
 }
 
 type MockPackageInstaller struct{}
@@ -31,7 +30,3 @@ func (inst *MockPackageInstaller) SetCopyDir(copyDir string) {
 func (inst *MockPackageInstaller) Copy(dest, src string) error {
 	return nil
 }
-
-func (inst *MockPackageInstaller) WaitExe(ctx context.Context, logE *logrus.Entry, exePath string) error {
-	return nil
-}
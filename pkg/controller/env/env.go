@@ -0,0 +1,121 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/util"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+type Controller struct {
+	stdout            io.Writer
+	rootDir           string
+	configFinder      ConfigFinder
+	configReader      domain.ConfigReader
+	registryInstaller domain.RegistryInstaller
+	fs                afero.Fs
+	runtime           *runtime.Runtime
+	trustChecker      domain.TrustChecker
+}
+
+func New(param *config.Param, configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, fs afero.Fs, rt *runtime.Runtime, trustChecker domain.TrustChecker) *Controller {
+	return &Controller{
+		stdout:            os.Stdout,
+		rootDir:           param.RootDir,
+		configFinder:      configFinder,
+		configReader:      configReader,
+		registryInstaller: registInstaller,
+		fs:                fs,
+		runtime:           rt,
+		trustChecker:      trustChecker,
+	}
+}
+
+// Output outputs environment variables of packages as a shell script.
+// Configuration files are searched in the same order as aqua exec,
+// so if multiple packages set the same environment variable, the package found first wins.
+func (ctrl *Controller) Output(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	envs := []string{}
+	names := map[string]struct{}{}
+	failed := false
+	add := func(cfgFilePath string) {
+		logE := logE.WithField("config_file_path", cfgFilePath)
+		es, err := ctrl.listEnvs(ctx, logE, cfgFilePath)
+		if err != nil {
+			logerr.WithError(logE, err).Error("list environment variables of packages")
+			failed = true
+		}
+		for _, env := range es {
+			name, _, _ := strings.Cut(env, "=")
+			if _, ok := names[name]; ok {
+				continue
+			}
+			names[name] = struct{}{}
+			envs = append(envs, env)
+		}
+	}
+
+	for _, cfgFilePath := range ctrl.configFinder.Finds(param.PWD, param.ConfigFilePath) {
+		if err := ctrl.trustChecker.Check(cfgFilePath); err != nil {
+			return err //nolint:wrapcheck
+		}
+		add(cfgFilePath)
+	}
+	for _, cfgFilePath := range param.GlobalConfigFilePaths {
+		if _, err := ctrl.fs.Stat(cfgFilePath); err != nil {
+			continue
+		}
+		add(cfgFilePath)
+	}
+
+	for _, env := range envs {
+		name, value, _ := strings.Cut(env, "=")
+		fmt.Fprintf(ctrl.stdout, "export %s=%s\n", name, util.QuoteShell(value))
+	}
+	if failed {
+		return errRenderEnvsFailure
+	}
+	return nil
+}
+
+func (ctrl *Controller) listEnvs(ctx context.Context, logE *logrus.Entry, cfgFilePath string) ([]string, error) {
+	cfg := &aqua.Config{}
+	if err := ctrl.configReader.Read(cfgFilePath, cfg); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	registryContents, err := ctrl.registryInstaller.InstallRegistries(ctx, cfg, cfgFilePath, logE)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	pkgs, failed := config.ListPackages(logE, cfg, ctrl.runtime, registryContents)
+	envs := []string{}
+	for _, pkg := range pkgs {
+		es, err := pkg.RenderEnvs(ctrl.rootDir, ctrl.runtime)
+		if err != nil {
+			logerr.WithError(logE, err).WithFields(logrus.Fields{
+				"package_name":    pkg.Package.Name,
+				"package_version": pkg.Package.Version,
+				"registry":        pkg.Package.Registry,
+			}).Error("render environment variables of the package")
+			failed = true
+			continue
+		}
+		envs = append(envs, es...)
+	}
+	if failed {
+		return envs, errRenderEnvsFailure
+	}
+	return envs, nil
+}
@@ -0,0 +1,177 @@
+package env
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	reader "github.com/aquaproj/aqua/pkg/config-reader"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/download"
+	registry "github.com/aquaproj/aqua/pkg/install-registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestController_Output(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name    string
+		files   map[string]string
+		cfgs    []string
+		globals []string
+		exp     string
+		isErr   bool
+	}{
+		{
+			name: "normal",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: local
+  path: registry.yaml
+packages:
+- name: golang/go@go1.20.0
+  registry: local
+- name: aquaproj/aqua-installer@v1.0.0
+  registry: local
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: http
+  repo_owner: golang
+  repo_name: go
+  url: https://golang.org/dl/{{.Version}}.{{.OS}}-{{.Arch}}.tar.gz
+  envs:
+  - name: GOROOT
+    value: "{{.PkgPath}}/go"
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+			},
+			cfgs: []string{"/home/foo/workspace/aqua.yaml"},
+			exp:  "export GOROOT='/home/foo/.local/share/aquaproj-aqua/pkgs/http/golang.org/dl/go1.20.0.linux-amd64.tar.gz/go'\n",
+		},
+		{
+			name: "the nearest configuration file wins",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: local
+  path: registry.yaml
+packages:
+- name: foo/foo@v1.0.0
+  registry: local
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: foo
+  repo_name: foo
+  path: foo
+  envs:
+  - name: FOO_VERSION
+    value: "{{.Version}}"
+`,
+				"/home/foo/aqua.yaml": `registries:
+- type: local
+  name: local
+  path: registry.yaml
+packages:
+- name: foo/foo@v2.0.0
+  registry: local
+- name: foo/bar@v1.0.0
+  registry: local
+`,
+				"/home/foo/registry.yaml": `packages:
+- type: github_content
+  repo_owner: foo
+  repo_name: foo
+  path: foo
+  envs:
+  - name: FOO_VERSION
+    value: "{{.Version}}"
+- type: github_content
+  repo_owner: foo
+  repo_name: bar
+  path: bar
+  envs:
+  - name: BAR_HOME
+    value: "/home/foo/it's bar"
+`,
+			},
+			cfgs:    []string{"/home/foo/workspace/aqua.yaml"},
+			globals: []string{"/home/foo/aqua.yaml", "/home/foo/not_found.yaml"},
+			exp:     "export FOO_VERSION='v1.0.0'\nexport BAR_HOME='/home/foo/it'\\''s bar'\n",
+		},
+		{
+			name: "invalid template",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: local
+  path: registry.yaml
+packages:
+- name: foo/foo@v1.0.0
+  registry: local
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: foo
+  repo_name: foo
+  path: foo
+  envs:
+  - name: FOO_HOME
+    value: "{{.PkgPath"
+`,
+			},
+			cfgs:  []string{"/home/foo/workspace/aqua.yaml"},
+			isErr: true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	registryDownloader := download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			param := &config.Param{
+				PWD:                   "/home/foo/workspace",
+				RootDir:               "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism:        5,
+				GlobalConfigFilePaths: d.globals,
+			}
+			fs := afero.NewMemMapFs()
+			for name, body := range d.files {
+				if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			stdout := &bytes.Buffer{}
+			ctrl := New(param, &MockConfigFinder{
+				Files: d.cfgs,
+			}, reader.New(fs, param), registry.New(param, registryDownloader, fs, &domain.MockLocker{}), fs, rt, &domain.MockTrustChecker{})
+			ctrl.stdout = stdout
+			if err := ctrl.Output(ctx, logE, param); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if s := stdout.String(); s != d.exp {
+				t.Fatalf("wanted %q, got %q", d.exp, s)
+			}
+		})
+	}
+}
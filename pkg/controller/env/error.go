@@ -0,0 +1,5 @@
+package env
+
+import "errors"
+
+var errRenderEnvsFailure = errors.New("it failed to render environment variables of some packages")
@@ -12,7 +12,6 @@ import (
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/aquaproj/aqua/pkg/domain"
 	"github.com/aquaproj/aqua/pkg/policy"
-	"github.com/aquaproj/aqua/pkg/util"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/suzuki-shunsuke/go-error-with-exit-code/ecerror"
@@ -30,15 +29,14 @@ type Controller struct {
 	enabledXSysExec    bool
 	fs                 afero.Fs
 	policyConfigReader domain.PolicyConfigReader
-	policyChecker      domain.PolicyChecker
 }
 
 type Executor interface {
-	Exec(ctx context.Context, exePath string, args []string) (int, error)
-	ExecXSys(exePath string, args []string) error
+	Exec(ctx context.Context, exePath string, args, envs []string) (int, error)
+	ExecXSys(exePath string, args, envs []string) error
 }
 
-func New(pkgInstaller domain.PackageInstaller, whichCtrl domain.WhichController, executor Executor, osEnv osenv.OSEnv, fs afero.Fs, policyConfigReader domain.PolicyConfigReader, policyChecker domain.PolicyChecker) *Controller {
+func New(pkgInstaller domain.PackageInstaller, whichCtrl domain.WhichController, executor Executor, osEnv osenv.OSEnv, fs afero.Fs, policyConfigReader domain.PolicyConfigReader) *Controller {
 	return &Controller{
 		stdin:              os.Stdin,
 		stdout:             os.Stdout,
@@ -49,7 +47,6 @@ func New(pkgInstaller domain.PackageInstaller, whichCtrl domain.WhichController,
 		enabledXSysExec:    osEnv.Getenv("AQUA_EXPERIMENTAL_X_SYS_EXEC") == "true",
 		fs:                 fs,
 		policyConfigReader: policyConfigReader,
-		policyChecker:      policyChecker,
 	}
 }
 
@@ -70,33 +67,24 @@ func (ctrl *Controller) Exec(ctx context.Context, param *config.Param, exeName s
 			"package":         findResult.Package.Package.Name,
 			"package_version": findResult.Package.Package.Version,
 		})
-		if err := ctrl.validate(findResult.Package, param.PolicyConfigFilePaths); err != nil {
-			return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+		// The package is validated with policy files in InstallPackage.
+		policyCfgs, err := ctrl.policyConfigReader.Read(ctx, logE, param.PolicyConfigFilePaths)
+		if err != nil {
+			return logerr.WithFields(fmt.Errorf("read policy files: %w", err), logrus.Fields{ //nolint:wrapcheck
 				"policy_files": param.PolicyConfigFilePaths,
 			})
 		}
-		if err := ctrl.install(ctx, logE, findResult); err != nil {
+		if err := ctrl.install(ctx, logE, findResult, policyCfgs); err != nil {
 			return err
 		}
 	}
-	return ctrl.execCommandWithRetry(ctx, findResult.ExePath, args, logE)
-}
-
-func (ctrl *Controller) validate(pkg *config.Package, policyConfigFilePaths []string) error {
-	policyCfgs, err := ctrl.policyConfigReader.Read(policyConfigFilePaths)
-	if err != nil {
-		return fmt.Errorf("read policy files: %w", err)
+	if len(findResult.ArgsPrefix) != 0 {
+		args = append(append(make([]string, 0, len(findResult.ArgsPrefix)+len(args)), findResult.ArgsPrefix...), args...)
 	}
-	if err := ctrl.policyChecker.ValidatePackage(&policy.ParamValidatePackage{
-		Pkg:           pkg,
-		PolicyConfigs: policyCfgs,
-	}); err != nil {
-		return fmt.Errorf("validate the installed package for security: %w", err)
-	}
-	return nil
+	return ctrl.execCommandWithRetry(ctx, findResult.ExePath, args, findResult.Envs, logE)
 }
 
-func (ctrl *Controller) install(ctx context.Context, logE *logrus.Entry, findResult *domain.FindResult) error {
+func (ctrl *Controller) install(ctx context.Context, logE *logrus.Entry, findResult *domain.FindResult, policyCfgs []*policy.Config) error {
 	logE = logE.WithField("exe_path", findResult.ExePath)
 
 	var checksums *checksum.Checksums
@@ -120,23 +108,11 @@ func (ctrl *Controller) install(ctx context.Context, logE *logrus.Entry, findRes
 		Pkg:             findResult.Package,
 		Checksums:       checksums,
 		RequireChecksum: findResult.Config.RequireChecksum(),
+		ChecksumConfig:  findResult.Config.Checksum,
+		PolicyConfigs:   policyCfgs,
 	}); err != nil {
 		return err //nolint:wrapcheck
 	}
-	for i := 0; i < 10; i++ {
-		logE.Debug("check if exec file exists")
-		if fi, err := ctrl.fs.Stat(findResult.ExePath); err == nil {
-			if util.IsOwnerExecutable(fi.Mode()) {
-				break
-			}
-		}
-		logE.WithFields(logrus.Fields{
-			"retry_count": i + 1,
-		}).Debug("command isn't found. wait for lazy install")
-		if err := wait(ctx, 10*time.Millisecond); err != nil { //nolint:gomnd
-			return err
-		}
-	}
 	return nil
 }
 
@@ -152,14 +128,14 @@ func wait(ctx context.Context, duration time.Duration) error {
 
 var errFailedToStartProcess = errors.New("it failed to start the process")
 
-func (ctrl *Controller) execCommand(ctx context.Context, exePath string, args []string) (bool, error) {
+func (ctrl *Controller) execCommand(ctx context.Context, exePath string, args, envs []string) (bool, error) {
 	if ctrl.enabledXSysExec {
-		if err := ctrl.executor.ExecXSys(exePath, args); err != nil {
+		if err := ctrl.executor.ExecXSys(exePath, args, envs); err != nil {
 			return true, fmt.Errorf("call execve(2): %w", err)
 		}
 		return false, nil
 	}
-	if exitCode, err := ctrl.executor.Exec(ctx, exePath, args); err != nil {
+	if exitCode, err := ctrl.executor.Exec(ctx, exePath, args, envs); err != nil {
 		// https://pkg.go.dev/os#ProcessState.ExitCode
 		// > ExitCode returns the exit code of the exited process,
 		// > or -1 if the process hasn't exited or was terminated by a signal.
@@ -171,11 +147,11 @@ func (ctrl *Controller) execCommand(ctx context.Context, exePath string, args []
 	return false, nil
 }
 
-func (ctrl *Controller) execCommandWithRetry(ctx context.Context, exePath string, args []string, logE *logrus.Entry) error {
+func (ctrl *Controller) execCommandWithRetry(ctx context.Context, exePath string, args, envs []string, logE *logrus.Entry) error {
 	logE = logE.WithField("exe_path", exePath)
 	for i := 0; i < 10; i++ {
 		logE.Debug("execute the command")
-		retried, err := ctrl.execCommand(ctx, exePath, args)
+		retried, err := ctrl.execCommand(ctx, exePath, args, envs)
 		if !retried {
 			return err
 		}
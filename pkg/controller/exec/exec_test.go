@@ -125,13 +125,13 @@ packages:
 					t.Fatal(err)
 				}
 			}
-			downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(http.DefaultClient))
+			downloader := download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
 			osEnv := osenv.NewMock(d.env)
-			whichCtrl := which.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, fs), d.rt, osEnv, fs, linker)
-			pkgDownloader := download.NewPackageDownloader(nil, d.rt, download.NewHTTPDownloader(http.DefaultClient))
+			whichCtrl := which.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, fs, &domain.MockLocker{}), d.rt, osEnv, fs, linker, &domain.MockTrustChecker{})
+			pkgDownloader := download.NewPackageDownloader(nil, nil, d.rt, download.NewHTTPDownloader(http.DefaultClient, nil))
 			executor := &exec.Mock{}
-			pkgInstaller := installpackage.New(d.param, pkgDownloader, d.rt, fs, linker, executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{})
-			ctrl := execCtrl.New(pkgInstaller, whichCtrl, executor, osEnv, fs, &domain.MockPolicyConfigReader{}, &domain.MockPolicyChecker{})
+			pkgInstaller := installpackage.New(d.param, pkgDownloader, d.rt, fs, linker, executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{}, nil, nil, nil, &domain.MockLocker{})
+			ctrl := execCtrl.New(pkgInstaller, whichCtrl, executor, osEnv, fs, &domain.MockPolicyConfigReader{})
 			if err := ctrl.Exec(ctx, d.param, d.exeName, d.args, logE); err != nil {
 				if d.isErr {
 					return
@@ -222,13 +222,13 @@ packages:
 					b.Fatal(err)
 				}
 			}
-			downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(http.DefaultClient))
+			downloader := download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
 			osEnv := osenv.NewMock(d.env)
-			whichCtrl := which.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, afero.NewOsFs()), d.rt, osEnv, fs, linker)
-			pkgDownloader := download.NewPackageDownloader(nil, d.rt, download.NewHTTPDownloader(http.DefaultClient))
+			whichCtrl := which.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, afero.NewOsFs(), &domain.MockLocker{}), d.rt, osEnv, fs, linker, &domain.MockTrustChecker{})
+			pkgDownloader := download.NewPackageDownloader(nil, nil, d.rt, download.NewHTTPDownloader(http.DefaultClient, nil))
 			executor := &exec.Mock{}
-			pkgInstaller := installpackage.New(d.param, pkgDownloader, d.rt, fs, linker, executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{})
-			ctrl := execCtrl.New(pkgInstaller, whichCtrl, executor, osEnv, fs, &domain.MockPolicyConfigReader{}, &domain.MockPolicyChecker{})
+			pkgInstaller := installpackage.New(d.param, pkgDownloader, d.rt, fs, linker, executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{}, nil, nil, nil, &domain.MockLocker{})
+			ctrl := execCtrl.New(pkgInstaller, whichCtrl, executor, osEnv, fs, &domain.MockPolicyConfigReader{})
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				func() {
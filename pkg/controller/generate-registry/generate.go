@@ -99,12 +99,16 @@ func (ctrl *Controller) getPackageInfo(ctx context.Context, logE *logrus.Entry,
 				logE.WithField("num_of_assets", len(assets)).Debug("got assets")
 				assetInfos := make([]*asset.AssetInfo, 0, len(assets))
 				pkgNameContainChecksum := strings.Contains(strings.ToLower(pkgName), "checksum")
+				assetNames := make([]string, 0, len(assets))
+				checksumFile := ""
 				for _, aset := range assets {
 					assetName := aset.GetName()
+					assetNames = append(assetNames, assetName)
 					if !pkgNameContainChecksum {
 						chksum := checksum.GetChecksumConfigFromFilename(assetName, release.GetTagName())
 						if chksum != nil {
 							pkgInfo.Checksum = chksum
+							checksumFile = assetName
 							continue
 						}
 					}
@@ -115,6 +119,16 @@ func (ctrl *Controller) getPackageInfo(ctx context.Context, logE *logrus.Entry,
 					assetInfo := asset.ParseAssetName(aset.GetName(), release.GetTagName())
 					assetInfos = append(assetInfos, assetInfo)
 				}
+				if pkgInfo.Checksum != nil {
+					// The signature can't be verified without the public key, which can't be detected.
+					// So the signature isn't outputted but reported.
+					if sig := checksum.GetSignatureConfigFromFilename(checksumFile, assetNames, release.GetTagName()); sig != nil {
+						logE.WithFields(logrus.Fields{
+							"signature_type":  sig.Type,
+							"signature_asset": *sig.Asset,
+						}).Warn("the checksum file is signed. Please configure checksum.signature with the public key by hand")
+					}
+				}
 				asset.ParseAssetInfos(pkgInfo, assetInfos)
 			}
 		}
@@ -2,4 +2,7 @@ package generate
 
 import "errors"
 
-var errUnknownPkg = errors.New("unknown package")
+var (
+	errUnknownPkg                    = errors.New("unknown package")
+	errGitHubEnterpriseIsUnavailable = errors.New("GitHub Enterprise Server isn't available")
+)
@@ -23,6 +23,7 @@ import (
 type Controller struct {
 	stdin             io.Reader
 	github            RepositoriesService
+	ghes              domain.GitHubEnterpriseClients
 	registryInstaller domain.RegistryInstaller
 	configFinder      ConfigFinder
 	configReader      domain.ConfigReader
@@ -38,6 +39,26 @@ type RepositoriesService interface {
 	ListTags(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error)
 }
 
+// getGitHub returns a GitHub API client for the package.
+// If github_host is set, a client for the GitHub Enterprise Server is returned.
+func (ctrl *Controller) getGitHub(ctx context.Context, pkgInfo *registry.PackageInfo) (RepositoriesService, error) {
+	if github.IsGitHubDotCom(pkgInfo.GitHubHost) {
+		return ctrl.github, nil
+	}
+	if ctrl.ghes == nil {
+		return nil, logerr.WithFields(errGitHubEnterpriseIsUnavailable, logrus.Fields{ //nolint:wrapcheck
+			"github_host": pkgInfo.GitHubHost,
+		})
+	}
+	gh, err := ctrl.ghes.Get(ctx, pkgInfo.GitHubHost)
+	if err != nil {
+		return nil, fmt.Errorf("get a GitHub Enterprise Server client: %w", logerr.WithFields(err, logrus.Fields{
+			"github_host": pkgInfo.GitHubHost,
+		}))
+	}
+	return gh, nil
+}
+
 type ConfigFinder interface {
 	Find(wd, configFilePath string, globalConfigFilePaths ...string) (string, error)
 }
@@ -46,13 +67,14 @@ type Outputter interface {
 	Output(param *output.Param) error
 }
 
-func New(configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, gh RepositoriesService, fs afero.Fs, fuzzyFinder FuzzyFinder, versionSelector VersionSelector) *Controller {
+func New(configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, gh RepositoriesService, ghes domain.GitHubEnterpriseClients, fs afero.Fs, fuzzyFinder FuzzyFinder, versionSelector VersionSelector) *Controller {
 	return &Controller{
 		stdin:             os.Stdin,
 		configFinder:      configFinder,
 		configReader:      configReader,
 		registryInstaller: registInstaller,
 		github:            gh,
+		ghes:              ghes,
 		fs:                fs,
 		fuzzyFinder:       fuzzyFinder,
 		versionSelector:   versionSelector,
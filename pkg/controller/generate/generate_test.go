@@ -399,12 +399,12 @@ packages:
 				Releases: d.releases,
 				Tags:     d.tags,
 			}
-			downloader := download.NewGitHubContentFileDownloader(gh, download.NewHTTPDownloader(http.DefaultClient))
-			registryInstaller := registry.New(d.param, downloader, fs)
+			downloader := download.NewGitHubContentFileDownloader(gh, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
+			registryInstaller := registry.New(d.param, downloader, fs, &domain.MockLocker{})
 			configReader := reader.New(fs, d.param)
 			fuzzyFinder := generate.NewMockFuzzyFinder(d.idxs, d.fuzzyFinderErr)
 			versionSelector := generate.NewMockVersionSelector(d.idx, d.versionSelectorErr)
-			ctrl := generate.New(configFinder, configReader, registryInstaller, gh, fs, fuzzyFinder, versionSelector)
+			ctrl := generate.New(configFinder, configReader, registryInstaller, gh, nil, fs, fuzzyFinder, versionSelector)
 			if err := ctrl.Generate(ctx, logE, d.param, d.args...); err != nil {
 				if d.isErr {
 					return
@@ -32,7 +32,15 @@ func (ctrl *Controller) selectVersionFromReleases(ctx context.Context, logE *log
 func (ctrl *Controller) getVersionFromLatestRelease(ctx context.Context, logE *logrus.Entry, pkgInfo *registry.PackageInfo) string {
 	repoOwner := pkgInfo.RepoOwner
 	repoName := pkgInfo.RepoName
-	release, _, err := ctrl.github.GetLatestRelease(ctx, repoOwner, repoName)
+	gh, err := ctrl.getGitHub(ctx, pkgInfo)
+	if err != nil {
+		logerr.WithError(logE, err).WithFields(logrus.Fields{
+			"repo_owner": repoOwner,
+			"repo_name":  repoName,
+		}).Warn("get a GitHub API client")
+		return ""
+	}
+	release, _, err := gh.GetLatestRelease(ctx, repoOwner, repoName)
 	if err != nil {
 		logerr.WithError(logE, err).WithFields(logrus.Fields{
 			"repo_owner": repoOwner,
@@ -46,6 +54,14 @@ func (ctrl *Controller) getVersionFromLatestRelease(ctx context.Context, logE *l
 func (ctrl *Controller) listReleases(ctx context.Context, logE *logrus.Entry, pkgInfo *registry.PackageInfo) []*github.RepositoryRelease { //nolint:cyclop
 	repoOwner := pkgInfo.RepoOwner
 	repoName := pkgInfo.RepoName
+	gh, err := ctrl.getGitHub(ctx, pkgInfo)
+	if err != nil {
+		logerr.WithError(logE, err).WithFields(logrus.Fields{
+			"repo_owner": repoOwner,
+			"repo_name":  repoName,
+		}).Warn("get a GitHub API client")
+		return nil
+	}
 	opt := &github.ListOptions{
 		PerPage: 100, //nolint:gomnd
 	}
@@ -59,7 +75,7 @@ func (ctrl *Controller) listReleases(ctx context.Context, logE *logrus.Entry, pk
 	}
 	var arr []*github.RepositoryRelease
 	for i := 0; i < 10; i++ {
-		releases, _, err := ctrl.github.ListReleases(ctx, repoOwner, repoName, opt)
+		releases, _, err := gh.ListReleases(ctx, repoOwner, repoName, opt)
 		if err != nil {
 			logerr.WithError(logE, err).WithFields(logrus.Fields{
 				"repo_owner": repoOwner,
@@ -90,6 +106,14 @@ func (ctrl *Controller) listReleases(ctx context.Context, logE *logrus.Entry, pk
 func (ctrl *Controller) listAndGetTagName(ctx context.Context, logE *logrus.Entry, pkgInfo *registry.PackageInfo) string {
 	repoOwner := pkgInfo.RepoOwner
 	repoName := pkgInfo.RepoName
+	gh, err := ctrl.getGitHub(ctx, pkgInfo)
+	if err != nil {
+		logerr.WithError(logE, err).WithFields(logrus.Fields{
+			"repo_owner": repoOwner,
+			"repo_name":  repoName,
+		}).Warn("get a GitHub API client")
+		return ""
+	}
 	opt := &github.ListOptions{
 		PerPage: 30, //nolint:gomnd
 	}
@@ -98,7 +122,7 @@ func (ctrl *Controller) listAndGetTagName(ctx context.Context, logE *logrus.Entr
 		return ""
 	}
 	for {
-		releases, _, err := ctrl.github.ListReleases(ctx, repoOwner, repoName, opt)
+		releases, _, err := gh.ListReleases(ctx, repoOwner, repoName, opt)
 		if err != nil {
 			logerr.WithError(logE, err).WithFields(logrus.Fields{
 				"repo_owner": repoOwner,
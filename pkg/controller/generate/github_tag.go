@@ -18,6 +18,14 @@ func (ctrl *Controller) listTags(ctx context.Context, logE *logrus.Entry, pkgInf
 	// Filter tags with version_filter
 	repoOwner := pkgInfo.RepoOwner
 	repoName := pkgInfo.RepoName
+	gh, err := ctrl.getGitHub(ctx, pkgInfo)
+	if err != nil {
+		logerr.WithError(logE, err).WithFields(logrus.Fields{
+			"repo_owner": repoOwner,
+			"repo_name":  repoName,
+		}).Warn("get a GitHub API client")
+		return nil
+	}
 	opt := &github.ListOptions{
 		PerPage: 100, //nolint:gomnd
 	}
@@ -31,7 +39,7 @@ func (ctrl *Controller) listTags(ctx context.Context, logE *logrus.Entry, pkgInf
 	}
 	var arr []*github.RepositoryTag
 	for i := 0; i < 10; i++ {
-		tags, _, err := ctrl.github.ListTags(ctx, repoOwner, repoName, opt)
+		tags, _, err := gh.ListTags(ctx, repoOwner, repoName, opt)
 		if err != nil {
 			logerr.WithError(logE, err).WithFields(logrus.Fields{
 				"repo_owner": repoOwner,
@@ -62,6 +70,14 @@ func (ctrl *Controller) listAndGetTagNameFromTag(ctx context.Context, logE *logr
 	// Get a tag
 	repoOwner := pkgInfo.RepoOwner
 	repoName := pkgInfo.RepoName
+	gh, err := ctrl.getGitHub(ctx, pkgInfo)
+	if err != nil {
+		logerr.WithError(logE, err).WithFields(logrus.Fields{
+			"repo_owner": repoOwner,
+			"repo_name":  repoName,
+		}).Warn("get a GitHub API client")
+		return ""
+	}
 	opt := &github.ListOptions{
 		PerPage: 30, //nolint:gomnd
 	}
@@ -74,7 +90,7 @@ func (ctrl *Controller) listAndGetTagNameFromTag(ctx context.Context, logE *logr
 		versionFilter = vf
 	}
 	for {
-		tags, _, err := ctrl.github.ListTags(ctx, repoOwner, repoName, opt)
+		tags, _, err := gh.ListTags(ctx, repoOwner, repoName, opt)
 		if err != nil {
 			logerr.WithError(logE, err).WithFields(logrus.Fields{
 				"repo_owner": repoOwner,
@@ -12,11 +12,25 @@ import (
 const configTemplate = `---
 # aqua Policy
 # https://aquaproj.github.io/docs/tutorial-extras/policy-as-code
+# If mode is audit, violations are logged but packages aren't denied.
+# mode: audit
 registries:
 - type: standard
   ref: semver(">= 3.0.0")
+  # post_install hooks are allowed only for the standard registry by default.
+  # Hooks run with few environment variables but aren't sandboxed, so allow them only for trusted registries.
+  # post_install: false
+# - name: local
+#   type: local
+#   path: registry.yaml
+#   post_install: true
 packages:
 - registry: standard
+# Packages matching deny are denied even if they are allowed by packages.
+# deny:
+# - name: suzuki-shunsuke/tfcmt
+#   version: semver("< 4.0.0")
+#   reason: the vulnerability is fixed in v4.0.0
 `
 
 type Controller struct {
@@ -0,0 +1,5 @@
+package install
+
+import "errors"
+
+var errListPackagesForPruneLinks = errors.New("orphaned links aren't removed because it failed to list some packages")
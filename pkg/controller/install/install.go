@@ -30,13 +30,14 @@ type Controller struct {
 	tags               map[string]struct{}
 	excludedTags       map[string]struct{}
 	policyConfigReader domain.PolicyConfigReader
+	trustChecker       domain.TrustChecker
 }
 
 type ConfigFinder interface {
 	Finds(wd, configFilePath string) []string
 }
 
-func New(param *config.Param, configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, pkgInstaller domain.PackageInstaller, fs afero.Fs, rt *runtime.Runtime, policyConfigReader domain.PolicyConfigReader) *Controller {
+func New(param *config.Param, configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, pkgInstaller domain.PackageInstaller, fs afero.Fs, rt *runtime.Runtime, policyConfigReader domain.PolicyConfigReader, trustChecker domain.TrustChecker) *Controller {
 	return &Controller{
 		rootDir:            param.RootDir,
 		configFinder:       configFinder,
@@ -49,6 +50,7 @@ func New(param *config.Param, configFinder ConfigFinder, configReader domain.Con
 		tags:               param.Tags,
 		excludedTags:       param.ExcludedTags,
 		policyConfigReader: policyConfigReader,
+		trustChecker:       trustChecker,
 	}
 }
 
@@ -69,18 +71,28 @@ func (ctrl *Controller) Install(ctx context.Context, logE *logrus.Entry, param *
 		}
 	}
 
-	policyCfgs, err := ctrl.policyConfigReader.Read(param.PolicyConfigFilePaths)
+	policyCfgs, err := ctrl.policyConfigReader.Read(ctx, logE, param.PolicyConfigFilePaths)
 	if err != nil {
 		return fmt.Errorf("read policy files: %w", err)
 	}
 
 	for _, cfgFilePath := range ctrl.configFinder.Finds(param.PWD, param.ConfigFilePath) {
-		if err := ctrl.install(ctx, logE, cfgFilePath, policyCfgs); err != nil {
+		if err := ctrl.trustChecker.Check(cfgFilePath); err != nil {
+			return err //nolint:wrapcheck
+		}
+		if err := ctrl.install(ctx, logE, cfgFilePath, policyCfgs, param.ProjectBin); err != nil {
 			return err
 		}
 	}
 
-	return ctrl.installAll(ctx, logE, param, policyCfgs)
+	if err := ctrl.installAll(ctx, logE, param, policyCfgs); err != nil {
+		return err
+	}
+
+	if param.PruneLinks {
+		return ctrl.pruneLinks(ctx, logE, param)
+	}
+	return nil
 }
 
 func (ctrl *Controller) installAll(ctx context.Context, logE *logrus.Entry, param *config.Param, policyConfigs []*policy.Config) error {
@@ -91,14 +103,14 @@ func (ctrl *Controller) installAll(ctx context.Context, logE *logrus.Entry, para
 		if _, err := ctrl.fs.Stat(cfgFilePath); err != nil {
 			continue
 		}
-		if err := ctrl.install(ctx, logE, cfgFilePath, policyConfigs); err != nil {
+		if err := ctrl.install(ctx, logE, cfgFilePath, policyConfigs, false); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (ctrl *Controller) install(ctx context.Context, logE *logrus.Entry, cfgFilePath string, policyConfigs []*policy.Config) error {
+func (ctrl *Controller) install(ctx context.Context, logE *logrus.Entry, cfgFilePath string, policyConfigs []*policy.Config, projectBin bool) error {
 	cfg := &aqua.Config{}
 	if cfgFilePath == "" {
 		return finder.ErrConfigFileNotFound
@@ -117,6 +129,7 @@ func (ctrl *Controller) install(ctx context.Context, logE *logrus.Entry, cfgFile
 		Registries:     registryContents,
 		ConfigFilePath: cfgFilePath,
 		SkipLink:       ctrl.skipLink,
+		ProjectBin:     projectBin,
 		Tags:           ctrl.tags,
 		ExcludedTags:   ctrl.excludedTags,
 		PolicyConfigs:  policyConfigs,
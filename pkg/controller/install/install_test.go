@@ -31,6 +31,9 @@ func TestController_Install(t *testing.T) { //nolint:funlen
 		param             *config.Param
 		rt                *runtime.Runtime
 		registryInstaller registry.Installer
+		removedFiles      []string
+		remainedFiles     []string
+		expLinks          map[string]string
 		isErr             bool
 	}{
 		{
@@ -61,18 +64,282 @@ packages:
 `,
 				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer":                                              ``,
 				fmt.Sprintf("/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion): ``,
-				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-installer": ``,
-				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy":     ``,
 			},
 			links: map[string]string{
-				"aqua-proxy": "/home/foo/.local/share/aquaproj-aqua/bin/aqua-installer",
-				fmt.Sprintf("../pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion): "/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy",
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-installer": "aqua-proxy",
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy":     fmt.Sprintf("../pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion),
+			},
+		},
+		{
+			name: "prune links",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+				PruneLinks:     true,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer":                                              ``,
+				fmt.Sprintf("/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion): ``,
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy": ``,
+			},
+			links: map[string]string{
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-installer": "aqua-proxy",
+				"/home/foo/.local/share/aquaproj-aqua/bin/gh":             "aqua-proxy",
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua":           "../pkgs/github_release/github.com/aquaproj/aqua/v1.32.0/aqua_linux_amd64.tar.gz/aqua",
+			},
+			removedFiles: []string{
+				"/home/foo/.local/share/aquaproj-aqua/bin/gh",
+			},
+			remainedFiles: []string{
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-installer",
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua",
+			},
+		},
+		{
+			name: "prune links dry run",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+				PruneLinks:     true,
+				DryRun:         true,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer":                                              ``,
+				fmt.Sprintf("/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion): ``,
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy": ``,
+			},
+			links: map[string]string{
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-installer": "aqua-proxy",
+				"/home/foo/.local/share/aquaproj-aqua/bin/gh":             "aqua-proxy",
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua":           "../pkgs/github_release/github.com/aquaproj/aqua/v1.32.0/aqua_linux_amd64.tar.gz/aqua",
+			},
+			remainedFiles: []string{
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-installer",
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua",
+				"/home/foo/.local/share/aquaproj-aqua/bin/gh",
+			},
+		},
+		{
+			name: "project bin",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+				ProjectBin:     true,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer":                                              ``,
+				fmt.Sprintf("/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion): ``,
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy": ``,
+				"/home/foo/workspace/.aqua/bin/gh": `#!/bin/sh
+# Generated by aqua. Don't edit this file.
+AQUA_CONFIG='/home/foo/workspace/aqua.yaml' exec aqua exec -- 'gh' "$@"
+`,
+				"/home/foo/workspace/.aqua/bin/foo": `#!/bin/sh
+echo foo
+`,
+			},
+			removedFiles: []string{
+				"/home/foo/workspace/.aqua/bin/gh",
+			},
+			remainedFiles: []string{
+				"/home/foo/workspace/.aqua/bin/aqua-installer",
+				"/home/foo/workspace/.aqua/bin/foo",
+			},
+		},
+		{
+			name: "stale shims aren't removed if it failed to list packages",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+				ProjectBin:     true,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+- name: cli/cli@v2.17.0
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer":                                              ``,
+				fmt.Sprintf("/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion): ``,
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy": ``,
+				"/home/foo/workspace/.aqua/bin/gh": `#!/bin/sh
+# Generated by aqua. Don't edit this file.
+AQUA_CONFIG='/home/foo/workspace/aqua.yaml' exec aqua exec -- 'gh' "$@"
+`,
+			},
+			remainedFiles: []string{
+				"/home/foo/workspace/.aqua/bin/aqua-installer",
+				"/home/foo/workspace/.aqua/bin/gh",
+			},
+			isErr: true,
+		},
+		{
+			name: "share links",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.1.0
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+  man:
+  - name: aqua-installer.1
+  completions:
+  - shell: zsh
+    name: _aqua-installer
+`,
+				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.1.0/aqua-installer/aqua-installer":                                              ``,
+				fmt.Sprintf("/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion): ``,
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy": ``,
+			},
+			links: map[string]string{
+				// The link was created by the old version.
+				"/home/foo/.local/share/aquaproj-aqua/share/man/man1/aqua-installer.1": "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer.1",
+			},
+			expLinks: map[string]string{
+				"/home/foo/.local/share/aquaproj-aqua/share/man/man1/aqua-installer.1":          "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.1.0/aqua-installer/aqua-installer.1",
+				"/home/foo/.local/share/aquaproj-aqua/share/zsh/site-functions/_aqua-installer": "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.1.0/aqua-installer/_aqua-installer",
+			},
+		},
+		{
+			name: "prune share links",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+				PruneLinks:     true,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.1.0
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+  man:
+  - name: aqua-installer.1
+`,
+				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.1.0/aqua-installer/aqua-installer":                                              ``,
+				fmt.Sprintf("/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/aquaproj/aqua-proxy/%s/aqua-proxy_linux_amd64.tar.gz/aqua-proxy", installpackage.ProxyVersion): ``,
+				"/home/foo/.local/share/aquaproj-aqua/bin/aqua-proxy": ``,
+			},
+			links: map[string]string{
+				// The completion was removed from the new version.
+				"/home/foo/.local/share/aquaproj-aqua/share/zsh/site-functions/_aqua-installer": "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/_aqua-installer",
+				// The link isn't managed by aqua.
+				"/home/foo/.local/share/aquaproj-aqua/share/zsh/site-functions/_foo": "/usr/share/zsh/site-functions/_foo",
+			},
+			removedFiles: []string{
+				"/home/foo/.local/share/aquaproj-aqua/share/zsh/site-functions/_aqua-installer",
+			},
+			remainedFiles: []string{
+				"/home/foo/.local/share/aquaproj-aqua/share/man/man1/aqua-installer.1",
+				"/home/foo/.local/share/aquaproj-aqua/share/zsh/site-functions/_foo",
 			},
 		},
 	}
 	logE := logrus.NewEntry(logrus.New())
 	ctx := context.Background()
-	registryDownloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(http.DefaultClient))
+	registryDownloader := download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
 	for _, d := range data {
 		d := d
 		t.Run(d.name, func(t *testing.T) {
@@ -84,24 +351,46 @@ packages:
 				}
 			}
 			linker := domain.NewMockLinker(fs)
-			for dest, src := range d.links {
+			for src, dest := range d.links {
 				if err := linker.Symlink(dest, src); err != nil {
 					t.Fatal(err)
 				}
 			}
-			downloader := download.NewPackageDownloader(nil, d.rt, download.NewHTTPDownloader(http.DefaultClient))
+			downloader := download.NewPackageDownloader(nil, nil, d.rt, download.NewHTTPDownloader(http.DefaultClient, nil))
 			executor := &exec.Mock{}
-			pkgInstaller := installpackage.New(d.param, downloader, d.rt, fs, linker, executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{})
-			ctrl := install.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, registryDownloader, fs), pkgInstaller, fs, d.rt, &domain.MockPolicyConfigReader{})
-			if err := ctrl.Install(ctx, logE, d.param); err != nil {
-				if d.isErr {
-					return
-				}
+			pkgInstaller := installpackage.New(d.param, downloader, d.rt, fs, linker, executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{}, nil, nil, nil, &domain.MockLocker{})
+			ctrl := install.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, registryDownloader, fs, &domain.MockLocker{}), pkgInstaller, fs, d.rt, &domain.MockPolicyConfigReader{}, &domain.MockTrustChecker{})
+			// Files are checked even if an error is returned.
+			err := ctrl.Install(ctx, logE, d.param)
+			if err != nil && !d.isErr {
 				t.Fatal(err)
 			}
-			if d.isErr {
+			if err == nil && d.isErr {
 				t.Fatal("error must be returned")
 			}
+			for _, p := range d.removedFiles {
+				if f, err := afero.Exists(fs, p); err != nil {
+					t.Fatal(err)
+				} else if f {
+					t.Fatalf("%s must be removed", p)
+				}
+			}
+			for src, exp := range d.expLinks {
+				dest, err := linker.Readlink(src)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if dest != exp {
+					t.Fatalf("%s: wanted %s, got %s", src, exp, dest)
+				}
+			}
+			for _, p := range d.remainedFiles {
+				if f, err := afero.Exists(fs, p); err != nil {
+					t.Fatal(err)
+				} else if !f {
+					t.Fatalf("%s must not be removed", p)
+				}
+			}
 		})
 	}
 }
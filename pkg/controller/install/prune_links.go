@@ -0,0 +1,77 @@
+package install
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// pruneLinks removes links of commands and links in $AQUA_ROOT_DIR/share which aren't provided by local and global configuration files.
+// If it fails to list packages of any configuration file, no link is removed.
+func (ctrl *Controller) pruneLinks(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	cfgFilePaths := ctrl.configFinder.Finds(param.PWD, param.ConfigFilePath)
+	for _, cfgFilePath := range param.GlobalConfigFilePaths {
+		if _, err := ctrl.fs.Stat(cfgFilePath); err != nil {
+			continue
+		}
+		cfgFilePaths = append(cfgFilePaths, cfgFilePath)
+	}
+
+	commands := map[string]struct{}{}
+	shareLinks := map[string]struct{}{}
+	for _, cfgFilePath := range cfgFilePaths {
+		if err := ctrl.listCommands(ctx, logE.WithField("config_file_path", cfgFilePath), cfgFilePath, commands, shareLinks); err != nil {
+			return err
+		}
+	}
+
+	orphans, err := ctrl.packageInstaller.PruneLinks(logE, commands, param.DryRun)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	shareOrphans, err := ctrl.packageInstaller.PruneShareLinks(logE, shareLinks, param.DryRun)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if len(orphans) == 0 && len(shareOrphans) == 0 {
+		logE.Debug("no orphaned link is found")
+	}
+	return nil
+}
+
+// listCommands adds commands and paths of links in $AQUA_ROOT_DIR/share of packages to commands and shareLinks.
+func (ctrl *Controller) listCommands(ctx context.Context, logE *logrus.Entry, cfgFilePath string, commands, shareLinks map[string]struct{}) error {
+	cfg := &aqua.Config{}
+	if err := ctrl.configReader.Read(cfgFilePath, cfg); err != nil {
+		return err //nolint:wrapcheck
+	}
+	registryContents, err := ctrl.registryInstaller.InstallRegistries(ctx, cfg, cfgFilePath, logE)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	pkgs, failed := config.ListPackages(logE, cfg, ctrl.runtime, registryContents)
+	if failed {
+		return logerr.WithFields(errListPackagesForPruneLinks, logrus.Fields{ //nolint:wrapcheck
+			"config_file_path": cfgFilePath,
+		})
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.PackageInfo.GetFiles() {
+			commands[file.Name] = struct{}{}
+		}
+		links, err := pkg.GetShareLinks(ctrl.rootDir, ctrl.runtime)
+		if err != nil {
+			return logerr.WithFields(fmt.Errorf("get links of man pages, shell completions, and data: %w", err), logrus.Fields{ //nolint:wrapcheck
+				"package_name": pkg.Package.Name,
+			})
+		}
+		for _, link := range links {
+			shareLinks[link.Path] = struct{}{}
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	finder "github.com/aquaproj/aqua/pkg/config-finder"
 	reader "github.com/aquaproj/aqua/pkg/config-reader"
 	"github.com/aquaproj/aqua/pkg/controller/list"
+	"github.com/aquaproj/aqua/pkg/domain"
 	"github.com/aquaproj/aqua/pkg/download"
 	registry "github.com/aquaproj/aqua/pkg/install-registry"
 	"github.com/sirupsen/logrus"
@@ -50,7 +51,7 @@ packages:
 	}
 	logE := logrus.NewEntry(logrus.New())
 	ctx := context.Background()
-	downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(http.DefaultClient))
+	downloader := download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
 	for _, d := range data {
 		d := d
 		t.Run(d.name, func(t *testing.T) {
@@ -61,7 +62,7 @@ packages:
 					t.Fatal(err)
 				}
 			}
-			ctrl := list.NewController(finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, fs))
+			ctrl := list.NewController(finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, fs, &domain.MockLocker{}))
 			if err := ctrl.List(ctx, d.param, logE); err != nil {
 				if d.isErr {
 					return
@@ -0,0 +1,60 @@
+package shellenv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/util"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+type Controller struct {
+	stdout       io.Writer
+	configFinder ConfigFinder
+	fs           afero.Fs
+	trustChecker domain.TrustChecker
+}
+
+func New(configFinder ConfigFinder, fs afero.Fs, trustChecker domain.TrustChecker) *Controller {
+	return &Controller{
+		stdout:       os.Stdout,
+		configFinder: configFinder,
+		fs:           fs,
+		trustChecker: trustChecker,
+	}
+}
+
+// Output outputs a shell script to add project-local bin directories to the environment variable PATH.
+// Directories are ordered by priority, so the directory of the nearest configuration file comes first.
+// Directories which don't exist are ignored.
+// Configuration files must be trusted because shims in the directories are executed via PATH.
+func (ctrl *Controller) Output(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	dirs := []string{}
+	m := map[string]struct{}{}
+	for _, cfgFilePath := range ctrl.configFinder.Finds(param.PWD, param.ConfigFilePath) {
+		if err := ctrl.trustChecker.Check(cfgFilePath); err != nil {
+			return err //nolint:wrapcheck
+		}
+		binDir := config.GetProjectBinDir(cfgFilePath)
+		if _, ok := m[binDir]; ok {
+			continue
+		}
+		m[binDir] = struct{}{}
+		if ok, err := afero.DirExists(ctrl.fs, binDir); err != nil || !ok {
+			logE.WithField("project_bin_dir", binDir).Debug("the project bin directory isn't found")
+			continue
+		}
+		dirs = append(dirs, binDir)
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	fmt.Fprintf(ctrl.stdout, "export PATH=%s:\"$PATH\"\n", util.QuoteShell(strings.Join(dirs, ":")))
+	return nil
+}
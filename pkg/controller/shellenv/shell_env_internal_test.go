@@ -0,0 +1,88 @@
+package shellenv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestController_Output(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name         string
+		files        []string
+		dirs         []string
+		trustChecker domain.TrustChecker
+		exp          string
+		isErr        bool
+	}{
+		{
+			name:  "normal",
+			files: []string{"/home/foo/workspace/bar/aqua.yaml", "/home/foo/workspace/aqua.yaml", "/home/foo/aqua.yaml"},
+			dirs:  []string{"/home/foo/workspace/bar/.aqua/bin", "/home/foo/.aqua/bin"},
+			exp:   "export PATH='/home/foo/workspace/bar/.aqua/bin:/home/foo/.aqua/bin':\"$PATH\"\n",
+		},
+		{
+			name:  "quote",
+			files: []string{"/home/foo/it's/aqua.yaml"},
+			dirs:  []string{"/home/foo/it's/.aqua/bin"},
+			exp:   "export PATH='/home/foo/it'\\''s/.aqua/bin':\"$PATH\"\n",
+		},
+		{
+			name:  "untrusted configuration file",
+			files: []string{"/home/foo/workspace/aqua.yaml"},
+			dirs:  []string{"/home/foo/workspace/.aqua/bin"},
+			trustChecker: &domain.MockTrustChecker{
+				Err: errors.New("the configuration file isn't trusted"),
+			},
+			isErr: true,
+		},
+		{
+			name:  "no project bin directory",
+			files: []string{"/home/foo/workspace/aqua.yaml"},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			for _, dir := range d.dirs {
+				if err := fs.MkdirAll(dir, 0o775); err != nil {
+					t.Fatal(err)
+				}
+			}
+			trustChecker := d.trustChecker
+			if trustChecker == nil {
+				trustChecker = &domain.MockTrustChecker{}
+			}
+			stdout := &bytes.Buffer{}
+			ctrl := &Controller{
+				stdout:       stdout,
+				configFinder: &MockConfigFinder{Files: d.files},
+				fs:           fs,
+				trustChecker: trustChecker,
+			}
+			if err := ctrl.Output(ctx, logE, &config.Param{}); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if s := stdout.String(); s != d.exp {
+				t.Fatalf("wanted %q, got %q", d.exp, s)
+			}
+		})
+	}
+}
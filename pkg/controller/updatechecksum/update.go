@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 
+	verifier "github.com/aquaproj/aqua/pkg/artifact-verifier"
 	"github.com/aquaproj/aqua/pkg/checksum"
 	"github.com/aquaproj/aqua/pkg/config"
 	finder "github.com/aquaproj/aqua/pkg/config-finder"
@@ -27,10 +28,12 @@ type Controller struct {
 	chkDL             domain.ChecksumDownloader
 	parser            *checksum.FileParser
 	pkgDownloader     domain.PackageDownloader
+	verifier          *verifier.Verifier
 	deep              bool
+	prune             bool
 }
 
-func New(param *config.Param, configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, fs afero.Fs, rt *runtime.Runtime, chkDL domain.ChecksumDownloader, pkgDownloader domain.PackageDownloader) *Controller {
+func New(param *config.Param, configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, fs afero.Fs, rt *runtime.Runtime, chkDL domain.ChecksumDownloader, pkgDownloader domain.PackageDownloader, cosignVerifier verifier.CosignVerifier, slsaVerifier verifier.SLSAVerifier, signatureVerifier verifier.SignatureVerifier) *Controller {
 	return &Controller{
 		rootDir:           param.RootDir,
 		configFinder:      configFinder,
@@ -41,7 +44,9 @@ func New(param *config.Param, configFinder ConfigFinder, configReader domain.Con
 		chkDL:             chkDL,
 		parser:            &checksum.FileParser{},
 		pkgDownloader:     pkgDownloader,
+		verifier:          verifier.New(cosignVerifier, slsaVerifier, signatureVerifier),
 		deep:              param.Deep,
+		prune:             param.Prune,
 	}
 }
 
@@ -92,13 +97,15 @@ func (ctrl *Controller) updateChecksum(ctx context.Context, logE *logrus.Entry,
 	if err := checksums.ReadFile(ctrl.fs, checksumFilePath); err != nil {
 		return fmt.Errorf("read a checksum JSON: %w", err)
 	}
-	pkgs, _ := config.ListPackagesNotOverride(logE, cfg, registryContents)
-	failed := false
+	pkgs, listFailed := config.ListPackagesNotOverride(logE, cfg, registryContents)
+	failed := listFailed
 	defer func() {
 		if err := checksums.UpdateFile(ctrl.fs, checksumFilePath); err != nil {
 			namedErr = fmt.Errorf("update a checksum file: %w", err)
 		}
 	}()
+	// checksum IDs of all packages in the configuration file
+	checksumIDs := map[string]struct{}{}
 	for _, pkg := range pkgs {
 		logE := logE.WithFields(logrus.Fields{
 			"package_name":     pkg.Package.Name,
@@ -106,7 +113,7 @@ func (ctrl *Controller) updateChecksum(ctx context.Context, logE *logrus.Entry,
 			"package_registry": pkg.Package.Registry,
 		})
 		logE.Info("updating a package checksum")
-		if err := ctrl.updatePackage(ctx, logE, checksums, pkg); err != nil {
+		if err := ctrl.updatePackage(ctx, logE, checksums, pkg, checksumIDs); err != nil {
 			failed = true
 			logerr.WithError(logE, err).Error("update checksums")
 		}
@@ -114,17 +121,22 @@ func (ctrl *Controller) updateChecksum(ctx context.Context, logE *logrus.Entry,
 	if failed {
 		return errFailedToUpdateChecksum
 	}
+	if ctrl.prune {
+		for _, id := range checksums.Prune(checksumIDs) {
+			logE.WithField("checksum_id", id).Info("remove a checksum of a package which isn't in the configuration file")
+		}
+	}
 	return nil
 }
 
-func (ctrl *Controller) updatePackage(ctx context.Context, logE *logrus.Entry, checksums *checksum.Checksums, pkg *config.Package) error {
-	if err := ctrl.getChecksums(ctx, logE, checksums, pkg); err != nil {
+func (ctrl *Controller) updatePackage(ctx context.Context, logE *logrus.Entry, checksums *checksum.Checksums, pkg *config.Package, checksumIDs map[string]struct{}) error {
+	if err := ctrl.getChecksums(ctx, logE, checksums, pkg, checksumIDs); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (ctrl *Controller) getChecksums(ctx context.Context, logE *logrus.Entry, checksums *checksum.Checksums, pkg *config.Package) error {
+func (ctrl *Controller) getChecksums(ctx context.Context, logE *logrus.Entry, checksums *checksum.Checksums, pkg *config.Package, checksumIDs map[string]struct{}) error {
 	rts, err := runtime.GetRuntimesFromEnvs(pkg.PackageInfo.SupportedEnvs)
 	if err != nil {
 		return fmt.Errorf("get supported platforms: %w", err)
@@ -135,30 +147,64 @@ func (ctrl *Controller) getChecksums(ctx context.Context, logE *logrus.Entry, ch
 		logE := logE.WithFields(logrus.Fields{
 			"checksum_env": rt.GOOS + "/" + rt.GOARCH,
 		})
-		if err := ctrl.getChecksum(ctx, logE, checksums, pkg, checksumFiles, rt); err != nil {
+		if err := ctrl.getChecksum(ctx, logE, checksums, getPackageByRuntime(pkg, rt), checksumFiles, rt); err != nil {
+			return err
+		}
+	}
+	return ctrl.fillChecksums(ctx, logE, checksums, pkg, rts, checksumIDs)
+}
+
+// fillChecksums fills checksums which can't be got from checksum files.
+// If --deep option isn't set, platforms whose checksums are missing are reported.
+func (ctrl *Controller) fillChecksums(ctx context.Context, logE *logrus.Entry, checksums *checksum.Checksums, pkg *config.Package, rts []*runtime.Runtime, checksumIDs map[string]struct{}) error {
+	var missingEnvs []string
+	for _, rt := range rts {
+		rt := rt
+		pkg := getPackageByRuntime(pkg, rt)
+		checksumID, err := pkg.GetChecksumID(rt)
+		if err != nil {
+			return fmt.Errorf("get a checksum id: %w", err)
+		}
+		if checksumID == "" {
+			continue
+		}
+		checksumIDs[checksumID] = struct{}{}
+		if a := checksums.Get(checksumID); a != nil {
+			continue
+		}
+		if !ctrl.deep {
+			missingEnvs = append(missingEnvs, rt.GOOS+"/"+rt.GOARCH)
+			continue
+		}
+		logE := logE.WithFields(logrus.Fields{
+			"checksum_env": rt.GOOS + "/" + rt.GOARCH,
+		})
+		if err := ctrl.dlAssetAndGetChecksum(ctx, logE, checksums, pkg, rt); err != nil {
 			return err
 		}
 	}
+	if len(missingEnvs) != 0 {
+		logE.WithFields(logrus.Fields{
+			"missing_envs": strings.Join(missingEnvs, ", "),
+		}).Warn("checksums of some platforms aren't found. If --deep option is set, aqua downloads assets and calculates checksums")
+	}
 	return nil
 }
 
-func (ctrl *Controller) getChecksum(ctx context.Context, logE *logrus.Entry, checksums *checksum.Checksums, pkg *config.Package, checksumFiles map[string]struct{}, rt *runtime.Runtime) error { //nolint:funlen,cyclop
-	pkgInfo := pkg.PackageInfo
-	pkgInfo = pkgInfo.Copy()
+func getPackageByRuntime(pkg *config.Package, rt *runtime.Runtime) *config.Package {
+	pkgInfo := pkg.PackageInfo.Copy()
 	pkgInfo.OverrideByRuntime(rt)
-	pkg = &config.Package{
+	return &config.Package{
 		Package:     pkg.Package,
 		PackageInfo: pkgInfo,
 	}
+}
 
-	if !pkg.PackageInfo.Checksum.GetEnabled() {
-		if !ctrl.deep {
-			logE.Debug("chekcsum isn't supported")
-			return nil
-		}
-		if err := ctrl.dlAssetAndGetChecksum(ctx, logE, checksums, pkg, rt); err != nil {
-			return err
-		}
+func (ctrl *Controller) getChecksum(ctx context.Context, logE *logrus.Entry, checksums *checksum.Checksums, pkg *config.Package, checksumFiles map[string]struct{}, rt *runtime.Runtime) error { //nolint:funlen,cyclop
+	pkgInfo := pkg.PackageInfo
+
+	if !pkgInfo.Checksum.GetEnabled() {
+		logE.Debug("chekcsum isn't supported")
 		return nil
 	}
 
@@ -167,7 +213,8 @@ func (ctrl *Controller) getChecksum(ctx context.Context, logE *logrus.Entry, che
 		return fmt.Errorf("get a checksum id: %w", err)
 	}
 
-	if a := checksums.Get(checksumID); a != nil {
+	// If the checksum of the other algorithm is recorded, the checksum of the registry's algorithm is added.
+	if a := checksums.GetByAlgorithm(checksumID, pkgInfo.Checksum.GetAlgorithm()); a != nil {
 		return nil
 	}
 
@@ -192,19 +239,10 @@ func (ctrl *Controller) getChecksum(ctx context.Context, logE *logrus.Entry, che
 	if err != nil {
 		return fmt.Errorf("read a checksum file: %w", err)
 	}
-	checksumFile := strings.TrimSpace(string(b))
-	if pkgInfo.Checksum.FileFormat == "raw" {
-		logE.WithFields(logrus.Fields{
-			"checksum_id": checksumID,
-			"checksum":    checksumFile,
-		}).Debug("set a checksum")
-		checksums.Set(checksumID, &checksum.Checksum{
-			ID:        checksumID,
-			Checksum:  checksumFile,
-			Algorithm: pkgInfo.Checksum.GetAlgorithm(),
-		})
-		return nil
+	if err := ctrl.verifier.VerifyChecksumFile(ctx, logE, rt, pkg, b); err != nil {
+		return fmt.Errorf("verify a checksum file: %w", err)
 	}
+	checksumFile := strings.TrimSpace(string(b))
 	m, s, err := ctrl.parser.ParseChecksumFile(checksumFile, pkg)
 	if err != nil {
 		return fmt.Errorf("parse a checksum file: %w", err)
@@ -266,16 +304,56 @@ func (ctrl *Controller) dlAssetAndGetChecksum(ctx context.Context, logE *logrus.
 		return fmt.Errorf("download an asset: %w", err)
 	}
 	defer file.Close()
+
+	// The asset is stored in a temporal file so that it can be verified with Cosign and SLSA Provenance
+	// before the checksum is recorded.
+	tempFile, err := afero.TempFile(ctrl.fs, "", "")
+	if err != nil {
+		return fmt.Errorf("create a temporal file: %w", err)
+	}
+	defer ctrl.fs.Remove(tempFile.Name()) //nolint:errcheck
+	defer tempFile.Close()
+
 	algorithm := "sha512"
 	fields["algorithm"] = algorithm
-	chk, err := checksum.CalculateReader(file, algorithm)
+	chk, err := checksum.CalculateReader(io.TeeReader(file, tempFile), algorithm)
 	if err != nil {
 		return fmt.Errorf("calculate an asset: %w", err)
 	}
-	checksums.Set(checksumID, &checksum.Checksum{
+
+	pkgInfo := pkg.PackageInfo
+	if pkgInfo.Cosign.GetEnabled() {
+		if err := rewind(tempFile); err != nil {
+			return err
+		}
+		if err := ctrl.verifier.VerifyWithCosign(ctx, logE, rt, pkg, pkgInfo.Cosign, tempFile); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	chksum := &checksum.Checksum{
 		ID:        checksumID,
 		Checksum:  chk,
 		Algorithm: algorithm,
-	})
+	}
+	if pkgInfo.SLSAProvenance.GetEnabled() {
+		if err := rewind(tempFile); err != nil {
+			return err
+		}
+		sp, err := ctrl.verifier.VerifySLSAProvenance(ctx, logE, rt, pkg, tempFile)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		chksum.SetSLSAProvenance(sp)
+	}
+
+	checksums.Set(checksumID, chksum)
+	return nil
+}
+
+func rewind(file afero.File) error {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek a temporal file: %w", err)
+	}
 	return nil
 }
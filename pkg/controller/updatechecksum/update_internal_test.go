@@ -0,0 +1,197 @@
+package updatechecksum
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	verifier "github.com/aquaproj/aqua/pkg/artifact-verifier"
+	"github.com/aquaproj/aqua/pkg/checksum"
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/installpackage"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/slsa"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/afero"
+)
+
+func strP(s string) *string {
+	return &s
+}
+
+func TestController_fillChecksums(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	darwinID := "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz"
+	linuxID := "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_linux_amd64.tar.gz"
+	darwinChecksum := &checksum.Checksum{
+		ID:        darwinID,
+		Checksum:  "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+		Algorithm: "sha256",
+	}
+	data := []struct {
+		name          string
+		deep          bool
+		pkgDownloader domain.PackageDownloader
+		cosign        *registry.Cosign
+		slsa          *registry.SLSAProvenance
+		cosignVerif   verifier.CosignVerifier
+		slsaVerif     verifier.SLSAVerifier
+		// exp is checksums of linux/amd64.
+		exp         []*checksum.Checksum
+		missingEnvs string
+		isErr       bool
+	}{
+		{
+			name:          "missing envs are reported",
+			pkgDownloader: &domain.MockPackageDownloader{},
+			missingEnvs:   "linux/amd64",
+		},
+		{
+			name: "deep",
+			deep: true,
+			pkgDownloader: &domain.MockPackageDownloader{
+				Body: "foo",
+			},
+			exp: []*checksum.Checksum{
+				{
+					ID:        linuxID,
+					Checksum:  "f7fbba6e0636f890e56fbbf3283e524c6fa3204ae298382d624741d0dc6638326e282c41be5e4254d8820772c5518a2c5a8c0c7f7eda19594a7eb539453e1ed7",
+					Algorithm: "sha512",
+				},
+			},
+		},
+		{
+			name: "deep with slsa provenance",
+			deep: true,
+			pkgDownloader: &domain.MockPackageDownloader{
+				Body: "foo",
+			},
+			slsa: &registry.SLSAProvenance{
+				Type:  "github_release",
+				Asset: strP("multiple.intoto.jsonl"),
+			},
+			slsaVerif: &installpackage.MockSLSAVerifier{
+				Result: &slsa.Result{
+					SourceURI: "github.com/cli/cli",
+					BuilderID: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.4.0",
+				},
+			},
+			exp: []*checksum.Checksum{
+				{
+					ID:        linuxID,
+					Checksum:  "f7fbba6e0636f890e56fbbf3283e524c6fa3204ae298382d624741d0dc6638326e282c41be5e4254d8820772c5518a2c5a8c0c7f7eda19594a7eb539453e1ed7",
+					Algorithm: "sha512",
+					Metadata: &checksum.Metadata{
+						SLSAProvenance: &checksum.SLSAProvenance{
+							SourceURI: "github.com/cli/cli",
+							BuilderID: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.4.0",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "deep with invalid cosign signature",
+			deep: true,
+			pkgDownloader: &domain.MockPackageDownloader{
+				Body: "foo",
+			},
+			cosign: &registry.Cosign{
+				Signature: &registry.DownloadedFile{
+					Type:  "github_release",
+					Asset: strP("{{.Asset}}.sig"),
+				},
+			},
+			cosignVerif: &installpackage.MockCosignVerifier{
+				Err: errors.New("the signature is invalid"),
+			},
+			isErr: true,
+		},
+		{
+			name: "failed to download an asset",
+			deep: true,
+			pkgDownloader: &domain.MockPackageDownloader{
+				Err: errors.New("not found"),
+			},
+			isErr: true,
+		},
+	}
+	pkg := &config.Package{
+		Package: &aqua.Package{
+			Name:     "cli/cli",
+			Version:  "v2.17.0",
+			Registry: "standard",
+		},
+		PackageInfo: &registry.PackageInfo{
+			Type:      "github_release",
+			RepoOwner: "cli",
+			RepoName:  "cli",
+			Asset:     strP("gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz"),
+		},
+	}
+	rts := []*runtime.Runtime{
+		{
+			GOOS:   "darwin",
+			GOARCH: "arm64",
+		},
+		{
+			GOOS:   "linux",
+			GOARCH: "amd64",
+		},
+	}
+	ctx := context.Background()
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := &Controller{
+				fs:            afero.NewMemMapFs(),
+				pkgDownloader: d.pkgDownloader,
+				verifier:      verifier.New(d.cosignVerif, d.slsaVerif, nil),
+				deep:          d.deep,
+			}
+			pkg := &config.Package{
+				Package:     pkg.Package,
+				PackageInfo: pkg.PackageInfo.Copy(),
+			}
+			pkg.PackageInfo.Cosign = d.cosign
+			pkg.PackageInfo.SLSAProvenance = d.slsa
+			logger, hook := test.NewNullLogger()
+			checksums := checksum.New()
+			checksums.Set(darwinID, darwinChecksum)
+			checksumIDs := map[string]struct{}{}
+			if err := ctrl.fillChecksums(ctx, logrus.NewEntry(logger), checksums, pkg, rts, checksumIDs); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(map[string]struct{}{darwinID: {}, linuxID: {}}, checksumIDs); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff([]*checksum.Checksum{darwinChecksum}, checksums.GetAll(darwinID)); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(d.exp, checksums.GetAll(linuxID)); diff != "" {
+				t.Fatal(diff)
+			}
+			missingEnvs := ""
+			for _, entry := range hook.AllEntries() {
+				if s, ok := entry.Data["missing_envs"].(string); ok {
+					missingEnvs = s
+				}
+			}
+			if missingEnvs != d.missingEnvs {
+				t.Fatalf("missing_envs: wanted %q, got %q", d.missingEnvs, missingEnvs)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package verify
+
+import "errors"
+
+var (
+	errPackagesAreCorrupted  = errors.New("some packages are corrupted")
+	errAssetChecksumMismatch = errors.New("the checksum of the asset doesn't match with aqua-checksums.json")
+)
@@ -0,0 +1,264 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/checksum"
+	"github.com/aquaproj/aqua/pkg/config"
+	finder "github.com/aquaproj/aqua/pkg/config-finder"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/manifest"
+	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+type Controller struct {
+	rootDir            string
+	configFinder       ConfigFinder
+	configReader       domain.ConfigReader
+	registryInstaller  domain.RegistryInstaller
+	packageInstaller   domain.PackageInstaller
+	pkgDownloader      domain.PackageDownloader
+	policyConfigReader domain.PolicyConfigReader
+	calculator         *checksum.Calculator
+	fs                 afero.Fs
+	runtime            *runtime.Runtime
+	download           bool
+	reinstall          bool
+}
+
+func New(param *config.Param, configFinder ConfigFinder, configReader domain.ConfigReader, registInstaller domain.RegistryInstaller, pkgInstaller domain.PackageInstaller, fs afero.Fs, rt *runtime.Runtime, pkgDownloader domain.PackageDownloader, policyConfigReader domain.PolicyConfigReader) *Controller {
+	return &Controller{
+		rootDir:            param.RootDir,
+		configFinder:       configFinder,
+		configReader:       configReader,
+		registryInstaller:  registInstaller,
+		packageInstaller:   pkgInstaller,
+		pkgDownloader:      pkgDownloader,
+		policyConfigReader: policyConfigReader,
+		calculator:         checksum.NewCalculator(),
+		fs:                 fs,
+		runtime:            rt,
+		download:           param.Download,
+		reinstall:          param.Reinstall,
+	}
+}
+
+// Verify re-calculates checksums of installed executables and compares them with checksums recorded at the installation.
+func (ctrl *Controller) Verify(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	policyCfgs, err := ctrl.policyConfigReader.Read(ctx, logE, param.PolicyConfigFilePaths)
+	if err != nil {
+		return fmt.Errorf("read policy files: %w", err)
+	}
+
+	failed := false
+	for _, cfgFilePath := range ctrl.configFinder.Finds(param.PWD, param.ConfigFilePath) {
+		f, err := ctrl.verify(ctx, logE, cfgFilePath, policyCfgs)
+		if err != nil {
+			return err
+		}
+		failed = failed || f
+	}
+
+	if param.All {
+		for _, cfgFilePath := range param.GlobalConfigFilePaths {
+			if _, err := ctrl.fs.Stat(cfgFilePath); err != nil {
+				continue
+			}
+			f, err := ctrl.verify(ctx, logE, cfgFilePath, policyCfgs)
+			if err != nil {
+				return err
+			}
+			failed = failed || f
+		}
+	}
+
+	if failed {
+		return errPackagesAreCorrupted
+	}
+	return nil
+}
+
+func (ctrl *Controller) verify(ctx context.Context, logE *logrus.Entry, cfgFilePath string, policyCfgs []*policy.Config) (bool, error) {
+	cfg := &aqua.Config{}
+	if cfgFilePath == "" {
+		return false, finder.ErrConfigFileNotFound
+	}
+	if err := ctrl.configReader.Read(cfgFilePath, cfg); err != nil {
+		return false, err //nolint:wrapcheck
+	}
+
+	registryContents, err := ctrl.registryInstaller.InstallRegistries(ctx, cfg, cfgFilePath, logE)
+	if err != nil {
+		return false, err //nolint:wrapcheck
+	}
+
+	checksums := checksum.New()
+	checksumFilePath, err := checksum.GetChecksumFilePathFromConfigFilePath(ctrl.fs, cfgFilePath)
+	if err != nil {
+		return false, err //nolint:wrapcheck
+	}
+	if err := checksums.ReadFile(ctrl.fs, checksumFilePath); err != nil {
+		return false, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+
+	pkgs, _ := config.ListPackages(logE, cfg, ctrl.runtime, registryContents)
+	failed := false
+	for _, pkg := range pkgs {
+		logE := logE.WithFields(logrus.Fields{
+			"package_name":    pkg.Package.Name,
+			"package_version": pkg.Package.Version,
+			"registry":        pkg.Package.Registry,
+		})
+		corrupted, err := ctrl.verifyPackage(ctx, logE, pkg, checksums)
+		if err != nil {
+			logerr.WithError(logE, err).Error("verify the package")
+			failed = true
+			continue
+		}
+		if len(corrupted) == 0 {
+			continue
+		}
+		if !ctrl.reinstall {
+			failed = true
+			continue
+		}
+		if err := ctrl.reinstallPackage(ctx, logE, pkg, corrupted, &domain.ParamInstallPackage{
+			Pkg:             pkg,
+			Checksums:       getChecksums(cfg, checksums),
+			RequireChecksum: cfg.RequireChecksum(),
+			ChecksumConfig:  cfg.Checksum,
+			PolicyConfigs:   policyCfgs,
+		}); err != nil {
+			logerr.WithError(logE, err).Error("reinstall the package")
+			failed = true
+		}
+	}
+	return failed, nil
+}
+
+// getChecksums returns checksums to reinstall packages.
+// aqua-checksums.json isn't updated by aqua verify.
+func getChecksums(cfg *aqua.Config, checksums *checksum.Checksums) *checksum.Checksums {
+	if !cfg.ChecksumEnabled() {
+		return nil
+	}
+	return checksums
+}
+
+// verifyPackage returns paths of corrupted files.
+func (ctrl *Controller) verifyPackage(ctx context.Context, logE *logrus.Entry, pkg *config.Package, checksums *checksum.Checksums) ([]string, error) {
+	pkgPath, err := pkg.GetPkgPath(ctrl.rootDir, ctrl.runtime)
+	if err != nil {
+		return nil, fmt.Errorf("get the package install path: %w", err)
+	}
+	if _, err := ctrl.fs.Stat(pkgPath); err != nil {
+		logE.Debug("skip verifying the package because the package isn't installed")
+		return nil, nil
+	}
+
+	corrupted, err := ctrl.verifyFiles(logE, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctrl.download {
+		if err := ctrl.verifyAsset(ctx, logE, pkg, checksums); err != nil {
+			return nil, err
+		}
+	}
+	return corrupted, nil
+}
+
+func (ctrl *Controller) verifyFiles(logE *logrus.Entry, pkgPath string) ([]string, error) {
+	manifestPath, err := manifest.GetPath(ctrl.rootDir, pkgPath)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	m, err := manifest.Read(ctrl.fs, manifestPath)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if m == nil {
+		logE.Warn("checksums of executables aren't recorded. Please reinstall the package to record them")
+		return nil, nil
+	}
+	corrupted, err := m.Verify(ctrl.fs, ctrl.rootDir)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	for _, p := range corrupted {
+		logE.WithField("file_path", p).Error("the file is corrupted")
+	}
+	return corrupted, nil
+}
+
+// verifyAsset downloads the asset and compares the checksum with aqua-checksums.json.
+func (ctrl *Controller) verifyAsset(ctx context.Context, logE *logrus.Entry, pkg *config.Package, checksums *checksum.Checksums) error {
+	if pkg.PackageInfo.Type == config.PkgInfoTypeGoInstall {
+		return nil
+	}
+	checksumID, err := pkg.GetChecksumID(ctrl.runtime)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	knowns := checksums.GetAll(checksumID)
+	if len(knowns) == 0 {
+		logE.Warn("skip verifying the asset because the checksum isn't recorded in aqua-checksums.json")
+		return nil
+	}
+	assetName, err := pkg.RenderAsset(ctrl.runtime)
+	if err != nil {
+		return fmt.Errorf("render the asset name: %w", err)
+	}
+	logE.Info("downloading the asset to verify the checksum")
+	body, _, err := ctrl.pkgDownloader.GetReadCloser(ctx, pkg, assetName, logE, ctrl.runtime)
+	if body != nil {
+		defer body.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("download the asset: %w", err)
+	}
+	algorithms := make([]string, len(knowns))
+	for i, known := range knowns {
+		algorithms[i] = known.Algorithm
+	}
+	sums, err := ctrl.calculator.Sum(body, algorithms)
+	if err != nil {
+		return fmt.Errorf("calculate a checksum of the asset: %w", err)
+	}
+	for _, known := range knowns {
+		if !strings.EqualFold(sums[known.Algorithm], known.Checksum) {
+			return logerr.WithFields(errAssetChecksumMismatch, logrus.Fields{ //nolint:wrapcheck
+				"algorithm":         known.Algorithm,
+				"actual_checksum":   sums[known.Algorithm],
+				"expected_checksum": known.Checksum,
+			})
+		}
+	}
+	return nil
+}
+
+func (ctrl *Controller) reinstallPackage(ctx context.Context, logE *logrus.Entry, pkg *config.Package, corrupted []string, param *domain.ParamInstallPackage) error {
+	pkgPath, err := pkg.GetPkgPath(ctrl.rootDir, ctrl.runtime)
+	if err != nil {
+		return fmt.Errorf("get the package install path: %w", err)
+	}
+	logE.Info("reinstalling the package")
+	if err := ctrl.fs.RemoveAll(pkgPath); err != nil {
+		return fmt.Errorf("remove the package: %w", err)
+	}
+	// Executables of some package types such as go are installed outside the package directory.
+	for _, p := range corrupted {
+		if err := ctrl.fs.RemoveAll(p); err != nil {
+			return fmt.Errorf("remove a corrupted file: %w", err)
+		}
+	}
+	return ctrl.packageInstaller.InstallPackage(ctx, logE, param) //nolint:wrapcheck
+}
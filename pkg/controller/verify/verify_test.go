@@ -0,0 +1,124 @@
+package verify_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	reader "github.com/aquaproj/aqua/pkg/config-reader"
+	"github.com/aquaproj/aqua/pkg/controller/verify"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/download"
+	registry "github.com/aquaproj/aqua/pkg/install-registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestController_Verify(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	const (
+		cfg = `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+`
+		rgst = `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`
+		exePath      = "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer"
+		manifestPath = "/home/foo/.local/share/aquaproj-aqua/manifests/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer.json"
+		manifest     = `{
+  "files": [
+    {
+      "path": "pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer",
+      "algorithm": "sha256",
+      "checksum": "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+    }
+  ]
+}`
+	)
+	data := []struct {
+		name  string
+		files map[string]string
+		isErr bool
+	}{
+		{
+			name: "normal",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml":     cfg,
+				"/home/foo/workspace/registry.yaml": rgst,
+				exePath:                             "hello",
+				manifestPath:                        manifest,
+			},
+		},
+		{
+			name: "not installed",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml":     cfg,
+				"/home/foo/workspace/registry.yaml": rgst,
+			},
+		},
+		{
+			name: "manifest isn't found",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml":     cfg,
+				"/home/foo/workspace/registry.yaml": rgst,
+				exePath:                             "hello",
+			},
+		},
+		{
+			name: "corrupted",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml":     cfg,
+				"/home/foo/workspace/registry.yaml": rgst,
+				exePath:                             "tampered",
+				manifestPath:                        manifest,
+			},
+			isErr: true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	registryDownloader := download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			param := &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+			}
+			fs := afero.NewMemMapFs()
+			for name, body := range d.files {
+				if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ctrl := verify.New(param, &verify.MockConfigFinder{
+				Files: []string{"/home/foo/workspace/aqua.yaml"},
+			}, reader.New(fs, param), registry.New(param, registryDownloader, fs, &domain.MockLocker{}), nil, fs, rt, nil, &domain.MockPolicyConfigReader{})
+			if err := ctrl.Verify(ctx, logE, param); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
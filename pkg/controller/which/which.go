@@ -11,6 +11,7 @@ import (
 	cfgRegistry "github.com/aquaproj/aqua/pkg/config/registry"
 	"github.com/aquaproj/aqua/pkg/domain"
 	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/util"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/suzuki-shunsuke/go-osenv/osenv"
@@ -27,6 +28,7 @@ type Controller struct {
 	osenv             osenv.OSEnv
 	fs                afero.Fs
 	linker            domain.Linker
+	trustChecker      domain.TrustChecker
 }
 
 type ConfigFinder interface {
@@ -35,6 +37,9 @@ type ConfigFinder interface {
 
 func (ctrl *Controller) Which(ctx context.Context, param *config.Param, exeName string, logE *logrus.Entry) (*domain.FindResult, error) {
 	for _, cfgFilePath := range ctrl.configFinder.Finds(param.PWD, param.ConfigFilePath) {
+		if err := ctrl.trustChecker.Check(cfgFilePath); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
 		findResult, err := ctrl.findExecFile(ctx, cfgFilePath, exeName, logE)
 		if err != nil {
 			return nil, err
@@ -77,7 +82,7 @@ func (ctrl *Controller) getExePath(findResult *domain.FindResult) (string, error
 		return "", errVersionIsRequired
 	}
 	if pkg.PackageInfo.Type == "go" {
-		return filepath.Join(ctrl.rootDir, "pkgs", pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version, "bin", file.Name), nil
+		return filepath.Join(ctrl.rootDir, "pkgs", pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version, "bin", file.Name), nil
 	}
 	fileSrc, err := pkg.GetFileSrc(file, ctrl.runtime)
 	if err != nil {
@@ -105,12 +110,38 @@ func (ctrl *Controller) findExecFile(ctx context.Context, cfgFilePath, exeName s
 			findResult.Config = cfg
 			findResult.ConfigFilePath = cfgFilePath
 			findResult.Package.Registry = cfg.Registries[pkg.Registry]
+			if err := ctrl.setWrapper(findResult); err != nil {
+				return nil, err
+			}
 			return findResult, nil
 		}
 	}
 	return nil, nil //nolint:nilnil
 }
 
+// setWrapper sets environment variables and arguments which are applied when the command is executed.
+// Environment variables of the configuration file take precedence over those of the registry.
+func (ctrl *Controller) setWrapper(findResult *domain.FindResult) error {
+	pkg := findResult.Package
+	registryEnvs, err := pkg.RenderEnvs(ctrl.rootDir, ctrl.runtime)
+	if err != nil {
+		return fmt.Errorf("render environment variables of the package: %w", err)
+	}
+	cfgEnvs, err := pkg.RenderConfigEnvs(findResult.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("render env of the package in the configuration file: %w", err)
+	}
+	argsPrefix, err := pkg.RenderArgsPrefix(findResult.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("render args_prefix of the package: %w", err)
+	}
+	if len(registryEnvs) != 0 || len(cfgEnvs) != 0 {
+		findResult.Envs = util.MergeEnvs(registryEnvs, cfgEnvs)
+	}
+	findResult.ArgsPrefix = argsPrefix
+	return nil
+}
+
 func (ctrl *Controller) findExecFileFromPkg(registries map[string]*cfgRegistry.Config, exeName string, pkg *aqua.Package, logE *logrus.Entry) *domain.FindResult { //nolint:cyclop
 	if pkg.Registry == "" || pkg.Name == "" {
 		logE.Debug("ignore a package because the package name or package registry name is empty")
@@ -109,6 +109,112 @@ packages:
 				ConfigFilePath: "/home/foo/workspace/aqua.yaml",
 			},
 		},
+		{
+			name: "env and args_prefix",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+			},
+			exeName: "aqua-installer",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+  env:
+    FOO: "{{.ConfigDir}}/foo"
+    BAR: bar
+  args_prefix:
+  - --config={{.ConfigDir}}/config.yaml
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+  envs:
+  - name: FOO
+    value: foo
+  - name: INSTALLER_HOME
+    value: "{{.PkgPath}}"
+`,
+			},
+			exp: &domain.FindResult{
+				Package: &config.Package{
+					Package: &aqua.Package{
+						Name:     "aquaproj/aqua-installer",
+						Registry: "standard",
+						Version:  "v1.0.0",
+						Env: map[string]string{
+							"FOO": "{{.ConfigDir}}/foo",
+							"BAR": "bar",
+						},
+						ArgsPrefix: []string{"--config={{.ConfigDir}}/config.yaml"},
+					},
+					PackageInfo: &cfgRegistry.PackageInfo{
+						Type:      "github_content",
+						RepoOwner: "aquaproj",
+						RepoName:  "aqua-installer",
+						Path:      stringP("aqua-installer"),
+						Envs: []*cfgRegistry.Env{
+							{
+								Name:  "FOO",
+								Value: "foo",
+							},
+							{
+								Name:  "INSTALLER_HOME",
+								Value: "{{.PkgPath}}",
+							},
+						},
+					},
+					Registry: &aqua.Registry{
+						Name: "standard",
+						Type: "local",
+						Path: "/home/foo/workspace/registry.yaml",
+					},
+				},
+				File: &cfgRegistry.File{
+					Name: "aqua-installer",
+				},
+				Config: &aqua.Config{
+					Packages: []*aqua.Package{
+						{
+							Name:     "aquaproj/aqua-installer",
+							Registry: "standard",
+							Version:  "v1.0.0",
+							Env: map[string]string{
+								"FOO": "{{.ConfigDir}}/foo",
+								"BAR": "bar",
+							},
+							ArgsPrefix: []string{"--config={{.ConfigDir}}/config.yaml"},
+						},
+					},
+					Registries: aqua.Registries{
+						"standard": {
+							Name: "standard",
+							Type: "local",
+							Path: "/home/foo/workspace/registry.yaml",
+						},
+					},
+				},
+				ExePath:        "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer",
+				ConfigFilePath: "/home/foo/workspace/aqua.yaml",
+				Envs: []string{
+					"FOO=/home/foo/workspace/foo",
+					"INSTALLER_HOME=/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer",
+					"BAR=bar",
+				},
+				ArgsPrefix: []string{"--config=/home/foo/workspace/config.yaml"},
+			},
+		},
 		{
 			name: "outside aqua",
 			rt: &runtime.Runtime{
@@ -252,8 +358,8 @@ packages:
 					t.Fatal(err)
 				}
 			}
-			downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(http.DefaultClient))
-			ctrl := which.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, fs), d.rt, osenv.NewMock(d.env), fs, linker)
+			downloader := download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(http.DefaultClient, nil))
+			ctrl := which.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, fs, &domain.MockLocker{}), d.rt, osenv.NewMock(d.env), fs, linker, &domain.MockTrustChecker{})
 			which, err := ctrl.Which(ctx, d.param, d.exeName, logE)
 			if err != nil {
 				if d.isErr {
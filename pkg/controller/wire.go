@@ -7,11 +7,15 @@ import (
 	"context"
 	"net/http"
 
+	verifier "github.com/aquaproj/aqua/pkg/artifact-verifier"
 	"github.com/aquaproj/aqua/pkg/checksum"
 	"github.com/aquaproj/aqua/pkg/config"
 	finder "github.com/aquaproj/aqua/pkg/config-finder"
 	reader "github.com/aquaproj/aqua/pkg/config-reader"
+	"github.com/aquaproj/aqua/pkg/controller/allowpolicy"
+	"github.com/aquaproj/aqua/pkg/controller/checkpolicy"
 	"github.com/aquaproj/aqua/pkg/controller/cp"
+	"github.com/aquaproj/aqua/pkg/controller/env"
 	cexec "github.com/aquaproj/aqua/pkg/controller/exec"
 	"github.com/aquaproj/aqua/pkg/controller/generate"
 	genrgst "github.com/aquaproj/aqua/pkg/controller/generate-registry"
@@ -19,18 +23,26 @@ import (
 	"github.com/aquaproj/aqua/pkg/controller/initpolicy"
 	"github.com/aquaproj/aqua/pkg/controller/install"
 	"github.com/aquaproj/aqua/pkg/controller/list"
+	"github.com/aquaproj/aqua/pkg/controller/shellenv"
 	"github.com/aquaproj/aqua/pkg/controller/updateaqua"
 	"github.com/aquaproj/aqua/pkg/controller/updatechecksum"
+	"github.com/aquaproj/aqua/pkg/controller/verify"
 	"github.com/aquaproj/aqua/pkg/controller/which"
+	"github.com/aquaproj/aqua/pkg/cosign"
 	"github.com/aquaproj/aqua/pkg/domain"
 	"github.com/aquaproj/aqua/pkg/download"
 	"github.com/aquaproj/aqua/pkg/exec"
+	"github.com/aquaproj/aqua/pkg/flock"
 	"github.com/aquaproj/aqua/pkg/github"
+	installpolicy "github.com/aquaproj/aqua/pkg/install-policy"
 	registry "github.com/aquaproj/aqua/pkg/install-registry"
 	"github.com/aquaproj/aqua/pkg/installpackage"
 	"github.com/aquaproj/aqua/pkg/link"
 	"github.com/aquaproj/aqua/pkg/policy"
 	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/signature"
+	"github.com/aquaproj/aqua/pkg/slsa"
+	"github.com/aquaproj/aqua/pkg/trust"
 	"github.com/aquaproj/aqua/pkg/unarchive"
 	"github.com/google/wire"
 	"github.com/spf13/afero"
@@ -49,8 +61,14 @@ func InitializeListCommandController(ctx context.Context, param *config.Param, h
 			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
 		wire.NewSet(
 			registry.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
 			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
@@ -63,6 +81,15 @@ func InitializeListCommandController(ctx context.Context, param *config.Param, h
 		),
 		afero.NewOsFs,
 		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		osenv.New,
+		wire.NewSet(
+			exec.New,
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
+		),
 	)
 	return &list.Controller{}
 }
@@ -75,6 +102,7 @@ func InitializeGenerateRegistryCommandController(ctx context.Context, param *con
 			wire.Bind(new(genrgst.RepositoriesService), new(*github.RepositoriesService)),
 		),
 		afero.NewOsFs,
+		osenv.New,
 	)
 	return &genrgst.Controller{}
 }
@@ -87,6 +115,7 @@ func InitializeInitCommandController(ctx context.Context, param *config.Param) *
 			wire.Bind(new(initcmd.RepositoriesService), new(*github.RepositoriesService)),
 		),
 		afero.NewOsFs,
+		osenv.New,
 	)
 	return &initcmd.Controller{}
 }
@@ -99,6 +128,79 @@ func InitializeInitPolicyCommandController(ctx context.Context) *initpolicy.Cont
 	return &initpolicy.Controller{}
 }
 
+func InitializeAllowPolicyCommandController(ctx context.Context, param *config.Param) *allowpolicy.Controller {
+	wire.Build(
+		allowpolicy.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(allowpolicy.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			trust.NewStore,
+			wire.Bind(new(allowpolicy.TrustStore), new(*trust.Store)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(domain.ConfigReader), new(*reader.ConfigReader)),
+		),
+		afero.NewOsFs,
+	)
+	return &allowpolicy.Controller{}
+}
+
+func InitializeCheckPolicyCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *checkpolicy.Controller {
+	wire.Build(
+		checkpolicy.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(checkpolicy.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
+		wire.NewSet(
+			registry.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
+			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(domain.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(domain.ConfigReader), new(*reader.ConfigReader)),
+		),
+		osenv.New,
+		afero.NewOsFs,
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		wire.NewSet(
+			exec.New,
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
+		),
+		wire.NewSet(
+			policy.NewChecker,
+			wire.Bind(new(checkpolicy.PolicyChecker), new(*policy.Checker)),
+		),
+		wire.NewSet(
+			installpolicy.NewConfigReader,
+			wire.Bind(new(domain.PolicyConfigReader), new(*installpolicy.ConfigReader)),
+		),
+	)
+	return &checkpolicy.Controller{}
+}
+
 func InitializeGenerateCommandController(ctx context.Context, param *config.Param, httpClient *http.Client) *generate.Controller {
 	wire.Build(
 		generate.New,
@@ -111,8 +213,14 @@ func InitializeGenerateCommandController(ctx context.Context, param *config.Para
 			wire.Bind(new(generate.RepositoriesService), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
 		wire.NewSet(
 			registry.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
 			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
@@ -127,6 +235,15 @@ func InitializeGenerateCommandController(ctx context.Context, param *config.Para
 		generate.NewFuzzyFinder,
 		generate.NewVersionSelector,
 		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		osenv.New,
+		wire.NewSet(
+			exec.New,
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
+		),
 	)
 	return &generate.Controller{}
 }
@@ -143,8 +260,14 @@ func InitializeInstallCommandController(ctx context.Context, param *config.Param
 			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
 		wire.NewSet(
 			registry.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
 			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
@@ -169,9 +292,15 @@ func InitializeInstallCommandController(ctx context.Context, param *config.Param
 			wire.Bind(new(domain.Linker), new(*link.Linker)),
 		),
 		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		osenv.New,
 		wire.NewSet(
 			exec.New,
 			wire.Bind(new(installpackage.Executor), new(*exec.Executor)),
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
 		),
 		wire.NewSet(
 			download.NewChecksumDownloader,
@@ -181,6 +310,28 @@ func InitializeInstallCommandController(ctx context.Context, param *config.Param
 			checksum.NewCalculator,
 			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
 		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewVerifier,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			cosign.NewRekorClient,
+			wire.Bind(new(slsa.TransparencyLog), new(*cosign.RekorClient)),
+		),
+		wire.NewSet(
+			signature.NewVerifier,
+			wire.Bind(new(installpackage.SignatureVerifier), new(*signature.Verifier)),
+		),
+		wire.NewSet(
+			download.NewFileDownloader,
+			wire.Bind(new(cosign.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(slsa.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(signature.FileDownloader), new(*download.FileDownloader)),
+		),
 		wire.NewSet(
 			unarchive.New,
 			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
@@ -190,8 +341,12 @@ func InitializeInstallCommandController(ctx context.Context, param *config.Param
 			wire.Bind(new(domain.PolicyChecker), new(*policy.Checker)),
 		),
 		wire.NewSet(
-			policy.NewConfigReader,
-			wire.Bind(new(domain.PolicyConfigReader), new(*policy.ConfigReader)),
+			installpolicy.NewConfigReader,
+			wire.Bind(new(domain.PolicyConfigReader), new(*installpolicy.ConfigReader)),
+		),
+		wire.NewSet(
+			trust.NewStore,
+			wire.Bind(new(domain.TrustChecker), new(*trust.Store)),
 		),
 	)
 	return &install.Controller{}
@@ -209,8 +364,14 @@ func InitializeWhichCommandController(ctx context.Context, param *config.Param,
 			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
 		wire.NewSet(
 			registry.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
 			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
@@ -224,10 +385,22 @@ func InitializeWhichCommandController(ctx context.Context, param *config.Param,
 		osenv.New,
 		afero.NewOsFs,
 		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		wire.NewSet(
+			exec.New,
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
+		),
 		wire.NewSet(
 			link.New,
 			wire.Bind(new(domain.Linker), new(*link.Linker)),
 		),
+		wire.NewSet(
+			trust.NewStore,
+			wire.Bind(new(domain.TrustChecker), new(*trust.Store)),
+		),
 	)
 	return nil
 }
@@ -245,6 +418,8 @@ func InitializeExecCommandController(ctx context.Context, param *config.Param, h
 		),
 		wire.NewSet(
 			installpackage.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
 			wire.Bind(new(domain.PackageInstaller), new(*installpackage.Installer)),
 		),
 		wire.NewSet(
@@ -252,6 +427,10 @@ func InitializeExecCommandController(ctx context.Context, param *config.Param, h
 			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
 		wire.NewSet(
 			registry.New,
 			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
@@ -271,6 +450,7 @@ func InitializeExecCommandController(ctx context.Context, param *config.Param, h
 		wire.NewSet(
 			exec.New,
 			wire.Bind(new(installpackage.Executor), new(*exec.Executor)),
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
 			wire.Bind(new(cexec.Executor), new(*exec.Executor)),
 		),
 		wire.NewSet(
@@ -284,10 +464,36 @@ func InitializeExecCommandController(ctx context.Context, param *config.Param, h
 			wire.Bind(new(domain.Linker), new(*link.Linker)),
 		),
 		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
 		wire.NewSet(
 			checksum.NewCalculator,
 			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
 		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewVerifier,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			cosign.NewRekorClient,
+			wire.Bind(new(slsa.TransparencyLog), new(*cosign.RekorClient)),
+		),
+		wire.NewSet(
+			signature.NewVerifier,
+			wire.Bind(new(installpackage.SignatureVerifier), new(*signature.Verifier)),
+		),
+		wire.NewSet(
+			download.NewFileDownloader,
+			wire.Bind(new(cosign.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(slsa.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(signature.FileDownloader), new(*download.FileDownloader)),
+		),
 		wire.NewSet(
 			unarchive.New,
 			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
@@ -297,8 +503,12 @@ func InitializeExecCommandController(ctx context.Context, param *config.Param, h
 			wire.Bind(new(domain.PolicyChecker), new(*policy.Checker)),
 		),
 		wire.NewSet(
-			policy.NewConfigReader,
-			wire.Bind(new(domain.PolicyConfigReader), new(*policy.ConfigReader)),
+			installpolicy.NewConfigReader,
+			wire.Bind(new(domain.PolicyConfigReader), new(*installpolicy.ConfigReader)),
+		),
+		wire.NewSet(
+			trust.NewStore,
+			wire.Bind(new(domain.TrustChecker), new(*trust.Store)),
 		),
 	)
 	return &cexec.Controller{}
@@ -313,11 +523,22 @@ func InitializeUpdateAquaCommandController(ctx context.Context, param *config.Pa
 			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
 			wire.Bind(new(updateaqua.RepositoriesService), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
 		wire.NewSet(
 			installpackage.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
 			wire.Bind(new(updateaqua.AquaInstaller), new(*installpackage.Installer)),
 		),
 		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		osenv.New,
 		wire.NewSet(
 			download.NewPackageDownloader,
 			wire.Bind(new(domain.PackageDownloader), new(*download.PackageDownloader)),
@@ -325,6 +546,7 @@ func InitializeUpdateAquaCommandController(ctx context.Context, param *config.Pa
 		wire.NewSet(
 			exec.New,
 			wire.Bind(new(installpackage.Executor), new(*exec.Executor)),
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
 		),
 		wire.NewSet(
 			unarchive.New,
@@ -334,6 +556,28 @@ func InitializeUpdateAquaCommandController(ctx context.Context, param *config.Pa
 			checksum.NewCalculator,
 			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
 		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewVerifier,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			cosign.NewRekorClient,
+			wire.Bind(new(slsa.TransparencyLog), new(*cosign.RekorClient)),
+		),
+		wire.NewSet(
+			signature.NewVerifier,
+			wire.Bind(new(installpackage.SignatureVerifier), new(*signature.Verifier)),
+		),
+		wire.NewSet(
+			download.NewFileDownloader,
+			wire.Bind(new(cosign.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(slsa.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(signature.FileDownloader), new(*download.FileDownloader)),
+		),
 		wire.NewSet(
 			download.NewChecksumDownloader,
 			wire.Bind(new(domain.ChecksumDownloader), new(*download.ChecksumDownloader)),
@@ -368,6 +612,8 @@ func InitializeCopyCommandController(ctx context.Context, param *config.Param, h
 		),
 		wire.NewSet(
 			installpackage.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
 			wire.Bind(new(domain.PackageInstaller), new(*installpackage.Installer)),
 			wire.Bind(new(cp.PackageInstaller), new(*installpackage.Installer)),
 		),
@@ -376,6 +622,10 @@ func InitializeCopyCommandController(ctx context.Context, param *config.Param, h
 			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
 		wire.NewSet(
 			registry.New,
 			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
@@ -395,6 +645,7 @@ func InitializeCopyCommandController(ctx context.Context, param *config.Param, h
 		wire.NewSet(
 			exec.New,
 			wire.Bind(new(installpackage.Executor), new(*exec.Executor)),
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
 			wire.Bind(new(cexec.Executor), new(*exec.Executor)),
 		),
 		wire.NewSet(
@@ -408,10 +659,36 @@ func InitializeCopyCommandController(ctx context.Context, param *config.Param, h
 			wire.Bind(new(domain.Linker), new(*link.Linker)),
 		),
 		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
 		wire.NewSet(
 			checksum.NewCalculator,
 			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
 		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewVerifier,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			cosign.NewRekorClient,
+			wire.Bind(new(slsa.TransparencyLog), new(*cosign.RekorClient)),
+		),
+		wire.NewSet(
+			signature.NewVerifier,
+			wire.Bind(new(installpackage.SignatureVerifier), new(*signature.Verifier)),
+		),
+		wire.NewSet(
+			download.NewFileDownloader,
+			wire.Bind(new(cosign.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(slsa.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(signature.FileDownloader), new(*download.FileDownloader)),
+		),
 		wire.NewSet(
 			unarchive.New,
 			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
@@ -421,8 +698,12 @@ func InitializeCopyCommandController(ctx context.Context, param *config.Param, h
 			wire.Bind(new(domain.PolicyChecker), new(*policy.Checker)),
 		),
 		wire.NewSet(
-			policy.NewConfigReader,
-			wire.Bind(new(domain.PolicyConfigReader), new(*policy.ConfigReader)),
+			installpolicy.NewConfigReader,
+			wire.Bind(new(domain.PolicyConfigReader), new(*installpolicy.ConfigReader)),
+		),
+		wire.NewSet(
+			trust.NewStore,
+			wire.Bind(new(domain.TrustChecker), new(*trust.Store)),
 		),
 	)
 	return &cp.Controller{}
@@ -445,6 +726,8 @@ func InitializeUpdateChecksumCommandController(ctx context.Context, param *confi
 		),
 		wire.NewSet(
 			registry.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
 			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
@@ -452,6 +735,10 @@ func InitializeUpdateChecksumCommandController(ctx context.Context, param *confi
 			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
 		wire.NewSet(
 			download.NewGitHubContentFileDownloader,
 			wire.Bind(new(domain.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
@@ -460,8 +747,208 @@ func InitializeUpdateChecksumCommandController(ctx context.Context, param *confi
 			download.NewPackageDownloader,
 			wire.Bind(new(domain.PackageDownloader), new(*download.PackageDownloader)),
 		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(verifier.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewVerifier,
+			wire.Bind(new(verifier.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			cosign.NewRekorClient,
+			wire.Bind(new(slsa.TransparencyLog), new(*cosign.RekorClient)),
+		),
+		wire.NewSet(
+			signature.NewVerifier,
+			wire.Bind(new(verifier.SignatureVerifier), new(*signature.Verifier)),
+		),
+		wire.NewSet(
+			download.NewFileDownloader,
+			wire.Bind(new(cosign.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(slsa.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(signature.FileDownloader), new(*download.FileDownloader)),
+		),
 		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		osenv.New,
+		wire.NewSet(
+			exec.New,
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
+		),
 		afero.NewOsFs,
 	)
 	return &updatechecksum.Controller{}
 }
+
+func InitializeVerifyCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *verify.Controller {
+	wire.Build(
+		verify.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(verify.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
+		wire.NewSet(
+			registry.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
+			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(domain.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(domain.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			installpackage.New,
+			wire.Bind(new(domain.PackageInstaller), new(*installpackage.Installer)),
+		),
+		wire.NewSet(
+			download.NewPackageDownloader,
+			wire.Bind(new(domain.PackageDownloader), new(*download.PackageDownloader)),
+		),
+		afero.NewOsFs,
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(domain.Linker), new(*link.Linker)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		osenv.New,
+		wire.NewSet(
+			exec.New,
+			wire.Bind(new(installpackage.Executor), new(*exec.Executor)),
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
+		),
+		wire.NewSet(
+			download.NewChecksumDownloader,
+			wire.Bind(new(domain.ChecksumDownloader), new(*download.ChecksumDownloader)),
+		),
+		wire.NewSet(
+			checksum.NewCalculator,
+			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewVerifier,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			cosign.NewRekorClient,
+			wire.Bind(new(slsa.TransparencyLog), new(*cosign.RekorClient)),
+		),
+		wire.NewSet(
+			signature.NewVerifier,
+			wire.Bind(new(installpackage.SignatureVerifier), new(*signature.Verifier)),
+		),
+		wire.NewSet(
+			download.NewFileDownloader,
+			wire.Bind(new(cosign.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(slsa.FileDownloader), new(*download.FileDownloader)),
+			wire.Bind(new(signature.FileDownloader), new(*download.FileDownloader)),
+		),
+		wire.NewSet(
+			unarchive.New,
+			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
+		),
+		wire.NewSet(
+			policy.NewChecker,
+			wire.Bind(new(domain.PolicyChecker), new(*policy.Checker)),
+		),
+		wire.NewSet(
+			installpolicy.NewConfigReader,
+			wire.Bind(new(domain.PolicyConfigReader), new(*installpolicy.ConfigReader)),
+		),
+	)
+	return &verify.Controller{}
+}
+
+func InitializeShellEnvCommandController(ctx context.Context, param *config.Param) *shellenv.Controller {
+	wire.Build(
+		shellenv.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(shellenv.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(domain.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			trust.NewStore,
+			wire.Bind(new(domain.TrustChecker), new(*trust.Store)),
+		),
+		afero.NewOsFs,
+	)
+	return &shellenv.Controller{}
+}
+
+func InitializeEnvCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *env.Controller {
+	wire.Build(
+		env.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(env.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(domain.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			github.NewEnterpriseClients,
+			wire.Bind(new(domain.GitHubEnterpriseClients), new(*github.EnterpriseClients)),
+		),
+		wire.NewSet(
+			registry.New,
+			flock.New,
+			wire.Bind(new(domain.Locker), new(*flock.Locker)),
+			wire.Bind(new(domain.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(domain.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(domain.ConfigReader), new(*reader.ConfigReader)),
+		),
+		osenv.New,
+		afero.NewOsFs,
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewCredentialStore,
+			wire.Bind(new(download.CredentialGetter), new(*download.CredentialStore)),
+		),
+		wire.NewSet(
+			exec.New,
+			wire.Bind(new(download.CredentialHelperExecutor), new(*exec.Executor)),
+		),
+		wire.NewSet(
+			trust.NewStore,
+			wire.Bind(new(domain.TrustChecker), new(*trust.Store)),
+		),
+	)
+	return &env.Controller{}
+}
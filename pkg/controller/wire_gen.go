@@ -12,7 +12,10 @@ import (
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/aquaproj/aqua/pkg/config-finder"
 	"github.com/aquaproj/aqua/pkg/config-reader"
+	"github.com/aquaproj/aqua/pkg/controller/allowpolicy"
+	"github.com/aquaproj/aqua/pkg/controller/checkpolicy"
 	"github.com/aquaproj/aqua/pkg/controller/cp"
+	"github.com/aquaproj/aqua/pkg/controller/env"
 	exec2 "github.com/aquaproj/aqua/pkg/controller/exec"
 	"github.com/aquaproj/aqua/pkg/controller/generate"
 	"github.com/aquaproj/aqua/pkg/controller/generate-registry"
@@ -20,17 +23,25 @@ import (
 	"github.com/aquaproj/aqua/pkg/controller/initpolicy"
 	"github.com/aquaproj/aqua/pkg/controller/install"
 	"github.com/aquaproj/aqua/pkg/controller/list"
+	"github.com/aquaproj/aqua/pkg/controller/shellenv"
 	"github.com/aquaproj/aqua/pkg/controller/updateaqua"
 	"github.com/aquaproj/aqua/pkg/controller/updatechecksum"
+	"github.com/aquaproj/aqua/pkg/controller/verify"
 	"github.com/aquaproj/aqua/pkg/controller/which"
+	"github.com/aquaproj/aqua/pkg/cosign"
 	"github.com/aquaproj/aqua/pkg/download"
 	"github.com/aquaproj/aqua/pkg/exec"
+	"github.com/aquaproj/aqua/pkg/flock"
 	"github.com/aquaproj/aqua/pkg/github"
+	"github.com/aquaproj/aqua/pkg/install-policy"
 	"github.com/aquaproj/aqua/pkg/install-registry"
 	"github.com/aquaproj/aqua/pkg/installpackage"
 	"github.com/aquaproj/aqua/pkg/link"
 	"github.com/aquaproj/aqua/pkg/policy"
 	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/signature"
+	"github.com/aquaproj/aqua/pkg/slsa"
+	"github.com/aquaproj/aqua/pkg/trust"
 	"github.com/aquaproj/aqua/pkg/unarchive"
 	"github.com/spf13/afero"
 	"github.com/suzuki-shunsuke/go-osenv/osenv"
@@ -43,24 +54,31 @@ func InitializeListCommandController(ctx context.Context, param *config.Param, h
 	fs := afero.NewOsFs()
 	configFinder := finder.NewConfigFinder(fs)
 	configReader := reader.New(fs, param)
-	repositoriesService := github.New(ctx)
-	httpDownloader := download.NewHTTPDownloader(httpClient)
-	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
-	installer := registry.New(param, gitHubContentFileDownloader, fs)
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
+	executor := exec.New()
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	locker := flock.New(fs)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, locker)
 	controller := list.NewController(configFinder, configReader, installer)
 	return controller
 }
 
 func InitializeGenerateRegistryCommandController(ctx context.Context, param *config.Param, httpClient *http.Client) *genrgst.Controller {
 	fs := afero.NewOsFs()
-	repositoriesService := github.New(ctx)
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
 	controller := genrgst.NewController(fs, repositoriesService)
 	return controller
 }
 
 func InitializeInitCommandController(ctx context.Context, param *config.Param) *initcmd.Controller {
-	repositoriesService := github.New(ctx)
+	osEnv := osenv.New()
 	fs := afero.NewOsFs()
+	repositoriesService := github.New(ctx, osEnv, fs)
 	controller := initcmd.New(repositoriesService, fs)
 	return controller
 }
@@ -71,17 +89,50 @@ func InitializeInitPolicyCommandController(ctx context.Context) *initpolicy.Cont
 	return controller
 }
 
+func InitializeAllowPolicyCommandController(ctx context.Context, param *config.Param) *allowpolicy.Controller {
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	store := trust.NewStore(fs, param, configReader)
+	controller := allowpolicy.New(configFinder, store)
+	return controller
+}
+
+func InitializeCheckPolicyCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *checkpolicy.Controller {
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
+	executor := exec.New()
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	locker := flock.New(fs)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, locker)
+	installpolicyConfigReader := installpolicy.NewConfigReader(param, gitHubContentFileDownloader, httpDownloader, fs, locker)
+	checker := policy.NewChecker()
+	controller := checkpolicy.New(configFinder, configReader, installer, installpolicyConfigReader, checker, rt)
+	return controller
+}
+
 func InitializeGenerateCommandController(ctx context.Context, param *config.Param, httpClient *http.Client) *generate.Controller {
 	fs := afero.NewOsFs()
 	configFinder := finder.NewConfigFinder(fs)
 	configReader := reader.New(fs, param)
-	repositoriesService := github.New(ctx)
-	httpDownloader := download.NewHTTPDownloader(httpClient)
-	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
-	installer := registry.New(param, gitHubContentFileDownloader, fs)
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
+	executor := exec.New()
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	locker := flock.New(fs)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, locker)
 	fuzzyFinder := generate.NewFuzzyFinder()
 	versionSelector := generate.NewVersionSelector()
-	controller := generate.New(configFinder, configReader, installer, repositoriesService, fs, fuzzyFinder, versionSelector)
+	controller := generate.New(configFinder, configReader, installer, repositoriesService, enterpriseClients, fs, fuzzyFinder, versionSelector)
 	return controller
 }
 
@@ -89,20 +140,30 @@ func InitializeInstallCommandController(ctx context.Context, param *config.Param
 	fs := afero.NewOsFs()
 	configFinder := finder.NewConfigFinder(fs)
 	configReader := reader.New(fs, param)
-	repositoriesService := github.New(ctx)
-	httpDownloader := download.NewHTTPDownloader(httpClient)
-	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
-	installer := registry.New(param, gitHubContentFileDownloader, fs)
-	packageDownloader := download.NewPackageDownloader(repositoriesService, rt, httpDownloader)
-	linker := link.New()
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
 	executor := exec.New()
-	checksumDownloader := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	locker := flock.New(fs)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, locker)
+	packageDownloader := download.NewPackageDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
+	linker := link.New()
+	checksumDownloader := download.NewChecksumDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
 	calculator := checksum.NewCalculator()
 	unarchiver := unarchive.New()
 	checker := policy.NewChecker()
-	installpackageInstaller := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker)
-	policyConfigReader := policy.NewConfigReader(fs)
-	controller := install.New(param, configFinder, configReader, installer, installpackageInstaller, fs, rt, policyConfigReader)
+	fileDownloader := download.NewFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	verifier := cosign.NewVerifier(fileDownloader)
+	rekorClient := cosign.NewRekorClient(httpClient)
+	slsaVerifier := slsa.NewVerifier(fileDownloader, rekorClient)
+	signatureVerifier := signature.NewVerifier(fileDownloader)
+	installpackageInstaller := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker, verifier, slsaVerifier, signatureVerifier, locker)
+	installpolicyConfigReader := installpolicy.NewConfigReader(param, gitHubContentFileDownloader, httpDownloader, fs, locker)
+	store := trust.NewStore(fs, param, configReader)
+	controller := install.New(param, configFinder, configReader, installer, installpackageInstaller, fs, rt, installpolicyConfigReader, store)
 	return controller
 }
 
@@ -110,76 +171,108 @@ func InitializeWhichCommandController(ctx context.Context, param *config.Param,
 	fs := afero.NewOsFs()
 	configFinder := finder.NewConfigFinder(fs)
 	configReader := reader.New(fs, param)
-	repositoriesService := github.New(ctx)
-	httpDownloader := download.NewHTTPDownloader(httpClient)
-	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
-	installer := registry.New(param, gitHubContentFileDownloader, fs)
 	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
+	executor := exec.New()
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	locker := flock.New(fs)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, locker)
 	linker := link.New()
-	controller := which.New(param, configFinder, configReader, installer, rt, osEnv, fs, linker)
+	store := trust.NewStore(fs, param, configReader)
+	controller := which.New(param, configFinder, configReader, installer, rt, osEnv, fs, linker, store)
 	return controller
 }
 
 func InitializeExecCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *exec2.Controller {
-	repositoriesService := github.New(ctx)
-	httpDownloader := download.NewHTTPDownloader(httpClient)
-	packageDownloader := download.NewPackageDownloader(repositoriesService, rt, httpDownloader)
+	osEnv := osenv.New()
 	fs := afero.NewOsFs()
-	linker := link.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
 	executor := exec.New()
-	checksumDownloader := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	packageDownloader := download.NewPackageDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
+	linker := link.New()
+	checksumDownloader := download.NewChecksumDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
 	calculator := checksum.NewCalculator()
 	unarchiver := unarchive.New()
 	checker := policy.NewChecker()
-	installer := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker)
+	fileDownloader := download.NewFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	verifier := cosign.NewVerifier(fileDownloader)
+	rekorClient := cosign.NewRekorClient(httpClient)
+	slsaVerifier := slsa.NewVerifier(fileDownloader, rekorClient)
+	signatureVerifier := signature.NewVerifier(fileDownloader)
+	locker := flock.New(fs)
+	installer := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker, verifier, slsaVerifier, signatureVerifier, locker)
 	configFinder := finder.NewConfigFinder(fs)
 	configReader := reader.New(fs, param)
-	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
-	registryInstaller := registry.New(param, gitHubContentFileDownloader, fs)
-	osEnv := osenv.New()
-	controller := which.New(param, configFinder, configReader, registryInstaller, rt, osEnv, fs, linker)
-	policyConfigReader := policy.NewConfigReader(fs)
-	execController := exec2.New(installer, controller, executor, osEnv, fs, policyConfigReader, checker)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	registryInstaller := registry.New(param, gitHubContentFileDownloader, fs, locker)
+	store := trust.NewStore(fs, param, configReader)
+	controller := which.New(param, configFinder, configReader, registryInstaller, rt, osEnv, fs, linker, store)
+	installpolicyConfigReader := installpolicy.NewConfigReader(param, gitHubContentFileDownloader, httpDownloader, fs, locker)
+	execController := exec2.New(installer, controller, executor, osEnv, fs, installpolicyConfigReader)
 	return execController
 }
 
 func InitializeUpdateAquaCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *updateaqua.Controller {
 	fs := afero.NewOsFs()
-	repositoriesService := github.New(ctx)
-	httpDownloader := download.NewHTTPDownloader(httpClient)
-	packageDownloader := download.NewPackageDownloader(repositoriesService, rt, httpDownloader)
-	linker := link.New()
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
 	executor := exec.New()
-	checksumDownloader := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	packageDownloader := download.NewPackageDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
+	linker := link.New()
+	checksumDownloader := download.NewChecksumDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
 	calculator := checksum.NewCalculator()
 	unarchiver := unarchive.New()
 	checker := policy.NewChecker()
-	installer := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker)
+	fileDownloader := download.NewFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	verifier := cosign.NewVerifier(fileDownloader)
+	rekorClient := cosign.NewRekorClient(httpClient)
+	slsaVerifier := slsa.NewVerifier(fileDownloader, rekorClient)
+	signatureVerifier := signature.NewVerifier(fileDownloader)
+	locker := flock.New(fs)
+	installer := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker, verifier, slsaVerifier, signatureVerifier, locker)
 	controller := updateaqua.New(param, fs, rt, repositoriesService, installer)
 	return controller
 }
 
 func InitializeCopyCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *cp.Controller {
-	repositoriesService := github.New(ctx)
-	httpDownloader := download.NewHTTPDownloader(httpClient)
-	packageDownloader := download.NewPackageDownloader(repositoriesService, rt, httpDownloader)
+	osEnv := osenv.New()
 	fs := afero.NewOsFs()
-	linker := link.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
 	executor := exec.New()
-	checksumDownloader := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	packageDownloader := download.NewPackageDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
+	linker := link.New()
+	checksumDownloader := download.NewChecksumDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
 	calculator := checksum.NewCalculator()
 	unarchiver := unarchive.New()
 	checker := policy.NewChecker()
-	installer := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker)
+	fileDownloader := download.NewFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	verifier := cosign.NewVerifier(fileDownloader)
+	rekorClient := cosign.NewRekorClient(httpClient)
+	slsaVerifier := slsa.NewVerifier(fileDownloader, rekorClient)
+	signatureVerifier := signature.NewVerifier(fileDownloader)
+	locker := flock.New(fs)
+	installer := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker, verifier, slsaVerifier, signatureVerifier, locker)
 	configFinder := finder.NewConfigFinder(fs)
 	configReader := reader.New(fs, param)
-	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
-	registryInstaller := registry.New(param, gitHubContentFileDownloader, fs)
-	osEnv := osenv.New()
-	controller := which.New(param, configFinder, configReader, registryInstaller, rt, osEnv, fs, linker)
-	policyConfigReader := policy.NewConfigReader(fs)
-	installController := install.New(param, configFinder, configReader, registryInstaller, installer, fs, rt, policyConfigReader)
-	cpController := cp.New(param, installer, fs, rt, controller, installController, policyConfigReader)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	registryInstaller := registry.New(param, gitHubContentFileDownloader, fs, locker)
+	store := trust.NewStore(fs, param, configReader)
+	controller := which.New(param, configFinder, configReader, registryInstaller, rt, osEnv, fs, linker, store)
+	installpolicyConfigReader := installpolicy.NewConfigReader(param, gitHubContentFileDownloader, httpDownloader, fs, locker)
+	installController := install.New(param, configFinder, configReader, registryInstaller, installer, fs, rt, installpolicyConfigReader, store)
+	cpController := cp.New(param, installer, fs, rt, controller, installController, installpolicyConfigReader)
 	return cpController
 }
 
@@ -187,12 +280,79 @@ func InitializeUpdateChecksumCommandController(ctx context.Context, param *confi
 	fs := afero.NewOsFs()
 	configFinder := finder.NewConfigFinder(fs)
 	configReader := reader.New(fs, param)
-	repositoriesService := github.New(ctx)
-	httpDownloader := download.NewHTTPDownloader(httpClient)
-	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
-	installer := registry.New(param, gitHubContentFileDownloader, fs)
-	checksumDownloader := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
-	packageDownloader := download.NewPackageDownloader(repositoriesService, rt, httpDownloader)
-	controller := updatechecksum.New(param, configFinder, configReader, installer, fs, rt, checksumDownloader, packageDownloader)
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
+	executor := exec.New()
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	locker := flock.New(fs)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, locker)
+	checksumDownloader := download.NewChecksumDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
+	packageDownloader := download.NewPackageDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
+	fileDownloader := download.NewFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	verifier := cosign.NewVerifier(fileDownloader)
+	rekorClient := cosign.NewRekorClient(httpClient)
+	slsaVerifier := slsa.NewVerifier(fileDownloader, rekorClient)
+	signatureVerifier := signature.NewVerifier(fileDownloader)
+	controller := updatechecksum.New(param, configFinder, configReader, installer, fs, rt, checksumDownloader, packageDownloader, verifier, slsaVerifier, signatureVerifier)
+	return controller
+}
+
+func InitializeVerifyCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *verify.Controller {
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
+	executor := exec.New()
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	locker := flock.New(fs)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, locker)
+	packageDownloader := download.NewPackageDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
+	linker := link.New()
+	checksumDownloader := download.NewChecksumDownloader(repositoriesService, enterpriseClients, rt, httpDownloader)
+	calculator := checksum.NewCalculator()
+	unarchiver := unarchive.New()
+	checker := policy.NewChecker()
+	fileDownloader := download.NewFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	verifier := cosign.NewVerifier(fileDownloader)
+	rekorClient := cosign.NewRekorClient(httpClient)
+	slsaVerifier := slsa.NewVerifier(fileDownloader, rekorClient)
+	signatureVerifier := signature.NewVerifier(fileDownloader)
+	installpackageInstaller := installpackage.New(param, packageDownloader, rt, fs, linker, executor, checksumDownloader, calculator, unarchiver, checker, verifier, slsaVerifier, signatureVerifier, locker)
+	installpolicyConfigReader := installpolicy.NewConfigReader(param, gitHubContentFileDownloader, httpDownloader, fs, locker)
+	controller := verify.New(param, configFinder, configReader, installer, installpackageInstaller, fs, rt, packageDownloader, installpolicyConfigReader)
+	return controller
+}
+
+func InitializeShellEnvCommandController(ctx context.Context, param *config.Param) *shellenv.Controller {
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	store := trust.NewStore(fs, param, configReader)
+	controller := shellenv.New(configFinder, fs, store)
+	return controller
+}
+
+func InitializeEnvCommandController(ctx context.Context, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *env.Controller {
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	osEnv := osenv.New()
+	repositoriesService := github.New(ctx, osEnv, fs)
+	enterpriseClients := github.NewEnterpriseClients(osEnv)
+	executor := exec.New()
+	credentialStore := download.NewCredentialStore(param, fs, osEnv, executor)
+	httpDownloader := download.NewHTTPDownloader(httpClient, credentialStore)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, enterpriseClients, httpDownloader)
+	locker := flock.New(fs)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, locker)
+	store := trust.NewStore(fs, param, configReader)
+	controller := env.New(param, configFinder, configReader, installer, fs, rt, store)
 	return controller
 }
@@ -0,0 +1,21 @@
+package cosign
+
+import "errors"
+
+var (
+	errInvalidTrustedRoot              = errors.New("the trusted root is invalid")
+	errInvalidPublicKey                = errors.New("the public key is invalid")
+	errInvalidCertificate              = errors.New("the certificate is invalid")
+	errInvalidSignature                = errors.New("the signature is invalid")
+	errUnsupportedPublicKey            = errors.New("the type of the public key is unsupported")
+	errSignatureIsRequired             = errors.New("a signature is required")
+	errCertificateIsRequired           = errors.New("either a key, a certificate, or a bundle is required")
+	errCertificateIdentityIsRequired   = errors.New("certificate_identity or certificate_identity_regexp is required")
+	errCertificateOIDCIssuerIsRequired = errors.New("certificate_oidc_issuer is required")
+	errCertificateIdentityMismatch     = errors.New("the identity of the certificate doesn't match")
+	errCertificateOIDCIssuerMismatch   = errors.New("the OIDC issuer of the certificate doesn't match")
+	errTransparencyLogIsRequired       = errors.New("a bundle including a transparency log entry is required to verify a certificate")
+	errUnknownRekorLog                 = errors.New("the transparency log entry was signed by an unknown Rekor")
+	errInvalidTransparencyLog          = errors.New("the transparency log entry is invalid")
+	errInvalidHTTPStatusCode           = errors.New("status code >= 400")
+)
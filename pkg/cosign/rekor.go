@@ -0,0 +1,204 @@
+package cosign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// RekorBundle is a transparency log entry and its Signed Entry Timestamp (SET).
+type RekorBundle struct {
+	SignedEntryTimestamp string        `json:"SignedEntryTimestamp"`
+	Payload              *RekorPayload `json:"Payload"`
+}
+
+// RekorPayload is signed by Rekor.
+// Fields are sorted by JSON keys so that json.Marshal returns the canonical JSON.
+type RekorPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// hashedRekord is the body of a transparency log entry.
+// https://github.com/sigstore/rekor/blob/main/pkg/types/hashedrekord/v0.0.1/hashedrekord_v0_0_1_schema.json
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   string `json:"content"`
+			PublicKey struct {
+				Content string `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// intoto is the body of a transparency log entry of an in-toto attestation.
+// The public key is in spec.publicKey in v0.0.1 and in spec.content.envelope.signatures in v0.0.2.
+// https://github.com/sigstore/rekor/tree/main/pkg/types/intoto
+type intoto struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Content struct {
+			PayloadHash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"payloadHash"`
+			Envelope struct {
+				Signatures []struct {
+					PublicKey string `json:"publicKey"`
+				} `json:"signatures"`
+			} `json:"envelope"`
+		} `json:"content"`
+		PublicKey string `json:"publicKey"`
+	} `json:"spec"`
+}
+
+// verifyRekorBundle verifies the transparency log entry offline and returns the time when the entry was recorded.
+func (tr *TrustedRoot) verifyRekorBundle(rb *RekorBundle, digest, sig []byte, cert *x509.Certificate) (time.Time, error) {
+	if rb.Payload == nil {
+		return time.Time{}, errInvalidTransparencyLog
+	}
+	if err := tr.verifySET(rb); err != nil {
+		return time.Time{}, err
+	}
+	if err := verifyRekorBody(rb.Payload.Body, digest, sig, cert); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(rb.Payload.IntegratedTime, 0), nil
+}
+
+// VerifyIntotoEntry verifies the transparency log entry of an in-toto attestation offline
+// and returns the time when the entry was recorded.
+// payload is the payload of the DSSE envelope and cert is the certificate which signed the envelope.
+func (tr *TrustedRoot) VerifyIntotoEntry(rb *RekorBundle, payload []byte, cert *x509.Certificate) (time.Time, error) {
+	if rb.Payload == nil {
+		return time.Time{}, errInvalidTransparencyLog
+	}
+	if err := tr.verifySET(rb); err != nil {
+		return time.Time{}, err
+	}
+	if err := verifyIntotoBody(rb.Payload.Body, payload, cert); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(rb.Payload.IntegratedTime, 0), nil
+}
+
+func (tr *TrustedRoot) verifySET(rb *RekorBundle) error {
+	key, err := tr.getRekorKey(rb.Payload.LogID)
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(rb.Payload); err != nil {
+		return fmt.Errorf("marshal a transparency log entry: %w", err)
+	}
+	set, err := base64.StdEncoding.DecodeString(rb.SignedEntryTimestamp)
+	if err != nil {
+		return fmt.Errorf("decode a signed entry timestamp: %w", err)
+	}
+	h := sha256.Sum256(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	if err := VerifySignature(key, h[:], set); err != nil {
+		return fmt.Errorf("verify a signed entry timestamp: %w", err)
+	}
+	return nil
+}
+
+// getRekorKey returns the public key of Rekor.
+// The log ID is the SHA256 digest of the DER encoded public key.
+func (tr *TrustedRoot) getRekorKey(logID string) (crypto.PublicKey, error) {
+	for _, key := range tr.RekorKeys {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("marshal the public key of Rekor: %w", err)
+		}
+		h := sha256.Sum256(der)
+		if hex.EncodeToString(h[:]) == logID {
+			return key, nil
+		}
+	}
+	return nil, errUnknownRekorLog
+}
+
+// verifyRekorBody verifies that the transparency log entry records the signature.
+func verifyRekorBody(body string, digest, sig []byte, cert *x509.Certificate) error {
+	b, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return fmt.Errorf("decode a transparency log entry: %w", err)
+	}
+	entry := &hashedRekord{}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return fmt.Errorf("parse a transparency log entry: %w", err)
+	}
+	if entry.Kind != "hashedrekord" {
+		return errInvalidTransparencyLog
+	}
+	if entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(digest) {
+		return errInvalidTransparencyLog
+	}
+	entrySig, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.Content)
+	if err != nil || !bytes.Equal(entrySig, sig) {
+		return errInvalidTransparencyLog
+	}
+	if !matchCertificate(entry.Spec.Signature.PublicKey.Content, cert) {
+		return errInvalidTransparencyLog
+	}
+	return nil
+}
+
+// verifyIntotoBody verifies that the transparency log entry records the attestation signed with the certificate.
+func verifyIntotoBody(body string, payload []byte, cert *x509.Certificate) error {
+	b, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return fmt.Errorf("decode a transparency log entry: %w", err)
+	}
+	entry := &intoto{}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return fmt.Errorf("parse a transparency log entry: %w", err)
+	}
+	if entry.Kind != "intoto" {
+		return errInvalidTransparencyLog
+	}
+	payloadHash := sha256.Sum256(payload)
+	content := entry.Spec.Content
+	if content.PayloadHash.Algorithm != "sha256" || content.PayloadHash.Value != hex.EncodeToString(payloadHash[:]) {
+		return errInvalidTransparencyLog
+	}
+	publicKeys := []string{entry.Spec.PublicKey}
+	for _, sig := range content.Envelope.Signatures {
+		publicKeys = append(publicKeys, sig.PublicKey)
+	}
+	for _, publicKey := range publicKeys {
+		if matchCertificate(publicKey, cert) {
+			return nil
+		}
+	}
+	return errInvalidTransparencyLog
+}
+
+// matchCertificate returns true if the base64 encoded PEM is the certificate.
+func matchCertificate(b64PEM string, cert *x509.Certificate) bool {
+	b, err := base64.StdEncoding.DecodeString(b64PEM)
+	if err != nil {
+		return false
+	}
+	block, _ := pem.Decode(b)
+	return block != nil && bytes.Equal(block.Bytes, cert.Raw)
+}
@@ -0,0 +1,112 @@
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const (
+	defaultRekorURL = "https://rekor.sigstore.dev"
+	// maxRekorEntries is the maximum number of transparency log entries which are got per artifact.
+	maxRekorEntries = 10
+)
+
+// RekorClient searches the transparency log of Rekor.
+// https://github.com/sigstore/rekor/blob/main/openapi.yaml
+type RekorClient struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewRekorClient(httpClient *http.Client) *RekorClient {
+	return &RekorClient{
+		client:  httpClient,
+		baseURL: defaultRekorURL,
+	}
+}
+
+// logEntry is a transparency log entry returned by Rekor API.
+type logEntry struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+	Verification   struct {
+		SignedEntryTimestamp string `json:"signedEntryTimestamp"`
+	} `json:"verification"`
+}
+
+// SearchEntries returns transparency log entries related to the artifact.
+// digest is the hex encoded SHA256 digest of the artifact.
+// Entries aren't verified, so they must be verified with TrustedRoot.
+func (rc *RekorClient) SearchEntries(ctx context.Context, digest string) ([]*RekorBundle, error) {
+	var uuids []string
+	if err := rc.do(ctx, http.MethodPost, "/api/v1/index/retrieve", map[string]string{
+		"hash": "sha256:" + digest,
+	}, &uuids); err != nil {
+		return nil, fmt.Errorf("search transparency log entries: %w", err)
+	}
+	if len(uuids) > maxRekorEntries {
+		uuids = uuids[:maxRekorEntries]
+	}
+	bundles := make([]*RekorBundle, 0, len(uuids))
+	for _, uuid := range uuids {
+		entries := map[string]*logEntry{}
+		if err := rc.do(ctx, http.MethodGet, "/api/v1/log/entries/"+url.PathEscape(uuid), nil, &entries); err != nil {
+			return nil, fmt.Errorf("get a transparency log entry: %w", err)
+		}
+		for _, entry := range entries {
+			bundles = append(bundles, &RekorBundle{
+				SignedEntryTimestamp: entry.Verification.SignedEntryTimestamp,
+				Payload: &RekorPayload{
+					Body:           entry.Body,
+					IntegratedTime: entry.IntegratedTime,
+					LogID:          entry.LogID,
+					LogIndex:       entry.LogIndex,
+				},
+			})
+		}
+	}
+	return bundles, nil
+}
+
+func (rc *RekorClient) do(ctx context.Context, method, p string, reqBody, respBody interface{}) error {
+	var body io.Reader
+	if reqBody != nil {
+		b, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("marshal a request body: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rc.baseURL+p, body)
+	if err != nil {
+		return fmt.Errorf("create a http request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send http request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return logerr.WithFields(errInvalidHTTPStatusCode, logrus.Fields{ //nolint:wrapcheck
+			"status_code": resp.StatusCode,
+		})
+	}
+	if err := json.NewDecoder(resp.Body).Decode(respBody); err != nil {
+		return fmt.Errorf("parse a response body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cosign
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRekorClient_SearchEntries(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/index/retrieve", func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Method != http.MethodPost || body["hash"] != "sha256:3516a4d8" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]string{"foo"})
+	})
+	mux.HandleFunc("/api/v1/log/entries/foo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"foo": {"body": "Ym9keQ==", "integratedTime": 1664000000, "logID": "c0d2", "logIndex": 3, "verification": {"signedEntryTimestamp": "c2V0"}}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	rc := &RekorClient{
+		client:  server.Client(),
+		baseURL: server.URL,
+	}
+	entries, err := rc.SearchEntries(context.Background(), "3516a4d8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []*RekorBundle{
+		{
+			SignedEntryTimestamp: "c2V0",
+			Payload: &RekorPayload{
+				Body:           "Ym9keQ==",
+				IntegratedTime: 1664000000,
+				LogID:          "c0d2",
+				LogIndex:       3,
+			},
+		},
+	}
+	if diff := cmp.Diff(exp, entries); diff != "" {
+		t.Fatal(diff)
+	}
+	if _, err := rc.SearchEntries(context.Background(), "invalid"); err == nil {
+		t.Fatal("error must be returned")
+	}
+}
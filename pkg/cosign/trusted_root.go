@@ -0,0 +1,64 @@
+package cosign
+
+import (
+	"crypto"
+	"crypto/x509"
+	_ "embed"
+	"encoding/pem"
+	"fmt"
+)
+
+// The trust root of the public good instance of Sigstore.
+// https://github.com/sigstore/root-signing
+var (
+	//go:embed fulcio_v1.crt.pem
+	fulcioRoot []byte
+	//go:embed fulcio_intermediate_v1.crt.pem
+	fulcioIntermediate []byte
+	//go:embed rekor.pub
+	rekorPublicKey []byte
+)
+
+// TrustedRoot is the trust root to verify keyless signatures.
+type TrustedRoot struct {
+	// Roots and Intermediates are certificates of Fulcio, which issues code signing certificates.
+	Roots         *x509.CertPool
+	Intermediates *x509.CertPool
+	// RekorKeys are public keys of Rekor, the transparency log.
+	// If RekorKeys is empty, transparency log entries aren't verified and certificates are verified at the current time.
+	// This is useful to verify bundles created without Rekor.
+	RekorKeys []crypto.PublicKey
+}
+
+// DefaultTrustedRoot returns the trust root of the public good instance of Sigstore.
+func DefaultTrustedRoot() (*TrustedRoot, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(fulcioRoot) {
+		return nil, errInvalidTrustedRoot
+	}
+	intermediates := x509.NewCertPool()
+	if !intermediates.AppendCertsFromPEM(fulcioIntermediate) {
+		return nil, errInvalidTrustedRoot
+	}
+	rekorKey, err := parsePublicKey(rekorPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse the public key of Rekor: %w", err)
+	}
+	return &TrustedRoot{
+		Roots:         roots,
+		Intermediates: intermediates,
+		RekorKeys:     []crypto.PublicKey{rekorKey},
+	}, nil
+}
+
+func parsePublicKey(b []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errInvalidPublicKey
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse a public key: %w", err)
+	}
+	return key, nil
+}
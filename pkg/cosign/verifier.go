@@ -0,0 +1,95 @@
+package cosign
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+)
+
+type FileDownloader interface {
+	DownloadFile(ctx context.Context, logE *logrus.Entry, rt *runtime.Runtime, pkg *config.Package, file *registry.DownloadedFile) (io.ReadCloser, error)
+}
+
+// Verifier downloads signatures and certificates and verifies files with them.
+type Verifier struct {
+	downloader  FileDownloader
+	trustedRoot *TrustedRoot
+	initErr     error
+}
+
+func NewVerifier(downloader FileDownloader) *Verifier {
+	trustedRoot, err := DefaultTrustedRoot()
+	return &Verifier{
+		downloader:  downloader,
+		trustedRoot: trustedRoot,
+		initErr:     err,
+	}
+}
+
+type ParamVerify struct {
+	Runtime  *runtime.Runtime
+	Package  *config.Package
+	Cosign   *registry.Cosign
+	Artifact io.Reader
+}
+
+func (verifier *Verifier) Verify(ctx context.Context, logE *logrus.Entry, param *ParamVerify) error {
+	if verifier.initErr != nil {
+		return verifier.initErr
+	}
+	cos := param.Cosign
+	m := &Material{
+		CertificateIdentity:       cos.CertificateIdentity,
+		CertificateIdentityRegexp: cos.CertificateIdentityRegexp,
+		CertificateOIDCIssuer:     cos.CertificateOIDCIssuer,
+	}
+	if cos.PublicKey != "" {
+		m.Key = []byte(cos.PublicKey)
+	}
+	files := []struct {
+		name string
+		file *registry.DownloadedFile
+		dest *[]byte
+	}{
+		{name: "signature", file: cos.Signature, dest: &m.Signature},
+		{name: "certificate", file: cos.Certificate, dest: &m.Certificate},
+		{name: "bundle", file: cos.Bundle, dest: &m.Bundle},
+	}
+	for _, f := range files {
+		if f.file == nil {
+			continue
+		}
+		b, err := verifier.download(ctx, logE, param, f.file)
+		if err != nil {
+			return fmt.Errorf("download a %s: %w", f.name, err)
+		}
+		*f.dest = b
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, param.Artifact); err != nil {
+		return fmt.Errorf("calculate a digest of the file: %w", err)
+	}
+	return verifier.trustedRoot.VerifyBlob(h.Sum(nil), m)
+}
+
+func (verifier *Verifier) download(ctx context.Context, logE *logrus.Entry, param *ParamVerify, file *registry.DownloadedFile) ([]byte, error) {
+	rc, err := verifier.downloader.DownloadFile(ctx, logE, param.Runtime, param.Package, file)
+	if rc != nil {
+		defer rc.Close()
+	}
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read a file: %w", err)
+	}
+	return b, nil
+}
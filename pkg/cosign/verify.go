@@ -0,0 +1,238 @@
+package cosign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// Material is a set of files and expectations to verify a signature.
+type Material struct {
+	// Signature is a signature outputted by `cosign sign-blob --output-signature`.
+	Signature []byte
+	// Certificate is a certificate outputted by `cosign sign-blob --output-certificate`.
+	Certificate []byte
+	// Key is a PEM encoded public key.
+	Key []byte
+	// Bundle is a bundle outputted by `cosign sign-blob --bundle`.
+	Bundle                    []byte
+	CertificateIdentity       string
+	CertificateIdentityRegexp string
+	CertificateOIDCIssuer     string
+}
+
+// bundle is a bundle outputted by `cosign sign-blob --bundle`.
+type bundle struct {
+	Base64Signature string       `json:"base64Signature"`
+	Cert            string       `json:"cert"`
+	RekorBundle     *RekorBundle `json:"rekorBundle"`
+}
+
+var (
+	// https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+	oidIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// VerifyBlob verifies the signature of a blob.
+// digest is the SHA256 digest of the blob.
+func (tr *TrustedRoot) VerifyBlob(digest []byte, m *Material) error { //nolint:cyclop
+	var bdl *bundle
+	if m.Bundle != nil {
+		bdl = &bundle{}
+		if err := json.Unmarshal(m.Bundle, bdl); err != nil {
+			return fmt.Errorf("parse a bundle: %w", err)
+		}
+	}
+
+	sigB := m.Signature
+	if sigB == nil && bdl != nil {
+		sigB = []byte(bdl.Base64Signature)
+	}
+	if len(sigB) == 0 {
+		return errSignatureIsRequired
+	}
+	sig := decodeSignature(sigB)
+
+	if m.Key != nil {
+		key, err := parsePublicKey(m.Key)
+		if err != nil {
+			return err
+		}
+		return VerifySignature(key, digest, sig)
+	}
+
+	certB := m.Certificate
+	if certB == nil && bdl != nil {
+		certB = []byte(bdl.Cert)
+	}
+	if len(certB) == 0 {
+		return errCertificateIsRequired
+	}
+	cert, err := ParseCertificate(certB)
+	if err != nil {
+		return err
+	}
+	if err := verifyIdentity(cert, m); err != nil {
+		return err
+	}
+	if err := VerifySignature(cert.PublicKey, digest, sig); err != nil {
+		return err
+	}
+
+	// Fulcio certificates are valid for only 10 minutes,
+	// so the certificate is verified at the time when the signature was recorded in the transparency log.
+	verifiedAt := time.Now()
+	if len(tr.RekorKeys) != 0 {
+		if bdl == nil || bdl.RekorBundle == nil {
+			return errTransparencyLogIsRequired
+		}
+		t, err := tr.verifyRekorBundle(bdl.RekorBundle, digest, sig, cert)
+		if err != nil {
+			return err
+		}
+		verifiedAt = t
+	}
+
+	return tr.VerifyCertificate(cert, verifiedAt)
+}
+
+// VerifyCertificate verifies that the certificate is a code signing certificate issued by Fulcio and valid at the given time.
+func (tr *TrustedRoot) VerifyCertificate(cert *x509.Certificate, at time.Time) error {
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         tr.Roots,
+		Intermediates: tr.Intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("verify the certificate chain: %w", err)
+	}
+	return nil
+}
+
+// decodeSignature decodes a base64 encoded signature.
+// If the signature isn't base64 encoded, it is returned as is.
+func decodeSignature(b []byte) []byte {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return b
+	}
+	return sig
+}
+
+// ParseCertificate parses a PEM encoded certificate.
+// cosign outputs base64 encoded PEM, so it is also accepted.
+func ParseCertificate(b []byte) (*x509.Certificate, error) {
+	if !bytes.Contains(b, []byte("-----BEGIN")) {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+		if err != nil {
+			return nil, errInvalidCertificate
+		}
+		b = decoded
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errInvalidCertificate
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse a certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// VerifySignature verifies a signature over a SHA256 digest.
+func VerifySignature(key crypto.PublicKey, digest, sig []byte) error {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest, sig) {
+			return errInvalidSignature
+		}
+		return nil
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig); err != nil {
+			return errInvalidSignature
+		}
+		return nil
+	default:
+		return errUnsupportedPublicKey
+	}
+}
+
+func verifyIdentity(cert *x509.Certificate, m *Material) error {
+	if m.CertificateIdentity == "" && m.CertificateIdentityRegexp == "" {
+		return errCertificateIdentityIsRequired
+	}
+	if m.CertificateOIDCIssuer == "" {
+		return errCertificateOIDCIssuerIsRequired
+	}
+
+	issuer := GetOIDCIssuer(cert)
+	if issuer != m.CertificateOIDCIssuer {
+		return logerr.WithFields(errCertificateOIDCIssuerMismatch, logrus.Fields{ //nolint:wrapcheck
+			"certificate_oidc_issuer": issuer,
+		})
+	}
+
+	sans := getSubjectAlternativeNames(cert)
+	if m.CertificateIdentity != "" {
+		for _, san := range sans {
+			if san == m.CertificateIdentity {
+				return nil
+			}
+		}
+	}
+	if m.CertificateIdentityRegexp != "" {
+		p, err := regexp.Compile(m.CertificateIdentityRegexp)
+		if err != nil {
+			return fmt.Errorf("compile certificate_identity_regexp: %w", err)
+		}
+		for _, san := range sans {
+			if p.MatchString(san) {
+				return nil
+			}
+		}
+	}
+	return logerr.WithFields(errCertificateIdentityMismatch, logrus.Fields{ //nolint:wrapcheck
+		"certificate_identities": strings.Join(sans, ", "),
+	})
+}
+
+func getSubjectAlternativeNames(cert *x509.Certificate) []string {
+	sans := make([]string, 0, len(cert.URIs)+len(cert.EmailAddresses))
+	for _, u := range cert.URIs {
+		sans = append(sans, u.String())
+	}
+	return append(sans, cert.EmailAddresses...)
+}
+
+// GetOIDCIssuer returns the OIDC issuer recorded in a certificate issued by Fulcio.
+func GetOIDCIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuer) {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
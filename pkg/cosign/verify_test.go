@@ -0,0 +1,396 @@
+package cosign_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/pkg/cosign"
+)
+
+const (
+	identity = "https://github.com/aquaproj/example/.github/workflows/release.yaml@refs/tags/v1.0.0"
+	issuer   = "https://token.actions.githubusercontent.com"
+)
+
+type testCA struct {
+	roots *x509.CertPool
+	cert  *x509.Certificate
+	key   *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key := generateKey(t)
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	return &testCA{
+		roots: roots,
+		cert:  cert,
+		key:   key,
+	}
+}
+
+// issue issues a short-lived certificate like Fulcio.
+func (ca *testCA) issue(t *testing.T, key *ecdsa.PrivateKey, notBefore time.Time) []byte {
+	t.Helper()
+	u, err := url.Parse(identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerExt, err := asn1.Marshal(issuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(10 * time.Minute), //nolint:gomnd
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{u},
+		ExtraExtensions: []pkix.Extension{
+			{
+				Id:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8},
+				Value: issuerExt,
+			},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, digest []byte) []byte {
+	t.Helper()
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func marshalPublicKey(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func marshalJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// rekorEntry creates a transparency log entry signed by a fake Rekor.
+func rekorEntry(t *testing.T, rekorKey *ecdsa.PrivateKey, digest, sig, cert []byte, integratedTime time.Time) map[string]interface{} {
+	t.Helper()
+	body := marshalJSON(t, map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"data": map[string]interface{}{
+				"hash": map[string]string{
+					"algorithm": "sha256",
+					"value":     hex.EncodeToString(digest),
+				},
+			},
+			"signature": map[string]interface{}{
+				"content": base64.StdEncoding.EncodeToString(sig),
+				"publicKey": map[string]string{
+					"content": base64.StdEncoding.EncodeToString(cert),
+				},
+			},
+		},
+	})
+	der, err := x509.MarshalPKIXPublicKey(&rekorKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(der)
+	payload := map[string]interface{}{
+		"body":           base64.StdEncoding.EncodeToString(body),
+		"integratedTime": integratedTime.Unix(),
+		"logID":          hex.EncodeToString(logID[:]),
+		"logIndex":       1,
+	}
+	// json.Marshal sorts map keys, so the output is the canonical JSON.
+	h := sha256.Sum256(marshalJSON(t, payload))
+	return map[string]interface{}{
+		"SignedEntryTimestamp": base64.StdEncoding.EncodeToString(sign(t, rekorKey, h[:])),
+		"Payload":              payload,
+	}
+}
+
+func TestDefaultTrustedRoot(t *testing.T) {
+	t.Parallel()
+	if _, err := cosign.DefaultTrustedRoot(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrustedRoot_VerifyBlob(t *testing.T) { //nolint:funlen,maintidx
+	t.Parallel()
+	ca := newTestCA(t)
+	rekorKey := generateKey(t)
+	signerKey := generateKey(t)
+	otherKey := generateKey(t)
+
+	digest := sha256.Sum256([]byte("hello"))
+	otherDigest := sha256.Sum256([]byte("foo"))
+	sig := sign(t, signerKey, digest[:])
+	b64Sig := []byte(base64.StdEncoding.EncodeToString(sig))
+
+	now := time.Now()
+	cert := ca.issue(t, signerKey, now.Add(-time.Minute))
+	// The certificate has already expired, so it must be verified with the transparency log.
+	expiredCert := ca.issue(t, signerKey, now.Add(-30*time.Minute))
+	b64Cert := base64.StdEncoding.EncodeToString(cert)
+
+	rekorFree := &cosign.TrustedRoot{
+		Roots: ca.roots,
+	}
+	withRekor := &cosign.TrustedRoot{
+		Roots:     ca.roots,
+		RekorKeys: []crypto.PublicKey{&rekorKey.PublicKey},
+	}
+
+	data := []struct {
+		name        string
+		trustedRoot *cosign.TrustedRoot
+		digest      []byte
+		material    *cosign.Material
+		isErr       bool
+	}{
+		{
+			name:        "key",
+			trustedRoot: rekorFree,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature: b64Sig,
+				Key:       marshalPublicKey(t, &signerKey.PublicKey),
+			},
+		},
+		{
+			name:        "key mismatch",
+			trustedRoot: rekorFree,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature: b64Sig,
+				Key:       marshalPublicKey(t, &otherKey.PublicKey),
+			},
+			isErr: true,
+		},
+		{
+			name:        "artifact is tampered",
+			trustedRoot: rekorFree,
+			digest:      otherDigest[:],
+			material: &cosign.Material{
+				Signature: b64Sig,
+				Key:       marshalPublicKey(t, &signerKey.PublicKey),
+			},
+			isErr: true,
+		},
+		{
+			name:        "keyless without rekor",
+			trustedRoot: rekorFree,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature:             b64Sig,
+				Certificate:           []byte(b64Cert),
+				CertificateIdentity:   identity,
+				CertificateOIDCIssuer: issuer,
+			},
+		},
+		{
+			name:        "keyless bundle without rekor",
+			trustedRoot: rekorFree,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Bundle: marshalJSON(t, map[string]interface{}{
+					"base64Signature": string(b64Sig),
+					"cert":            b64Cert,
+				}),
+				CertificateIdentityRegexp: `^https://github\.com/aquaproj/example/`,
+				CertificateOIDCIssuer:     issuer,
+			},
+		},
+		{
+			name:        "identity mismatch",
+			trustedRoot: rekorFree,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature:             b64Sig,
+				Certificate:           cert,
+				CertificateIdentity:   "https://github.com/aquaproj/other/.github/workflows/release.yaml@refs/tags/v1.0.0",
+				CertificateOIDCIssuer: issuer,
+			},
+			isErr: true,
+		},
+		{
+			name:        "issuer mismatch",
+			trustedRoot: rekorFree,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature:             b64Sig,
+				Certificate:           cert,
+				CertificateIdentity:   identity,
+				CertificateOIDCIssuer: "https://accounts.google.com",
+			},
+			isErr: true,
+		},
+		{
+			name:        "identity is required",
+			trustedRoot: rekorFree,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature:             b64Sig,
+				Certificate:           cert,
+				CertificateOIDCIssuer: issuer,
+			},
+			isErr: true,
+		},
+		{
+			name:        "certificate isn't trusted",
+			trustedRoot: &cosign.TrustedRoot{Roots: newTestCA(t).roots},
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature:             b64Sig,
+				Certificate:           cert,
+				CertificateIdentity:   identity,
+				CertificateOIDCIssuer: issuer,
+			},
+			isErr: true,
+		},
+		{
+			name:        "expired certificate without rekor",
+			trustedRoot: rekorFree,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature:             b64Sig,
+				Certificate:           expiredCert,
+				CertificateIdentity:   identity,
+				CertificateOIDCIssuer: issuer,
+			},
+			isErr: true,
+		},
+		{
+			name:        "rekor",
+			trustedRoot: withRekor,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Bundle: marshalJSON(t, map[string]interface{}{
+					"base64Signature": string(b64Sig),
+					"cert":            base64.StdEncoding.EncodeToString(expiredCert),
+					"rekorBundle":     rekorEntry(t, rekorKey, digest[:], sig, expiredCert, now.Add(-25*time.Minute)),
+				}),
+				CertificateIdentity:   identity,
+				CertificateOIDCIssuer: issuer,
+			},
+		},
+		{
+			name:        "rekor entry is required",
+			trustedRoot: withRekor,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Signature:             b64Sig,
+				Certificate:           cert,
+				CertificateIdentity:   identity,
+				CertificateOIDCIssuer: issuer,
+			},
+			isErr: true,
+		},
+		{
+			name:        "rekor entry signed by unknown rekor",
+			trustedRoot: withRekor,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Bundle: marshalJSON(t, map[string]interface{}{
+					"base64Signature": string(b64Sig),
+					"cert":            b64Cert,
+					"rekorBundle":     rekorEntry(t, otherKey, digest[:], sig, cert, now),
+				}),
+				CertificateIdentity:   identity,
+				CertificateOIDCIssuer: issuer,
+			},
+			isErr: true,
+		},
+		{
+			name:        "rekor entry records another artifact",
+			trustedRoot: withRekor,
+			digest:      digest[:],
+			material: &cosign.Material{
+				Bundle: marshalJSON(t, map[string]interface{}{
+					"base64Signature": string(b64Sig),
+					"cert":            b64Cert,
+					"rekorBundle":     rekorEntry(t, rekorKey, otherDigest[:], sig, cert, now),
+				}),
+				CertificateIdentity:   identity,
+				CertificateOIDCIssuer: issuer,
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if err := d.trustedRoot.VerifyBlob(d.digest, d.material); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package domain
 
 import (
+	"context"
+
 	"github.com/aquaproj/aqua/pkg/config/aqua"
 	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/sirupsen/logrus"
 )
 
 type ConfigReader interface {
@@ -20,7 +23,7 @@ func (reader *MockConfigReader) Read(configFilePath string, cfg *aqua.Config) er
 }
 
 type PolicyConfigReader interface {
-	Read([]string) ([]*policy.Config, error)
+	Read(ctx context.Context, logE *logrus.Entry, files []string) ([]*policy.Config, error)
 }
 
 type MockPolicyConfigReader struct {
@@ -28,6 +31,6 @@ type MockPolicyConfigReader struct {
 	Err  error
 }
 
-func (reader *MockPolicyConfigReader) Read(files []string) ([]*policy.Config, error) {
+func (reader *MockPolicyConfigReader) Read(ctx context.Context, logE *logrus.Entry, files []string) ([]*policy.Config, error) {
 	return reader.Cfgs, reader.Err
 }
@@ -15,3 +15,7 @@ type RepositoriesService interface {
 	DownloadReleaseAsset(ctx context.Context, owner, repoName string, assetID int64, httpClient *http.Client) (io.ReadCloser, string, error)
 	GetContents(ctx context.Context, repoOwner, repoName, path string, opt *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
 }
+
+type GitHubEnterpriseClients interface {
+	Get(ctx context.Context, host string) (*github.RepositoriesService, error)
+}
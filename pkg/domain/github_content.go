@@ -12,6 +12,9 @@ type GitHubContentFileParam struct {
 	RepoName  string
 	Ref       string
 	Path      string
+	// GitHubHost is the host of GitHub Enterprise Server.
+	// If GitHubHost is empty or github.com, github.com is used.
+	GitHubHost string
 }
 
 type GitHubContentFile struct {
@@ -12,6 +12,9 @@ type DownloadGitHubReleaseParam struct {
 	RepoName  string
 	Version   string
 	Asset     string
+	// GitHubHost is the host of GitHub Enterprise Server.
+	// If GitHubHost is empty or github.com, github.com is used.
+	GitHubHost string
 }
 
 type GitHubReleaseDownloader interface {
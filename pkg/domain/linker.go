@@ -26,15 +26,28 @@ func NewMockLinker(fs afero.Fs) Linker {
 	}
 }
 
+// getLink returns the link.
+// If the link was removed from the file system, the link isn't found.
+func (lk *mockLinker) getLink(s string) (*mockFileInfo, bool) {
+	f, ok := lk.files[s]
+	if !ok {
+		return nil, false
+	}
+	if _, err := lk.fs.Stat(s); err != nil {
+		return nil, false
+	}
+	return f, true
+}
+
 func (lk *mockLinker) Lstat(s string) (os.FileInfo, error) {
-	if f, ok := lk.files[s]; ok {
+	if f, ok := lk.getLink(s); ok {
 		return f, nil
 	}
 	return lk.fs.Stat(s) //nolint:wrapcheck
 }
 
 func (lk *mockLinker) Symlink(dest, src string) error {
-	if _, ok := lk.files[src]; ok {
+	if _, ok := lk.getLink(src); ok {
 		return errors.New("file already exists")
 	}
 	if _, err := lk.fs.Create(src); err != nil {
@@ -51,7 +64,7 @@ func (lk *mockLinker) Symlink(dest, src string) error {
 }
 
 func (lk *mockLinker) Readlink(src string) (string, error) {
-	if f, ok := lk.files[src]; ok {
+	if f, ok := lk.getLink(src); ok {
 		return f.Dest, nil
 	}
 	return "", errors.New("file isn't found")
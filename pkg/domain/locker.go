@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Locker interface {
+	Lock(ctx context.Context, logE *logrus.Entry, p string) (func() error, error)
+}
+
+type MockLocker struct {
+	Err error
+}
+
+func (locker *MockLocker) Lock(ctx context.Context, logE *logrus.Entry, p string) (func() error, error) {
+	if locker.Err != nil {
+		return nil, locker.Err
+	}
+	return func() error {
+		return nil
+	}, nil
+}
@@ -15,6 +15,8 @@ type PackageInstaller interface {
 	InstallPackage(ctx context.Context, logE *logrus.Entry, param *ParamInstallPackage) error
 	InstallPackages(ctx context.Context, logE *logrus.Entry, param *ParamInstallPackages) error
 	InstallProxy(ctx context.Context, logE *logrus.Entry) error
+	PruneLinks(logE *logrus.Entry, commands map[string]struct{}, dryRun bool) ([]string, error)
+	PruneShareLinks(logE *logrus.Entry, links map[string]struct{}, dryRun bool) ([]string, error)
 }
 
 type ParamInstallPackages struct {
@@ -24,13 +26,17 @@ type ParamInstallPackages struct {
 	Tags           map[string]struct{}
 	ExcludedTags   map[string]struct{}
 	SkipLink       bool
-	PolicyConfigs  []*policy.Config
+	// ProjectBin creates shims in the project-local bin directory.
+	ProjectBin    bool
+	PolicyConfigs []*policy.Config
 }
 
 type ParamInstallPackage struct {
 	Pkg             *config.Package
 	Checksums       *checksum.Checksums
 	RequireChecksum bool
-	PolicyConfigs   []*policy.Config
-	ConfigFileDir   string
+	// ChecksumConfig is the checksum configuration of aqua.yaml.
+	ChecksumConfig *aqua.Checksum
+	PolicyConfigs  []*policy.Config
+	ConfigFileDir  string
 }
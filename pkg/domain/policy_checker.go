@@ -2,16 +2,22 @@ package domain
 
 import (
 	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/sirupsen/logrus"
 )
 
 type PolicyChecker interface {
-	ValidatePackage(param *policy.ParamValidatePackage) error
+	ValidatePackage(logE *logrus.Entry, param *policy.ParamValidatePackage) error
+	ValidatePostInstall(logE *logrus.Entry, param *policy.ParamValidatePackage) error
 }
 
 type MockPolicyChecker struct {
 	Err error
 }
 
-func (pc *MockPolicyChecker) ValidatePackage(param *policy.ParamValidatePackage) error {
+func (pc *MockPolicyChecker) ValidatePackage(logE *logrus.Entry, param *policy.ParamValidatePackage) error {
+	return pc.Err
+}
+
+func (pc *MockPolicyChecker) ValidatePostInstall(logE *logrus.Entry, param *policy.ParamValidatePackage) error {
 	return pc.Err
 }
@@ -0,0 +1,13 @@
+package domain
+
+type TrustChecker interface {
+	Check(cfgFilePath string) error
+}
+
+type MockTrustChecker struct {
+	Err error
+}
+
+func (checker *MockTrustChecker) Check(cfgFilePath string) error {
+	return checker.Err
+}
@@ -29,4 +29,8 @@ type FindResult struct {
 	ExePath        string
 	ConfigFilePath string
 	EnableChecksum bool
+	// Envs are environment variables in the format "<name>=<value>" set by the registry and the configuration file.
+	Envs []string
+	// ArgsPrefix is arguments which are prepended to arguments of the command.
+	ArgsPrefix []string
 }
@@ -22,12 +22,12 @@ type ChecksumDownloader struct {
 	ghRelease domain.GitHubReleaseDownloader
 }
 
-func NewChecksumDownloader(gh domain.RepositoriesService, rt *runtime.Runtime, httpDownloader HTTPDownloader) *ChecksumDownloader {
+func NewChecksumDownloader(gh domain.RepositoriesService, ghes domain.GitHubEnterpriseClients, rt *runtime.Runtime, httpDownloader HTTPDownloader) *ChecksumDownloader {
 	return &ChecksumDownloader{
 		github:    gh,
 		runtime:   rt,
 		http:      httpDownloader,
-		ghRelease: NewGitHubReleaseDownloader(gh, httpDownloader),
+		ghRelease: NewGitHubReleaseDownloader(gh, ghes, httpDownloader),
 	}
 }
 
@@ -40,10 +40,11 @@ func (dl *ChecksumDownloader) DownloadChecksum(ctx context.Context, logE *logrus
 			return nil, 0, fmt.Errorf("render a checksum file name: %w", err)
 		}
 		return dl.ghRelease.DownloadGitHubRelease(ctx, logE, &domain.DownloadGitHubReleaseParam{ //nolint:wrapcheck
-			RepoOwner: pkgInfo.RepoOwner,
-			RepoName:  pkgInfo.RepoName,
-			Version:   pkg.Package.Version,
-			Asset:     asset,
+			RepoOwner:  pkgInfo.RepoOwner,
+			RepoName:   pkgInfo.RepoName,
+			Version:    pkg.Package.Version,
+			Asset:      asset,
+			GitHubHost: pkgInfo.GitHubHost,
 		})
 	case config.PkgInfoTypeHTTP:
 		u, err := pkg.RenderChecksumURL(rt)
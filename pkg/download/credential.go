@@ -0,0 +1,199 @@
+package download
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/netrc"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+// Credential is a credential to download files from a HTTP server.
+// Credential must not be outputted to logs.
+type Credential struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+func (cred *Credential) isEmpty() bool {
+	return cred == nil || (cred.Token == "" && cred.Username == "" && cred.Password == "")
+}
+
+type CredentialGetter interface {
+	Get(ctx context.Context, host string) (*Credential, error)
+}
+
+type CredentialHelperExecutor interface {
+	ExecAndOutput(ctx context.Context, exePath string, args []string) (string, error)
+}
+
+// CredentialStore gets credentials from the following sources in order.
+// The host may include the port such as example.com:8080.
+//
+// 1. Environment variables AQUA_HTTP_TOKEN_<HOST>, or AQUA_HTTP_USERNAME_<HOST> and AQUA_HTTP_PASSWORD_<HOST>.
+// If they aren't found for the host with the port, environment variables for the host without the port are used.
+// 2. The credential helper command AQUA_HTTP_CREDENTIAL_HELPER
+// 3. .netrc ($NETRC or $HOME/.netrc). Machines in .netrc are matched with the host without the port.
+type CredentialStore struct {
+	fs       afero.Fs
+	osEnv    osenv.OSEnv
+	executor CredentialHelperExecutor
+	homeDir  string
+	cache    map[string]*Credential
+	netrc    *netrc.Netrc
+	mutex    *sync.Mutex
+	netrcErr error
+	readOnce *sync.Once
+}
+
+func NewCredentialStore(param *config.Param, fs afero.Fs, osEnv osenv.OSEnv, executor CredentialHelperExecutor) *CredentialStore {
+	return &CredentialStore{
+		fs:       fs,
+		osEnv:    osEnv,
+		executor: executor,
+		homeDir:  param.HomeDir,
+		cache:    map[string]*Credential{},
+		mutex:    &sync.Mutex{},
+		readOnce: &sync.Once{},
+	}
+}
+
+func (store *CredentialStore) Get(ctx context.Context, host string) (*Credential, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if cred, ok := store.cache[host]; ok {
+		return cred, nil
+	}
+	cred, err := store.get(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if cred.isEmpty() {
+		cred = nil
+	}
+	store.cache[host] = cred
+	return cred, nil
+}
+
+func (store *CredentialStore) get(ctx context.Context, host string) (*Credential, error) {
+	hostname := trimPort(host)
+	if cred := store.getFromEnv(host); cred != nil {
+		return cred, nil
+	}
+	if hostname != host {
+		if cred := store.getFromEnv(hostname); cred != nil {
+			return cred, nil
+		}
+	}
+	if helper := store.osEnv.Getenv("AQUA_HTTP_CREDENTIAL_HELPER"); helper != "" {
+		cred, err := store.getFromHelper(ctx, helper, host)
+		if err != nil {
+			return nil, err
+		}
+		if !cred.isEmpty() {
+			return cred, nil
+		}
+	}
+	return store.getFromNetrc(hostname)
+}
+
+// trimPort removes the port from the host.
+// e.g. example.com:8080 => example.com
+func trimPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// envSuffix converts a host to the suffix of environment variable names.
+// e.g. example.com:8080 => EXAMPLE_COM_8080
+func envSuffix(host string) string {
+	return strings.NewReplacer(".", "_", "-", "_", ":", "_").Replace(strings.ToUpper(host))
+}
+
+func (store *CredentialStore) getFromEnv(host string) *Credential {
+	suffix := envSuffix(host)
+	if token := store.osEnv.Getenv("AQUA_HTTP_TOKEN_" + suffix); token != "" {
+		return &Credential{
+			Token: token,
+		}
+	}
+	username := store.osEnv.Getenv("AQUA_HTTP_USERNAME_" + suffix)
+	password := store.osEnv.Getenv("AQUA_HTTP_PASSWORD_" + suffix)
+	if username == "" && password == "" {
+		return nil
+	}
+	return &Credential{
+		Username: username,
+		Password: password,
+	}
+}
+
+func (store *CredentialStore) getFromHelper(ctx context.Context, helper, host string) (*Credential, error) {
+	out, err := store.executor.ExecAndOutput(ctx, helper, []string{"get", host})
+	if err != nil {
+		// The output of the helper isn't included in the error because it may include secrets.
+		return nil, fmt.Errorf("execute a credential helper: %w", err)
+	}
+	if strings.TrimSpace(out) == "" {
+		return nil, nil //nolint:nilnil
+	}
+	cred := &Credential{}
+	if err := json.Unmarshal([]byte(out), cred); err != nil {
+		return nil, errParseCredentialHelperOutput
+	}
+	return cred, nil
+}
+
+func (store *CredentialStore) netrcPaths() []string {
+	if p := store.osEnv.Getenv("NETRC"); p != "" {
+		return []string{p}
+	}
+	if store.homeDir == "" {
+		return nil
+	}
+	return []string{
+		filepath.Join(store.homeDir, ".netrc"),
+		filepath.Join(store.homeDir, "_netrc"),
+	}
+}
+
+func (store *CredentialStore) readNetrc() (*netrc.Netrc, error) {
+	for _, p := range store.netrcPaths() {
+		b, err := afero.ReadFile(store.fs, p)
+		if err == nil {
+			return netrc.Parse(string(b)), nil
+		}
+		if !errors.Is(err, afero.ErrFileNotFound) {
+			return nil, fmt.Errorf("read a netrc file: %w", err)
+		}
+	}
+	return nil, nil //nolint:nilnil
+}
+
+func (store *CredentialStore) getFromNetrc(host string) (*Credential, error) {
+	store.readOnce.Do(func() {
+		store.netrc, store.netrcErr = store.readNetrc()
+	})
+	if store.netrcErr != nil {
+		return nil, store.netrcErr
+	}
+	machine := store.netrc.Find(host)
+	if machine == nil {
+		return nil, nil //nolint:nilnil
+	}
+	return &Credential{
+		Username: machine.Login,
+		Password: machine.Password,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package download_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/download"
+	"github.com/aquaproj/aqua/pkg/exec"
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+func TestCredentialStore_Get(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name     string
+		host     string
+		env      map[string]string
+		files    map[string]string
+		executor *exec.Mock
+		exp      *download.Credential
+		isErr    bool
+	}{
+		{
+			name:     "no credential",
+			host:     "example.com",
+			executor: &exec.Mock{},
+		},
+		{
+			name: "token from env",
+			host: "example-foo.com",
+			env: map[string]string{
+				"AQUA_HTTP_TOKEN_EXAMPLE_FOO_COM": "xxx",
+			},
+			files: map[string]string{
+				"/home/foo/.netrc": "machine example-foo.com login foo password bar",
+			},
+			executor: &exec.Mock{},
+			exp: &download.Credential{
+				Token: "xxx",
+			},
+		},
+		{
+			name: "basic auth from env",
+			host: "example.com",
+			env: map[string]string{
+				"AQUA_HTTP_USERNAME_EXAMPLE_COM": "foo",
+				"AQUA_HTTP_PASSWORD_EXAMPLE_COM": "bar",
+			},
+			executor: &exec.Mock{},
+			exp: &download.Credential{
+				Username: "foo",
+				Password: "bar",
+			},
+		},
+		{
+			name: "token from env with port",
+			host: "example.com:8080",
+			env: map[string]string{
+				"AQUA_HTTP_TOKEN_EXAMPLE_COM_8080": "xxx",
+				"AQUA_HTTP_TOKEN_EXAMPLE_COM":      "yyy",
+			},
+			executor: &exec.Mock{},
+			exp: &download.Credential{
+				Token: "xxx",
+			},
+		},
+		{
+			name: "token from env without port",
+			host: "example.com:8080",
+			env: map[string]string{
+				"AQUA_HTTP_TOKEN_EXAMPLE_COM": "yyy",
+			},
+			executor: &exec.Mock{},
+			exp: &download.Credential{
+				Token: "yyy",
+			},
+		},
+		{
+			name: "credential helper",
+			host: "example.com",
+			env: map[string]string{
+				"AQUA_HTTP_CREDENTIAL_HELPER": "aqua-credential-helper",
+			},
+			executor: &exec.Mock{
+				Output: `{"token": "xxx"}`,
+			},
+			exp: &download.Credential{
+				Token: "xxx",
+			},
+		},
+		{
+			name: "invalid output of credential helper",
+			host: "example.com",
+			env: map[string]string{
+				"AQUA_HTTP_CREDENTIAL_HELPER": "aqua-credential-helper",
+			},
+			executor: &exec.Mock{
+				Output: `secret`,
+			},
+			isErr: true,
+		},
+		{
+			name: "netrc",
+			host: "example.com",
+			files: map[string]string{
+				"/home/foo/.netrc": "machine example.com login foo password bar",
+			},
+			executor: &exec.Mock{},
+			exp: &download.Credential{
+				Username: "foo",
+				Password: "bar",
+			},
+		},
+		{
+			name: "netrc with port",
+			host: "example.com:8080",
+			files: map[string]string{
+				"/home/foo/.netrc": "machine example.com login foo password bar",
+			},
+			executor: &exec.Mock{},
+			exp: &download.Credential{
+				Username: "foo",
+				Password: "bar",
+			},
+		},
+		{
+			name: "NETRC",
+			host: "example.com",
+			env: map[string]string{
+				"NETRC": "/etc/netrc",
+			},
+			files: map[string]string{
+				"/home/foo/.netrc": "machine example.com login foo password bar",
+				"/etc/netrc":       "machine example.com login yoo password zoo",
+			},
+			executor: &exec.Mock{},
+			exp: &download.Credential{
+				Username: "yoo",
+				Password: "zoo",
+			},
+		},
+	}
+	ctx := context.Background()
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			for name, body := range d.files {
+				if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil { //nolint:gomnd
+					t.Fatal(err)
+				}
+			}
+			store := download.NewCredentialStore(&config.Param{
+				HomeDir: "/home/foo",
+			}, fs, osenv.NewMock(d.env), d.executor)
+			cred, err := store.Get(ctx, d.host)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, cred); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -3,7 +3,13 @@ package download
 import "errors"
 
 var (
-	errInvalidPackageType      = errors.New("package type is invalid")
-	errGitHubContentMustBeFile = errors.New("path must be not a directory but a file")
-	errInvalidHTTPStatusCode   = errors.New("status code >= 400")
+	errInvalidPackageType            = errors.New("package type is invalid")
+	errInvalidDownloadedFileType     = errors.New("file type is invalid")
+	errGitHubContentMustBeFile       = errors.New("path must be not a directory but a file")
+	errInvalidHTTPStatusCode         = errors.New("status code >= 400")
+	errGitHubEnterpriseIsUnavailable = errors.New("GitHub Enterprise Server isn't available")
+	errGitHubRateLimitExceeded       = errors.New("GitHub API rate limit exceeded. Please set the environment variable GITHUB_TOKEN or wait until the rate limit is reset")
+	errTooManyRedirects              = errors.New("stopped after 10 redirects")
+	// The output of the credential helper isn't included because it may include secrets.
+	errParseCredentialHelperOutput = errors.New("parse the output of a credential helper as JSON")
 )
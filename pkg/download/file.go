@@ -0,0 +1,77 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// FileDownloader downloads files such as signatures and certificates to verify packages.
+type FileDownloader struct {
+	http      HTTPDownloader
+	ghRelease domain.GitHubReleaseDownloader
+}
+
+func NewFileDownloader(gh domain.RepositoriesService, ghes domain.GitHubEnterpriseClients, httpDownloader HTTPDownloader) *FileDownloader {
+	return &FileDownloader{
+		http:      httpDownloader,
+		ghRelease: NewGitHubReleaseDownloader(gh, ghes, httpDownloader),
+	}
+}
+
+func (dl *FileDownloader) DownloadFile(ctx context.Context, logE *logrus.Entry, rt *runtime.Runtime, pkg *config.Package, file *registry.DownloadedFile) (io.ReadCloser, error) {
+	pkgInfo := pkg.PackageInfo
+	switch file.Type {
+	case config.PkgInfoTypeGitHubRelease:
+		asset, err := pkg.RenderDownloadedFileName(file, rt)
+		if err != nil {
+			return nil, fmt.Errorf("render an asset name: %w", err)
+		}
+		repoOwner := file.RepoOwner
+		if repoOwner == "" {
+			repoOwner = pkgInfo.RepoOwner
+		}
+		repoName := file.RepoName
+		if repoName == "" {
+			repoName = pkgInfo.RepoName
+		}
+		rc, _, err := dl.ghRelease.DownloadGitHubRelease(ctx, logE, &domain.DownloadGitHubReleaseParam{
+			RepoOwner:  repoOwner,
+			RepoName:   repoName,
+			Version:    pkg.Package.Version,
+			Asset:      asset,
+			GitHubHost: pkgInfo.GitHubHost,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("download a file from GitHub Releases: %w", logerr.WithFields(err, logrus.Fields{
+				"repo_owner": repoOwner,
+				"repo_name":  repoName,
+				"asset_name": asset,
+			}))
+		}
+		return rc, nil
+	case config.PkgInfoTypeHTTP:
+		u, err := pkg.RenderDownloadedFileURL(file, rt)
+		if err != nil {
+			return nil, fmt.Errorf("render a URL: %w", err)
+		}
+		rc, _, err := dl.http.Download(ctx, u)
+		if err != nil {
+			return rc, fmt.Errorf("download a file: %w", logerr.WithFields(err, logrus.Fields{
+				"download_url": u,
+			}))
+		}
+		return rc, nil
+	default:
+		return nil, logerr.WithFields(errInvalidDownloadedFileType, logrus.Fields{ //nolint:wrapcheck
+			"file_type": file.Type,
+		})
+	}
+}
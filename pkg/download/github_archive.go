@@ -6,11 +6,19 @@ import (
 	"io"
 
 	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/domain"
 	"github.com/aquaproj/aqua/pkg/github"
 )
 
 func (downloader *PackageDownloader) getReadCloserFromGitHubArchive(ctx context.Context, pkg *config.Package) (io.ReadCloser, int64, error) {
 	pkgInfo := pkg.PackageInfo
+	if !github.IsGitHubDotCom(pkgInfo.GitHubHost) {
+		gh, err := getGitHubEnterpriseClient(ctx, downloader.ghes, pkgInfo.GitHubHost)
+		if err != nil {
+			return nil, 0, err
+		}
+		return downloader.getReadCloserFromGitHubArchiveWithAPI(ctx, gh, pkg)
+	}
 	if rc, length, err := downloader.http.Download(ctx, fmt.Sprintf("https://github.com/%s/%s/archive/refs/tags/%s.tar.gz", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version)); err == nil {
 		return rc, length, nil
 	}
@@ -18,11 +26,16 @@ func (downloader *PackageDownloader) getReadCloserFromGitHubArchive(ctx context.
 	if rc, length, err := downloader.http.Download(ctx, fmt.Sprintf("https://github.com/%s/%s/archive/%s.tar.gz", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version)); err == nil {
 		return rc, length, nil
 	}
-	u, _, err := downloader.github.GetArchiveLink(ctx, pkgInfo.RepoOwner, pkgInfo.RepoName, github.Tarball, &github.RepositoryContentGetOptions{
+	return downloader.getReadCloserFromGitHubArchiveWithAPI(ctx, downloader.github, pkg)
+}
+
+func (downloader *PackageDownloader) getReadCloserFromGitHubArchiveWithAPI(ctx context.Context, gh domain.RepositoriesService, pkg *config.Package) (io.ReadCloser, int64, error) {
+	pkgInfo := pkg.PackageInfo
+	u, _, err := gh.GetArchiveLink(ctx, pkgInfo.RepoOwner, pkgInfo.RepoName, github.Tarball, &github.RepositoryContentGetOptions{
 		Ref: pkg.Package.Version,
 	}, true)
 	if err != nil {
-		return nil, 0, fmt.Errorf("git an archive link with GitHub API: %w", err)
+		return nil, 0, fmt.Errorf("git an archive link with GitHub API: %w", handleRateLimitError(err))
 	}
 	return downloader.http.Download(ctx, u.String()) //nolint:wrapcheck
 }
@@ -11,6 +11,7 @@ import (
 
 type GitHubContentFileDownloader struct {
 	github GitHubContentAPI
+	ghes   domain.GitHubEnterpriseClients
 	http   HTTPDownloader
 }
 
@@ -18,14 +19,22 @@ type GitHubContentAPI interface {
 	GetContents(ctx context.Context, repoOwner, repoName, path string, opt *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
 }
 
-func NewGitHubContentFileDownloader(gh GitHubContentAPI, httpDL HTTPDownloader) *GitHubContentFileDownloader {
+func NewGitHubContentFileDownloader(gh GitHubContentAPI, ghes domain.GitHubEnterpriseClients, httpDL HTTPDownloader) *GitHubContentFileDownloader {
 	return &GitHubContentFileDownloader{
 		github: gh,
+		ghes:   ghes,
 		http:   httpDL,
 	}
 }
 
 func (dl *GitHubContentFileDownloader) DownloadGitHubContentFile(ctx context.Context, logE *logrus.Entry, param *domain.GitHubContentFileParam) (*domain.GitHubContentFile, error) {
+	if !github.IsGitHubDotCom(param.GitHubHost) {
+		gh, err := getGitHubEnterpriseClient(ctx, dl.ghes, param.GitHubHost)
+		if err != nil {
+			return nil, err
+		}
+		return downloadGitHubContentFileWithAPI(ctx, gh, param)
+	}
 	// https://github.com/aquaproj/aqua/issues/391
 	body, _, err := dl.http.Download(ctx, fmt.Sprintf(
 		"https://raw.githubusercontent.com/%s/%s/%s/%s",
@@ -39,12 +48,15 @@ func (dl *GitHubContentFileDownloader) DownloadGitHubContentFile(ctx context.Con
 	if body != nil {
 		body.Close()
 	}
+	return downloadGitHubContentFileWithAPI(ctx, dl.github, param)
+}
 
-	file, _, _, err := dl.github.GetContents(ctx, param.RepoOwner, param.RepoName, param.Path, &github.RepositoryContentGetOptions{
+func downloadGitHubContentFileWithAPI(ctx context.Context, gh GitHubContentAPI, param *domain.GitHubContentFileParam) (*domain.GitHubContentFile, error) {
+	file, _, _, err := gh.GetContents(ctx, param.RepoOwner, param.RepoName, param.Path, &github.RepositoryContentGetOptions{
 		Ref: param.Ref,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("get a file by Get GitHub Content API: %w", err)
+		return nil, fmt.Errorf("get a file by Get GitHub Content API: %w", handleRateLimitError(err))
 	}
 	if file == nil {
 		return nil, errGitHubContentMustBeFile
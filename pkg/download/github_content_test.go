@@ -104,7 +104,7 @@ func TestGitHubContentFileDownloader_DownloadGitHubContentFile(t *testing.T) { /
 		d := d
 		t.Run(d.name, func(t *testing.T) {
 			t.Parallel()
-			downloader := download.NewGitHubContentFileDownloader(d.github, download.NewHTTPDownloader(d.httpClient))
+			downloader := download.NewGitHubContentFileDownloader(d.github, nil, download.NewHTTPDownloader(d.httpClient, nil))
 			file, err := downloader.DownloadGitHubContentFile(ctx, logE, d.param)
 			if err != nil {
 				if d.isErr {
@@ -0,0 +1,100 @@
+package download_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/download"
+	"github.com/aquaproj/aqua/pkg/github"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+	"golang.org/x/oauth2"
+)
+
+// newGitHubEnterpriseServer returns a server emulating the API of GitHub Enterprise Server.
+func newGitHubEnterpriseServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/suzuki-shunsuke/foo/releases/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "assets": [{"id": 10, "name": "foo.tar.gz"}]}`)
+	})
+	mux.HandleFunc("/api/v3/repos/suzuki-shunsuke/foo/releases/assets/10", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "foo")
+	})
+	mux.HandleFunc("/api/v3/repos/suzuki-shunsuke/foo/contents/registry.yaml", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "v1.0.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "content": %q}`, base64.StdEncoding.EncodeToString([]byte("packages: []")))
+	})
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xxx" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+func TestGitHubReleaseDownloader_DownloadGitHubRelease_enterprise(t *testing.T) {
+	t.Parallel()
+	server := newGitHubEnterpriseServer(t)
+	defer server.Close()
+	host := server.Listener.Addr().String()
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, server.Client())
+	ghes := github.NewEnterpriseClients(osenv.NewMock(map[string]string{
+		"AQUA_GITHUB_TOKEN_" + strings.NewReplacer(".", "_", ":", "_").Replace(host): "xxx",
+	}))
+	downloader := download.NewGitHubReleaseDownloader(nil, ghes, download.NewHTTPDownloader(server.Client(), nil))
+	rc, _, err := downloader.DownloadGitHubRelease(ctx, logrus.NewEntry(logrus.New()), &domain.DownloadGitHubReleaseParam{
+		RepoOwner:  "suzuki-shunsuke",
+		RepoName:   "foo",
+		Version:    "v1.0.0",
+		Asset:      "foo.tar.gz",
+		GitHubHost: host,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "foo" {
+		t.Fatalf("wanted foo, got %s", string(b))
+	}
+}
+
+func TestGitHubContentFileDownloader_DownloadGitHubContentFile_enterprise(t *testing.T) {
+	t.Parallel()
+	server := newGitHubEnterpriseServer(t)
+	defer server.Close()
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, server.Client())
+	ghes := github.NewEnterpriseClients(osenv.NewMock(map[string]string{
+		"GITHUB_ENTERPRISE_TOKEN":      "xxx",
+		"AQUA_GITHUB_ENTERPRISE_HOSTS": "ghe.example.com," + server.Listener.Addr().String(),
+	}))
+	downloader := download.NewGitHubContentFileDownloader(nil, ghes, download.NewHTTPDownloader(server.Client(), nil))
+	file, err := downloader.DownloadGitHubContentFile(ctx, logrus.NewEntry(logrus.New()), &domain.GitHubContentFileParam{
+		RepoOwner:  "suzuki-shunsuke",
+		RepoName:   "foo",
+		Ref:        "v1.0.0",
+		Path:       "registry.yaml",
+		GitHubHost: server.Listener.Addr().String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.String != "packages: []" {
+		t.Fatalf("wanted packages: [], got %s", file.String)
+	}
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/aquaproj/aqua/pkg/domain"
 	"github.com/aquaproj/aqua/pkg/github"
 	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
 type GitHubReleaseDownloader struct {
 	github GitHubReleaseAPI
+	ghes   domain.GitHubEnterpriseClients
 	http   HTTPDownloader
 }
 
@@ -21,14 +23,23 @@ type GitHubReleaseAPI interface {
 	DownloadReleaseAsset(ctx context.Context, owner, repoName string, assetID int64, httpClient *http.Client) (io.ReadCloser, string, error)
 }
 
-func NewGitHubReleaseDownloader(gh GitHubReleaseAPI, httpDL HTTPDownloader) *GitHubReleaseDownloader {
+func NewGitHubReleaseDownloader(gh GitHubReleaseAPI, ghes domain.GitHubEnterpriseClients, httpDL HTTPDownloader) *GitHubReleaseDownloader {
 	return &GitHubReleaseDownloader{
 		github: gh,
+		ghes:   ghes,
 		http:   httpDL,
 	}
 }
 
 func (dl *GitHubReleaseDownloader) DownloadGitHubRelease(ctx context.Context, logE *logrus.Entry, param *domain.DownloadGitHubReleaseParam) (io.ReadCloser, int64, error) {
+	if !github.IsGitHubDotCom(param.GitHubHost) {
+		// GitHub Enterprise Server is often private, so aqua downloads assets with GitHub API.
+		gh, err := getGitHubEnterpriseClient(ctx, dl.ghes, param.GitHubHost)
+		if err != nil {
+			return nil, 0, err
+		}
+		return dl.downloadWithAPI(ctx, gh, param)
+	}
 	// I have tested if downloading assets from public repository's GitHub Releases anonymously is rate limited.
 	// As a result of test, it seems not to be limited.
 	// So at first aqua tries to download assets without GitHub API.
@@ -49,24 +60,27 @@ func (dl *GitHubReleaseDownloader) DownloadGitHubRelease(ctx context.Context, lo
 		"asset_version": param.Version,
 		"asset_name":    param.Asset,
 	}).Debug("failed to download an asset from GitHub Release without GitHub API. Try again with GitHub API")
+	return dl.downloadWithAPI(ctx, dl.github, param)
+}
 
-	release, _, err := dl.github.GetReleaseByTag(ctx, param.RepoOwner, param.RepoName, param.Version)
+func (dl *GitHubReleaseDownloader) downloadWithAPI(ctx context.Context, gh GitHubReleaseAPI, param *domain.DownloadGitHubReleaseParam) (io.ReadCloser, int64, error) {
+	release, _, err := gh.GetReleaseByTag(ctx, param.RepoOwner, param.RepoName, param.Version)
 	if err != nil {
-		return nil, 0, fmt.Errorf("get the GitHub Release by Tag: %w", err)
+		return nil, 0, fmt.Errorf("get the GitHub Release by Tag: %w", handleRateLimitError(err))
 	}
 	assetID, err := getAssetIDFromAssets(release.Assets, param.Asset)
 	if err != nil {
 		return nil, 0, err
 	}
-	body, redirectURL, err := dl.github.DownloadReleaseAsset(ctx, param.RepoOwner, param.RepoName, assetID, http.DefaultClient)
+	body, redirectURL, err := gh.DownloadReleaseAsset(ctx, param.RepoOwner, param.RepoName, assetID, http.DefaultClient)
 	if err != nil {
-		return nil, 0, fmt.Errorf("download the release asset (asset id: %d): %w", assetID, err)
+		return nil, 0, fmt.Errorf("download the release asset (asset id: %d): %w", assetID, handleRateLimitError(err))
 	}
 	if body != nil {
 		// DownloadReleaseAsset doesn't return a http.Response, so the content length is zero.
 		return body, 0, nil
 	}
-	b, length, err = dl.http.Download(ctx, redirectURL)
+	b, length, err := dl.http.Download(ctx, redirectURL)
 	if err != nil {
 		if b != nil {
 			b.Close()
@@ -84,3 +98,18 @@ func getAssetIDFromAssets(assets []*github.ReleaseAsset, assetName string) (int6
 	}
 	return 0, fmt.Errorf("the asset isn't found: %s", assetName)
 }
+
+func getGitHubEnterpriseClient(ctx context.Context, ghes domain.GitHubEnterpriseClients, host string) (*github.RepositoriesService, error) {
+	if ghes == nil {
+		return nil, logerr.WithFields(errGitHubEnterpriseIsUnavailable, logrus.Fields{ //nolint:wrapcheck
+			"github_host": host,
+		})
+	}
+	gh, err := ghes.Get(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("get a GitHub Enterprise Server client: %w", logerr.WithFields(err, logrus.Fields{
+			"github_host": host,
+		}))
+	}
+	return gh, nil
+}
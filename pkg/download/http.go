@@ -11,14 +11,18 @@ type HTTPDownloader interface {
 	Download(ctx context.Context, u string) (io.ReadCloser, int64, error)
 }
 
-func NewHTTPDownloader(httpClient *http.Client) HTTPDownloader {
+// NewHTTPDownloader returns a HTTPDownloader.
+// If credGetter is nil, files are downloaded anonymously.
+func NewHTTPDownloader(httpClient *http.Client, credGetter CredentialGetter) HTTPDownloader {
 	return &httpDownloader{
-		client: httpClient,
+		client:     httpClient,
+		credGetter: credGetter,
 	}
 }
 
 type httpDownloader struct {
-	client *http.Client
+	client     *http.Client
+	credGetter CredentialGetter
 }
 
 func (downloader *httpDownloader) Download(ctx context.Context, u string) (io.ReadCloser, int64, error) {
@@ -26,7 +30,15 @@ func (downloader *httpDownloader) Download(ctx context.Context, u string) (io.Re
 	if err != nil {
 		return nil, 0, fmt.Errorf("create a http request: %w", err)
 	}
-	resp, err := downloader.client.Do(req)
+	client := downloader.client
+	attached, err := downloader.setCredential(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	if attached {
+		client = dropCredentialOnCrossHostRedirect(client)
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, 0, fmt.Errorf("send http request: %w", err)
 	}
@@ -35,3 +47,46 @@ func (downloader *httpDownloader) Download(ctx context.Context, u string) (io.Re
 	}
 	return resp.Body, resp.ContentLength, nil
 }
+
+// setCredential sets a credential to the request.
+// To prevent credentials from being leaked, credentials are sent only over HTTPS.
+func (downloader *httpDownloader) setCredential(ctx context.Context, req *http.Request) (bool, error) {
+	if downloader.credGetter == nil || req.URL.Scheme != "https" {
+		return false, nil
+	}
+	cred, err := downloader.credGetter.Get(ctx, req.URL.Host)
+	if err != nil {
+		return false, fmt.Errorf("get a credential: %w", err)
+	}
+	if cred.isEmpty() {
+		return false, nil
+	}
+	if cred.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+cred.Token)
+		return true, nil
+	}
+	req.SetBasicAuth(cred.Username, cred.Password)
+	return true, nil
+}
+
+// dropCredentialOnCrossHostRedirect returns a copy of the client which removes the Authorization header
+// when the request is redirected to a different host or scheme.
+// e.g. the credential isn't sent if the request is redirected from https://example.com to http://example.com.
+func dropCredentialOnCrossHostRedirect(client *http.Client) *http.Client {
+	c := *client
+	checkRedirect := client.CheckRedirect
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > 0 && (req.URL.Host != via[0].URL.Host || req.URL.Scheme != via[0].URL.Scheme) {
+			req.Header.Del("Authorization")
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		// Same as the default policy of net/http.
+		if len(via) >= 10 { //nolint:gomnd
+			return errTooManyRedirects
+		}
+		return nil
+	}
+	return &c
+}
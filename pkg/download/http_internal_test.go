@@ -2,8 +2,12 @@ package download
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/suzuki-shunsuke/flute/flute"
@@ -15,6 +19,7 @@ func Test_fromURL(t *testing.T) { //nolint:funlen
 		title      string
 		url        string
 		httpClient *http.Client
+		credGetter CredentialGetter
 		isErr      bool
 		body       string
 	}{
@@ -47,13 +52,129 @@ func Test_fromURL(t *testing.T) { //nolint:funlen
 				},
 			},
 		},
+		{
+			title: "token",
+			url:   "https://example.com/v0.1.0/foo",
+			body:  "xxxxxx",
+			credGetter: &MockCredentialGetter{
+				Credentials: map[string]*Credential{
+					"example.com": {
+						Token: "xxx",
+					},
+				},
+			},
+			httpClient: &http.Client{
+				Transport: &flute.Transport{
+					Services: []flute.Service{
+						{
+							Endpoint: "https://example.com",
+							Routes: []flute.Route{
+								{
+									Name: "download an asset",
+									Matcher: &flute.Matcher{
+										Method: "GET",
+										Path:   "/v0.1.0/foo",
+										Header: http.Header{
+											"Authorization": []string{"Bearer xxx"},
+										},
+									},
+									Response: &flute.Response{
+										Base: http.Response{
+											StatusCode: http.StatusOK,
+										},
+										BodyString: "xxxxxx",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			title: "basic auth",
+			url:   "https://example.com/v0.1.0/foo",
+			body:  "xxxxxx",
+			credGetter: &MockCredentialGetter{
+				Credentials: map[string]*Credential{
+					"example.com": {
+						Username: "foo",
+						Password: "bar",
+					},
+				},
+			},
+			httpClient: &http.Client{
+				Transport: &flute.Transport{
+					Services: []flute.Service{
+						{
+							Endpoint: "https://example.com",
+							Routes: []flute.Route{
+								{
+									Name: "download an asset",
+									Matcher: &flute.Matcher{
+										Method: "GET",
+										Path:   "/v0.1.0/foo",
+										Header: http.Header{
+											"Authorization": []string{"Basic Zm9vOmJhcg=="},
+										},
+									},
+									Response: &flute.Response{
+										Base: http.Response{
+											StatusCode: http.StatusOK,
+										},
+										BodyString: "xxxxxx",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			title: "credentials aren't sent over http",
+			url:   "http://example.com/v0.1.0/foo",
+			body:  "xxxxxx",
+			credGetter: &MockCredentialGetter{
+				Credentials: map[string]*Credential{
+					"example.com": {
+						Token: "xxx",
+					},
+				},
+			},
+			httpClient: &http.Client{
+				Transport: &flute.Transport{
+					Services: []flute.Service{
+						{
+							Endpoint: "http://example.com",
+							Routes: []flute.Route{
+								{
+									Name: "download an asset",
+									Matcher: &flute.Matcher{
+										Method: "GET",
+										Path:   "/v0.1.0/foo",
+										Header: http.Header{},
+									},
+									Response: &flute.Response{
+										Base: http.Response{
+											StatusCode: http.StatusOK,
+										},
+										BodyString: "xxxxxx",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	ctx := context.Background()
 	for _, d := range data {
 		d := d
 		t.Run(d.title, func(t *testing.T) {
 			t.Parallel()
-			httpDownloader := NewHTTPDownloader(d.httpClient)
+			httpDownloader := NewHTTPDownloader(d.httpClient, d.credGetter)
 			readCloser, _, err := httpDownloader.Download(ctx, d.url)
 			if readCloser != nil {
 				defer readCloser.Close()
@@ -77,3 +198,97 @@ func Test_fromURL(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func Test_httpDownloader_Download_redirect(t *testing.T) {
+	t.Parallel()
+	other := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer other.Close()
+	origin := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xxx" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.Redirect(w, r, other.URL+"/foo", http.StatusFound)
+	}))
+	defer origin.Close()
+	client := origin.Client()
+	client.Transport.(*http.Transport).TLSClientConfig.RootCAs.AddCert(other.Certificate()) //nolint:forcetypeassert
+	downloader := NewHTTPDownloader(client, &MockCredentialGetter{
+		Credentials: map[string]*Credential{
+			strings.TrimPrefix(origin.URL, "https://"): {
+				Token: "xxx",
+			},
+		},
+	})
+	readCloser, _, err := downloader.Download(context.Background(), origin.URL+"/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readCloser.Close()
+	b, err := io.ReadAll(readCloser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 0 {
+		t.Fatalf("the credential must not be sent to a different host: %s", string(b))
+	}
+}
+
+func Test_dropCredentialOnCrossHostRedirect(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name    string
+		from    string
+		to      string
+		removed bool
+	}{
+		{
+			name: "same host",
+			from: "https://example.com/foo",
+			to:   "https://example.com/bar",
+		},
+		{
+			name:    "different host",
+			from:    "https://example.com/foo",
+			to:      "https://example.org/bar",
+			removed: true,
+		},
+		{
+			name:    "different port",
+			from:    "https://example.com/foo",
+			to:      "https://example.com:8443/bar",
+			removed: true,
+		},
+		{
+			name:    "downgrade to http",
+			from:    "https://example.com/foo",
+			to:      "http://example.com/bar",
+			removed: true,
+		},
+	}
+	client := dropCredentialOnCrossHostRedirect(&http.Client{})
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			from, err := url.Parse(d.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			to, err := url.Parse(d.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := &http.Request{URL: to, Header: http.Header{}}
+			req.Header.Set("Authorization", "Bearer xxx")
+			if err := client.CheckRedirect(req, []*http.Request{{URL: from}}); err != nil {
+				t.Fatal(err)
+			}
+			if removed := req.Header.Get("Authorization") == ""; removed != d.removed {
+				t.Fatalf("wanted %v, got %v", d.removed, removed)
+			}
+		})
+	}
+}
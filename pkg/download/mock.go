@@ -0,0 +1,12 @@
+package download
+
+import "context"
+
+type MockCredentialGetter struct {
+	Credentials map[string]*Credential
+	Err         error
+}
+
+func (getter *MockCredentialGetter) Get(ctx context.Context, host string) (*Credential, error) {
+	return getter.Credentials[host], getter.Err
+}
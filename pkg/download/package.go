@@ -15,19 +15,21 @@ import (
 
 type PackageDownloader struct {
 	github    domain.RepositoriesService
+	ghes      domain.GitHubEnterpriseClients
 	runtime   *runtime.Runtime
 	http      HTTPDownloader
 	ghContent domain.GitHubContentFileDownloader
 	ghRelease domain.GitHubReleaseDownloader
 }
 
-func NewPackageDownloader(gh domain.RepositoriesService, rt *runtime.Runtime, httpDownloader HTTPDownloader) *PackageDownloader {
+func NewPackageDownloader(gh domain.RepositoriesService, ghes domain.GitHubEnterpriseClients, rt *runtime.Runtime, httpDownloader HTTPDownloader) *PackageDownloader {
 	return &PackageDownloader{
 		github:    gh,
+		ghes:      ghes,
 		runtime:   rt,
 		http:      httpDownloader,
-		ghContent: NewGitHubContentFileDownloader(gh, httpDownloader),
-		ghRelease: NewGitHubReleaseDownloader(gh, httpDownloader),
+		ghContent: NewGitHubContentFileDownloader(gh, ghes, httpDownloader),
+		ghRelease: NewGitHubReleaseDownloader(gh, ghes, httpDownloader),
 	}
 }
 
@@ -40,18 +42,20 @@ func (downloader *PackageDownloader) GetReadCloser(ctx context.Context, pkg *con
 	case config.PkgInfoTypeGitHubRelease:
 		pkgInfo := pkg.PackageInfo
 		return downloader.ghRelease.DownloadGitHubRelease(ctx, logE, &domain.DownloadGitHubReleaseParam{ //nolint:wrapcheck
-			RepoOwner: pkgInfo.RepoOwner,
-			RepoName:  pkgInfo.RepoName,
-			Version:   pkg.Package.Version,
-			Asset:     assetName,
+			RepoOwner:  pkgInfo.RepoOwner,
+			RepoName:   pkgInfo.RepoName,
+			Version:    pkg.Package.Version,
+			Asset:      assetName,
+			GitHubHost: pkgInfo.GitHubHost,
 		})
 	case config.PkgInfoTypeGitHubContent:
 		pkgInfo := pkg.PackageInfo
 		file, err := downloader.ghContent.DownloadGitHubContentFile(ctx, logE, &domain.GitHubContentFileParam{
-			RepoOwner: pkgInfo.RepoOwner,
-			RepoName:  pkgInfo.RepoName,
-			Ref:       pkg.Package.Version,
-			Path:      assetName,
+			RepoOwner:  pkgInfo.RepoOwner,
+			RepoName:   pkgInfo.RepoName,
+			Ref:        pkg.Package.Version,
+			Path:       assetName,
+			GitHubHost: pkgInfo.GitHubHost,
 		})
 		if err != nil {
 			return nil, 0, fmt.Errorf("download a package from GitHub Content: %w", err)
@@ -375,7 +375,7 @@ func Test_PackageDownloader_GetReadCloser(t *testing.T) { //nolint:funlen,mainti
 		d := d
 		t.Run(d.name, func(t *testing.T) {
 			t.Parallel()
-			downloader := download.NewPackageDownloader(d.github, d.rt, download.NewHTTPDownloader(d.httpClient))
+			downloader := download.NewPackageDownloader(d.github, nil, d.rt, download.NewHTTPDownloader(d.httpClient, nil))
 			file, _, err := downloader.GetReadCloser(ctx, d.pkg, d.assetName, logE, nil)
 			if err != nil {
 				if d.isErr {
@@ -0,0 +1,42 @@
+package download
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// defaultAbuseRetryAfter is the time to wait when the secondary rate limit error doesn't have Retry-After header.
+// GitHub recommends to wait at least one minute.
+// https://docs.github.com/en/rest/overview/resources-in-the-rest-api#secondary-rate-limits
+const defaultAbuseRetryAfter = time.Minute
+
+// handleRateLimitError converts the rate limit error of GitHub API to errGitHubRateLimitExceeded with the reset time.
+// Other errors are returned as is.
+func handleRateLimitError(err error) error {
+	return convertRateLimitError(err, time.Now())
+}
+
+func convertRateLimitError(err error, now time.Time) error {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return logerr.WithFields(errGitHubRateLimitExceeded, logrus.Fields{ //nolint:wrapcheck
+			"rate_limit":       rateLimitErr.Rate.Limit,
+			"rate_limit_reset": rateLimitErr.Rate.Reset.Time.Format(time.RFC3339),
+		})
+	}
+	var abuseRateLimitErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseRateLimitErr) {
+		retryAfter := abuseRateLimitErr.GetRetryAfter()
+		if retryAfter == 0 {
+			retryAfter = defaultAbuseRetryAfter
+		}
+		return logerr.WithFields(errGitHubRateLimitExceeded, logrus.Fields{ //nolint:wrapcheck
+			"rate_limit_reset": now.Add(retryAfter).Format(time.RFC3339),
+		})
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package download
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+func Test_convertRateLimitError(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	errFoo := errors.New("foo")
+	retryAfter := 30 * time.Second
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := []struct {
+		title  string
+		err    error
+		exp    error
+		fields logrus.Fields
+	}{
+		{
+			title: "rate limit",
+			err: fmt.Errorf("get a release: %w", &github.RateLimitError{
+				Rate: github.Rate{
+					Limit: 60,
+					Reset: github.Timestamp{Time: time.Date(2022, 1, 1, 1, 0, 0, 0, time.UTC)},
+				},
+			}),
+			exp: errGitHubRateLimitExceeded,
+			fields: logrus.Fields{
+				"rate_limit":       60,
+				"rate_limit_reset": "2022-01-01T01:00:00Z",
+			},
+		},
+		{
+			title: "abuse rate limit",
+			err: &github.AbuseRateLimitError{
+				RetryAfter: &retryAfter,
+			},
+			exp: errGitHubRateLimitExceeded,
+			fields: logrus.Fields{
+				"rate_limit_reset": "2022-01-01T00:00:30Z",
+			},
+		},
+		{
+			title: "abuse rate limit without Retry-After",
+			err:   &github.AbuseRateLimitError{},
+			exp:   errGitHubRateLimitExceeded,
+			fields: logrus.Fields{
+				"rate_limit_reset": "2022-01-01T00:01:00Z",
+			},
+		},
+		{
+			title:  "other error",
+			err:    errFoo,
+			exp:    errFoo,
+			fields: logrus.Fields{},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			err := convertRateLimitError(d.err, now)
+			if !errors.Is(err, d.exp) {
+				t.Fatalf("wanted %v, got %v", d.exp, err)
+			}
+			fields := logerr.WithError(logrus.NewEntry(logrus.New()), err).Data
+			delete(fields, logrus.ErrorKey)
+			if diff := cmp.Diff(d.fields, fields); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package exec
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"os"
 	"os/exec"
 
+	"github.com/aquaproj/aqua/pkg/util"
 	"github.com/suzuki-shunsuke/go-timeout/timeout"
 )
 
@@ -38,8 +40,14 @@ func (exe *Executor) exec(ctx context.Context, cmd *exec.Cmd) (int, error) {
 	return 0, nil
 }
 
-func (exe *Executor) Exec(ctx context.Context, exePath string, args []string) (int, error) {
-	return exe.exec(ctx, exe.command(exec.Command(exePath, args...)))
+// Exec executes a command.
+// envs are added to environment variables of the current process and take precedence over them.
+func (exe *Executor) Exec(ctx context.Context, exePath string, args, envs []string) (int, error) {
+	cmd := exe.command(exec.Command(exePath, args...))
+	if len(envs) != 0 {
+		cmd.Env = util.MergeEnvs(os.Environ(), envs)
+	}
+	return exe.exec(ctx, cmd)
 }
 
 func (exe *Executor) GoBuild(ctx context.Context, exePath, src, exeDir string) (int, error) {
@@ -53,3 +61,30 @@ func (exe *Executor) GoInstall(ctx context.Context, path, gobin string) (int, er
 	cmd.Env = append(os.Environ(), "GOBIN="+gobin)
 	return exe.exec(ctx, cmd)
 }
+
+// ExecHook executes a hook such as post_install in the directory dir.
+// Environment variables of the current process aren't inherited, so only envs are passed to the command.
+// The standard input isn't passed and the standard output is outputted to the standard error,
+// because the hook may be run while executing other command via aqua exec.
+func (exe *Executor) ExecHook(ctx context.Context, exePath string, args []string, dir string, envs []string) (int, error) {
+	cmd := exe.command(exec.Command(exePath, args...))
+	cmd.Stdin = nil
+	cmd.Stdout = exe.stderr
+	cmd.Dir = dir
+	cmd.Env = envs
+	if envs == nil {
+		cmd.Env = []string{}
+	}
+	return exe.exec(ctx, cmd)
+}
+
+// ExecAndOutput executes a command and returns the standard output.
+// The standard output isn't outputted to the terminal because it may include secrets.
+func (exe *Executor) ExecAndOutput(ctx context.Context, exePath string, args []string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := exe.command(exec.Command(exePath, args...))
+	cmd.Stdin = nil
+	cmd.Stdout = out
+	_, err := exe.exec(ctx, cmd)
+	return out.String(), err
+}
@@ -13,6 +13,7 @@ func TestExecutorExec(t *testing.T) {
 		name     string
 		exePath  string
 		args     []string
+		envs     []string
 		isErr    bool
 		exitCode int
 	}{
@@ -20,6 +21,18 @@ func TestExecutorExec(t *testing.T) {
 			name:    "/bin/date",
 			exePath: "/bin/date",
 		},
+		{
+			name:    "envs",
+			exePath: "/bin/sh",
+			args:    []string{"-c", `test "$AQUA_TEST_FOO" = bar`},
+			envs:    []string{"AQUA_TEST_FOO=bar"},
+		},
+		{
+			name:    "envs aren't set",
+			exePath: "/bin/sh",
+			args:    []string{"-c", `test "$AQUA_TEST_FOO" = bar`},
+			isErr:   true,
+		},
 	}
 	executor := exec.New()
 	ctx := context.Background()
@@ -27,7 +40,7 @@ func TestExecutorExec(t *testing.T) {
 		d := d
 		t.Run(d.name, func(t *testing.T) {
 			t.Parallel()
-			exitCode, err := executor.Exec(ctx, d.exePath, d.args)
+			exitCode, err := executor.Exec(ctx, d.exePath, d.args, d.envs)
 			if d.isErr {
 				if err == nil {
 					t.Fatal("err should be returned")
@@ -43,3 +56,60 @@ func TestExecutorExec(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutorExecHook(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name    string
+		exePath string
+		args    []string
+		dir     string
+		envs    []string
+		isErr   bool
+	}{
+		{
+			name:    "dir",
+			exePath: "/bin/sh",
+			args:    []string{"-c", `test "$(pwd)" = /`},
+			dir:     "/",
+		},
+		{
+			name:    "envs",
+			exePath: "/bin/sh",
+			args:    []string{"-c", `test "$AQUA_TEST_FOO" = bar`},
+			dir:     "/",
+			envs:    []string{"AQUA_TEST_FOO=bar"},
+		},
+		{
+			name:    "environment variables of the current process aren't inherited",
+			exePath: "/bin/sh",
+			args:    []string{"-c", `test -z "$HOME"`},
+			dir:     "/",
+		},
+		{
+			name:    "failure",
+			exePath: "/bin/sh",
+			args:    []string{"-c", "exit 1"},
+			dir:     "/",
+			isErr:   true,
+		},
+	}
+	executor := exec.New()
+	ctx := context.Background()
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := executor.ExecHook(ctx, d.exePath, d.args, d.dir, d.envs)
+			if d.isErr {
+				if err == nil {
+					t.Fatal("err should be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -6,14 +6,15 @@ import (
 
 type Mock struct {
 	ExitCode int
+	Output   string
 	Err      error
 }
 
-func (exe *Mock) Exec(ctx context.Context, exePath string, args []string) (int, error) {
+func (exe *Mock) Exec(ctx context.Context, exePath string, args, envs []string) (int, error) {
 	return exe.ExitCode, exe.Err
 }
 
-func (exe *Mock) ExecXSys(exePath string, args []string) error {
+func (exe *Mock) ExecXSys(exePath string, args, envs []string) error {
 	return exe.Err
 }
 
@@ -24,3 +25,11 @@ func (exe *Mock) GoBuild(ctx context.Context, exePath, src, exeDir string) (int,
 func (exe *Mock) GoInstall(ctx context.Context, path, gobin string) (int, error) {
 	return exe.ExitCode, exe.Err
 }
+
+func (exe *Mock) ExecHook(ctx context.Context, exePath string, args []string, dir string, envs []string) (int, error) {
+	return exe.ExitCode, exe.Err
+}
+
+func (exe *Mock) ExecAndOutput(ctx context.Context, exePath string, args []string) (string, error) {
+	return exe.Output, exe.Err
+}
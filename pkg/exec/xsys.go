@@ -7,9 +7,10 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/aquaproj/aqua/pkg/util"
 	"golang.org/x/sys/unix"
 )
 
-func (exe *Executor) ExecXSys(exePath string, args []string) error {
-	return unix.Exec(exePath, append([]string{filepath.Base(exePath)}, args...), os.Environ()) //nolint:wrapcheck
+func (exe *Executor) ExecXSys(exePath string, args, envs []string) error {
+	return unix.Exec(exePath, append([]string{filepath.Base(exePath)}, args...), util.MergeEnvs(os.Environ(), envs)) //nolint:wrapcheck
 }
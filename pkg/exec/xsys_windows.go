@@ -7,6 +7,6 @@ import "errors"
 
 var errXSysNotSuppported = errors.New("Windows doesn't support AQUA_EXPERIMENTAL_X_SYS_EXEC")
 
-func (exe *Executor) ExecXSys(exePath string, args []string) error {
+func (exe *Executor) ExecXSys(exePath string, args, envs []string) error {
 	return errXSysNotSuppported
 }
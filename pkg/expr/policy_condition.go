@@ -0,0 +1,17 @@
+package expr
+
+// PackageMetadata is attributes of a package which policies can refer to.
+type PackageMetadata struct {
+	PackageType string
+	RepoOwner   string
+	RepoName    string
+	// URLHost is the host of the URL of http packages. e.g. example.com
+	URLHost         string
+	ChecksumEnabled bool
+	// SupportedEnvs is ["all"] if the package supports all environments.
+	SupportedEnvs []string
+}
+
+func EvaluatePolicyCondition(condition string, metadata *PackageMetadata) (bool, error) {
+	return evaluateBool(condition, &PackageMetadata{}, metadata)
+}
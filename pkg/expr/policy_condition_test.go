@@ -0,0 +1,73 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/expr"
+)
+
+func TestEvaluatePolicyCondition(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title     string
+		condition string
+		metadata  *expr.PackageMetadata
+		exp       bool
+		isErr     bool
+	}{
+		{
+			title:     "true",
+			condition: `PackageType == "github_release" && RepoOwner in ["aquaproj", "suzuki-shunsuke"] && ChecksumEnabled`,
+			metadata: &expr.PackageMetadata{
+				PackageType:     "github_release",
+				RepoOwner:       "suzuki-shunsuke",
+				RepoName:        "tfcmt",
+				ChecksumEnabled: true,
+				SupportedEnvs:   []string{"all"},
+			},
+			exp: true,
+		},
+		{
+			title:     "false",
+			condition: `PackageType != "http" || URLHost == "example.com"`,
+			metadata: &expr.PackageMetadata{
+				PackageType: "http",
+				URLHost:     "example.org",
+			},
+			exp: false,
+		},
+		{
+			title:     "supported envs",
+			condition: `"darwin" in SupportedEnvs`,
+			metadata: &expr.PackageMetadata{
+				SupportedEnvs: []string{"darwin", "linux/amd64"},
+			},
+			exp: true,
+		},
+		{
+			title:     "unknown field",
+			condition: `Foo == "foo"`,
+			metadata:  &expr.PackageMetadata{},
+			isErr:     true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			b, err := expr.EvaluatePolicyCondition(d.condition, d.metadata)
+			if d.isErr {
+				if err == nil {
+					t.Fatal("err should be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, b)
+			}
+		})
+	}
+}
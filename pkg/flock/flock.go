@@ -0,0 +1,95 @@
+package flock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aquaproj/aqua/pkg/util"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const (
+	defaultTimeout                   = 10 * time.Minute
+	defaultRetryInterval             = 100 * time.Millisecond
+	filePermission       os.FileMode = 0o644
+)
+
+var errTimeout = errors.New("timeout to acquire the file lock")
+
+// Locker acquires file locks shared between processes.
+type Locker struct {
+	fs            afero.Fs
+	timeout       time.Duration
+	retryInterval time.Duration
+}
+
+func New(fs afero.Fs) *Locker {
+	return &Locker{
+		fs:            fs,
+		timeout:       defaultTimeout,
+		retryInterval: defaultRetryInterval,
+	}
+}
+
+// fdFile is a file which has the file descriptor such as *os.File.
+type fdFile interface {
+	Fd() uintptr
+}
+
+// Lock acquires the exclusive lock of the file and returns a function to release the lock.
+// If the file is locked by other process, Lock waits until the lock is released or the timeout is exceeded.
+// The lock is released by the OS when the process exits, so an interrupted process never leaves the lock.
+// If the file system doesn't provide file descriptors such as afero.MemMapFs, the lock is a no-op
+// because the file system isn't shared with other processes.
+func (locker *Locker) Lock(ctx context.Context, logE *logrus.Entry, p string) (func() error, error) {
+	file, err := locker.fs.OpenFile(p, os.O_CREATE|os.O_RDWR, filePermission) //nolint:nosnakecase
+	if err != nil {
+		return nil, fmt.Errorf("open a lock file: %w", logerr.WithFields(err, logrus.Fields{
+			"lock_file": p,
+		}))
+	}
+	f, ok := file.(fdFile)
+	if !ok {
+		return file.Close, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, locker.timeout)
+	defer cancel()
+	waiting := false
+	for {
+		locked, err := tryLock(f.Fd())
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("lock a file: %w", logerr.WithFields(err, logrus.Fields{
+				"lock_file": p,
+			}))
+		}
+		if locked {
+			return func() error {
+				defer file.Close()
+				if err := unlock(f.Fd()); err != nil {
+					return fmt.Errorf("unlock a file: %w", err)
+				}
+				return nil
+			}, nil
+		}
+		if !waiting {
+			logE.WithField("lock_file", p).Info("wait until other process releases the lock")
+			waiting = true
+		}
+		if err := util.Wait(ctx, locker.retryInterval); err != nil {
+			file.Close()
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = errTimeout
+			}
+			return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"lock_file": p,
+				"timeout":   locker.timeout,
+			})
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package flock
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestLocker_Lock(t *testing.T) {
+	t.Parallel()
+	locker := &Locker{
+		fs:            afero.NewOsFs(),
+		timeout:       50 * time.Millisecond,
+		retryInterval: 10 * time.Millisecond,
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	p := filepath.Join(t.TempDir(), "foo.lock")
+
+	unlock, err := locker.Lock(ctx, logE, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := locker.Lock(ctx, logE, p); err == nil {
+		t.Fatal("the lock must not be acquired while other holds it")
+	}
+	if err := unlock(); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = locker.Lock(ctx, logE, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLocker_Lock_memMapFs(t *testing.T) {
+	t.Parallel()
+	locker := &Locker{
+		fs:            afero.NewMemMapFs(),
+		timeout:       50 * time.Millisecond,
+		retryInterval: 10 * time.Millisecond,
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	p := "/home/foo/.local/share/aquaproj-aqua/foo.lock"
+	if err := locker.fs.MkdirAll(filepath.Dir(p), 0o775); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err := locker.Lock(ctx, logE, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock() //nolint:errcheck
+	// the file system isn't shared with other processes, so the lock is a no-op
+	unlock2, err := locker.Lock(ctx, logE, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unlock2(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package flock
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+func tryLock(fd uintptr) (bool, error) {
+	if err := unix.Flock(int(fd), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return false, nil
+		}
+		return false, err //nolint:wrapcheck
+	}
+	return true, nil
+}
+
+func unlock(fd uintptr) error {
+	return unix.Flock(int(fd), unix.LOCK_UN) //nolint:wrapcheck
+}
@@ -0,0 +1,24 @@
+//go:build windows
+// +build windows
+
+package flock
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows"
+)
+
+func tryLock(fd uintptr) (bool, error) {
+	if err := windows.LockFileEx(windows.Handle(fd), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &windows.Overlapped{}); err != nil {
+		if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+			return false, nil
+		}
+		return false, err //nolint:wrapcheck
+	}
+	return true, nil
+}
+
+func unlock(fd uintptr) error {
+	return windows.UnlockFileEx(windows.Handle(fd), 0, 1, 0, &windows.Overlapped{}) //nolint:wrapcheck
+}
@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+	"golang.org/x/oauth2"
+)
+
+var (
+	errInvalidPrivateKey = errors.New("the private key of GitHub App is invalid")
+	errInvalidAppID      = errors.New("AQUA_GITHUB_APP_ID is invalid")
+)
+
+// appConfig is the configuration of GitHub App.
+type appConfig struct {
+	appID          string
+	privateKeyPath string
+}
+
+// getAppConfig returns the configuration of GitHub App.
+// If AQUA_GITHUB_APP_ID or AQUA_GITHUB_APP_PRIVATE_KEY_FILE isn't set, nil is returned.
+func getAppConfig(osEnv osenv.OSEnv) *appConfig {
+	appID := osEnv.Getenv("AQUA_GITHUB_APP_ID")
+	keyPath := osEnv.Getenv("AQUA_GITHUB_APP_PRIVATE_KEY_FILE")
+	if appID == "" || keyPath == "" {
+		return nil
+	}
+	return &appConfig{
+		appID:          appID,
+		privateKeyPath: keyPath,
+	}
+}
+
+// appAuth mints GitHub App installation access tokens.
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation
+type appAuth struct {
+	mutex     *sync.Mutex
+	appClient *github.Client
+	initErr   error
+	// installation access tokens per installation id
+	tokenSources map[int64]oauth2.TokenSource
+}
+
+func newAppAuth(ctx context.Context, param *clientParam) *appAuth {
+	auth := &appAuth{
+		mutex:        &sync.Mutex{},
+		tokenSources: map[int64]oauth2.TokenSource{},
+	}
+	key, err := readPrivateKey(param.fs, param.app.privateKeyPath)
+	if err != nil {
+		auth.initErr = err
+		return auth
+	}
+	appID, err := strconv.ParseInt(param.app.appID, 10, 64)
+	if err != nil {
+		auth.initErr = errInvalidAppID
+		return auth
+	}
+	client, err := newClient(param.baseURL, oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, &jwtTokenSource{
+		appID: appID,
+		key:   key,
+	})))
+	if err != nil {
+		auth.initErr = err
+		return auth
+	}
+	auth.appClient = client
+	return auth
+}
+
+func readPrivateKey(fs afero.Fs, p string) (*rsa.PrivateKey, error) {
+	b, err := afero.ReadFile(fs, p)
+	if err != nil {
+		return nil, fmt.Errorf("read the private key of GitHub App: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errInvalidPrivateKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errInvalidPrivateKey
+	}
+	key, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errInvalidPrivateKey
+	}
+	return key, nil
+}
+
+// httpClient returns a http client authenticated with an installation access token.
+// If the GitHub App isn't installed to the repository, nil is returned.
+// If it fails to find the installation, the error is logged and nil is returned
+// so that the request falls back to the default access token.
+func (auth *appAuth) httpClient(ctx context.Context, logE *logrus.Entry, owner, repo string) (*http.Client, error) {
+	if auth.initErr != nil {
+		return nil, auth.initErr
+	}
+	installation, resp, err := auth.appClient.Apps.FindRepositoryInstallation(ctx, owner, repo)
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			logE.WithError(err).WithFields(logrus.Fields{
+				"repo_owner": owner,
+				"repo_name":  repo,
+			}).Warn("find a GitHub App installation")
+		}
+		return nil, nil
+	}
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	id := installation.GetID()
+	src, ok := auth.tokenSources[id]
+	if !ok {
+		src = oauth2.ReuseTokenSource(nil, &installationTokenSource{
+			client:         auth.appClient,
+			installationID: id,
+		})
+		auth.tokenSources[id] = src
+	}
+	return oauth2.NewClient(ctx, src), nil
+}
+
+// installationTokenSource creates an installation access token.
+// The token expires after one hour, and oauth2.ReuseTokenSource refreshes it.
+// The context of the request isn't used because the token source outlives the request.
+type installationTokenSource struct {
+	client         *github.Client
+	installationID int64
+}
+
+func (src *installationTokenSource) Token() (*oauth2.Token, error) {
+	token, _, err := src.client.Apps.CreateInstallationToken(context.Background(), src.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create a GitHub App installation access token: %w", err)
+	}
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		Expiry:      token.GetExpiresAt(),
+	}, nil
+}
+
+// jwtTokenSource creates a JSON Web Token (JWT) to authenticate as a GitHub App.
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/generating-a-json-web-token-jwt-for-a-github-app
+type jwtTokenSource struct {
+	appID int64
+	key   *rsa.PrivateKey
+}
+
+func (src *jwtTokenSource) Token() (*oauth2.Token, error) {
+	now := time.Now()
+	// The maximum expiration time is 10 minutes.
+	expiry := now.Add(9 * time.Minute) //nolint:gomnd
+	token, err := createJWT(src.appID, src.key, now, expiry)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{
+		AccessToken: token,
+		Expiry:      expiry,
+	}, nil
+}
+
+func createJWT(appID int64, key *rsa.PrivateKey, now, expiry time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal a JWT header: %w", err)
+	}
+	claims, err := json.Marshal(map[string]int64{
+		// To protect against clock drift, iat is set 60 seconds in the past.
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": expiry.Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal JWT claims: %w", err)
+	}
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign a JWT: %w", err)
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+const defaultHost = "github.com"
+
+// IsGitHubDotCom returns true if the host is empty or github.com.
+func IsGitHubDotCom(host string) bool {
+	return host == "" || host == defaultHost
+}
+
+// EnterpriseClients creates and caches GitHub API clients for GitHub Enterprise Server per host.
+type EnterpriseClients struct {
+	osEnv   osenv.OSEnv
+	clients map[string]*RepositoriesService
+	mutex   *sync.Mutex
+}
+
+func NewEnterpriseClients(osEnv osenv.OSEnv) *EnterpriseClients {
+	return &EnterpriseClients{
+		osEnv:   osEnv,
+		clients: map[string]*RepositoriesService{},
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// Get returns a GitHub API client for the GitHub Enterprise Server.
+// The base URL is https://<host>/api/v3/ and the upload URL is https://<host>/api/uploads/ .
+func (ec *EnterpriseClients) Get(ctx context.Context, host string) (*RepositoriesService, error) {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+	if client, ok := ec.clients[host]; ok {
+		return client, nil
+	}
+	client := newRepositoriesService(ctx, &clientParam{
+		osEnv:        ec.osEnv,
+		baseURL:      "https://" + host + "/",
+		defaultToken: ec.getToken(host),
+	})
+	ec.clients[host] = client
+	return client, nil
+}
+
+// getToken returns a GitHub access token for the GitHub Enterprise Server.
+// The token for github.com isn't used to prevent the token from being leaked.
+// e.g. AQUA_GITHUB_TOKEN_GHE_EXAMPLE_COM for ghe.example.com
+// GITHUB_ENTERPRISE_TOKEN is used only for hosts listed in AQUA_GITHUB_ENTERPRISE_HOSTS,
+// because github_host is set by registries and packages and the token mustn't be sent to untrusted hosts.
+func (ec *EnterpriseClients) getToken(host string) string {
+	if token := ec.osEnv.Getenv("AQUA_GITHUB_TOKEN_" + envSuffix(host)); token != "" {
+		return token
+	}
+	for _, h := range strings.Split(ec.osEnv.Getenv("AQUA_GITHUB_ENTERPRISE_HOSTS"), ",") {
+		if strings.TrimSpace(h) == host {
+			return ec.osEnv.Getenv("GITHUB_ENTERPRISE_TOKEN")
+		}
+	}
+	return ""
+}
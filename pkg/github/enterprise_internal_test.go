@@ -0,0 +1,57 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+func TestEnterpriseClients_getToken(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		envs map[string]string
+		exp  string
+	}{
+		{
+			name: "per host token",
+			envs: map[string]string{
+				"AQUA_GITHUB_TOKEN_GHE_EXAMPLE_COM": "foo",
+				"GITHUB_ENTERPRISE_TOKEN":           "bar",
+				"AQUA_GITHUB_ENTERPRISE_HOSTS":      "ghe.example.com",
+			},
+			exp: "foo",
+		},
+		{
+			name: "allowed host",
+			envs: map[string]string{
+				"GITHUB_ENTERPRISE_TOKEN":      "bar",
+				"AQUA_GITHUB_ENTERPRISE_HOSTS": "ghe.example.org, ghe.example.com",
+			},
+			exp: "bar",
+		},
+		{
+			name: "host isn't allowed",
+			envs: map[string]string{
+				"GITHUB_ENTERPRISE_TOKEN":      "bar",
+				"AQUA_GITHUB_ENTERPRISE_HOSTS": "ghe.example.org",
+			},
+		},
+		{
+			name: "no allowed host",
+			envs: map[string]string{
+				"GITHUB_ENTERPRISE_TOKEN": "bar",
+			},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ec := NewEnterpriseClients(osenv.NewMock(d.envs))
+			if token := ec.getToken("ghe.example.com"); token != d.exp {
+				t.Fatalf("wanted %q, got %q", d.exp, token)
+			}
+		})
+	}
+}
@@ -3,9 +3,11 @@ package github
 import (
 	"context"
 	"net/http"
-	"os"
+	"strings"
 
 	"github.com/google/go-github/v45/github"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
 	"golang.org/x/oauth2"
 )
 
@@ -13,7 +15,6 @@ type (
 	ReleaseAsset                = github.ReleaseAsset
 	ListOptions                 = github.ListOptions
 	RepositoryRelease           = github.RepositoryRelease
-	RepositoriesService         = github.RepositoriesService
 	Repository                  = github.Repository
 	RepositoryContentGetOptions = github.RepositoryContentGetOptions
 	RepositoryContent           = github.RepositoryContent
@@ -24,20 +25,46 @@ type (
 
 const Tarball = github.Tarball
 
-func New(ctx context.Context) *github.RepositoriesService {
-	return github.NewClient(getHTTPClientForGitHub(ctx, getGitHubToken())).Repositories
+// New returns a client for github.com.
+// An access token is selected by the repository owner.
+//
+// 1. AQUA_GITHUB_OWNER_TOKEN_<OWNER>
+// 2. GitHub App installation access token (AQUA_GITHUB_APP_ID and AQUA_GITHUB_APP_PRIVATE_KEY_FILE)
+// 3. AQUA_GITHUB_TOKEN or GITHUB_TOKEN
+func New(ctx context.Context, osEnv osenv.OSEnv, fs afero.Fs) *RepositoriesService {
+	return newRepositoriesService(ctx, &clientParam{
+		fs:           fs,
+		osEnv:        osEnv,
+		defaultToken: getGitHubToken(osEnv),
+		ownerToken:   true,
+		app:          getAppConfig(osEnv),
+	})
 }
 
-func getGitHubToken() string {
-	if token := os.Getenv("AQUA_GITHUB_TOKEN"); token != "" {
+func getGitHubToken(osEnv osenv.OSEnv) string {
+	if token := osEnv.Getenv("AQUA_GITHUB_TOKEN"); token != "" {
 		return token
 	}
-	return os.Getenv("GITHUB_TOKEN")
+	return osEnv.Getenv("GITHUB_TOKEN")
+}
+
+// getOwnerToken returns an access token for the repository owner.
+// e.g. AQUA_GITHUB_OWNER_TOKEN_AQUAPROJ for aquaproj
+func getOwnerToken(osEnv osenv.OSEnv, owner string) string {
+	return osEnv.Getenv("AQUA_GITHUB_OWNER_TOKEN_" + envSuffix(owner))
+}
+
+// envSuffix converts a string to the suffix of environment variable names.
+// e.g. ghe.example.com => GHE_EXAMPLE_COM
+func envSuffix(s string) string {
+	return strings.NewReplacer(".", "_", "-", "_", ":", "_").Replace(strings.ToUpper(s))
 }
 
 func getHTTPClientForGitHub(ctx context.Context, token string) *http.Client {
 	if token == "" {
-		return http.DefaultClient
+		// If the context has a http client, it is used.
+		// Otherwise, http.DefaultClient is used.
+		return oauth2.NewClient(ctx, nil)
 	}
 	return oauth2.NewClient(ctx, oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
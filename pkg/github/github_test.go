@@ -5,11 +5,13 @@ import (
 	"testing"
 
 	"github.com/aquaproj/aqua/pkg/github"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
 )
 
 func TestNew(t *testing.T) {
 	t.Parallel()
-	if client := github.New(context.Background()); client == nil {
+	if client := github.New(context.Background(), osenv.NewMock(nil), afero.NewMemMapFs()); client == nil {
 		t.Fatal("client must not be nil")
 	}
 }
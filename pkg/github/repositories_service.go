@@ -0,0 +1,190 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+// RepositoriesService is a wrapper of GitHub Repositories API.
+// It selects an access token by the repository owner.
+// Access tokens must not be outputted to logs.
+type RepositoriesService struct {
+	param   *clientParam
+	clients map[string]*github.RepositoriesService
+	app     *appAuth
+	mutex   *sync.Mutex
+}
+
+type clientParam struct {
+	fs    afero.Fs
+	osEnv osenv.OSEnv
+	logE  *logrus.Entry
+	// baseURL is the base URL of GitHub Enterprise Server.
+	// If baseURL is empty, github.com is used.
+	baseURL      string
+	defaultToken string
+	// If ownerToken is true, AQUA_GITHUB_OWNER_TOKEN_<OWNER> is used.
+	ownerToken bool
+	app        *appConfig
+}
+
+func newRepositoriesService(ctx context.Context, param *clientParam) *RepositoriesService {
+	if param.logE == nil {
+		param.logE = logrus.NewEntry(logrus.StandardLogger())
+	}
+	svc := &RepositoriesService{
+		param:   param,
+		clients: map[string]*github.RepositoriesService{},
+		mutex:   &sync.Mutex{},
+	}
+	if param.app != nil {
+		svc.app = newAppAuth(ctx, param)
+	}
+	return svc
+}
+
+func newClient(baseURL string, httpClient *http.Client) (*github.Client, error) {
+	if baseURL == "" {
+		return github.NewClient(httpClient), nil
+	}
+	client, err := github.NewEnterpriseClient(baseURL, baseURL, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("create a GitHub Enterprise Server client: %w", err)
+	}
+	return client, nil
+}
+
+func (svc *RepositoriesService) newClient(httpClient *http.Client) (*github.RepositoriesService, error) {
+	client, err := newClient(svc.param.baseURL, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return client.Repositories, nil
+}
+
+// client returns a client for the repository.
+// Clients are cached per owner, but they are cached per repository if GitHub App is used
+// because GitHub App is installed per repository.
+func (svc *RepositoriesService) client(ctx context.Context, owner, repo string) (*github.RepositoriesService, error) {
+	svc.mutex.Lock()
+	defer svc.mutex.Unlock()
+	key := svc.cacheKey(owner, repo)
+	if client, ok := svc.clients[key]; ok {
+		return client, nil
+	}
+	client, err := svc.createClient(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	svc.clients[key] = client
+	return client, nil
+}
+
+func (svc *RepositoriesService) cacheKey(owner, repo string) string {
+	if svc.app == nil {
+		return owner
+	}
+	if svc.param.ownerToken && getOwnerToken(svc.param.osEnv, owner) != "" {
+		return owner
+	}
+	return owner + "/" + repo
+}
+
+func (svc *RepositoriesService) createClient(ctx context.Context, owner, repo string) (*github.RepositoriesService, error) {
+	if svc.param.ownerToken {
+		if token := getOwnerToken(svc.param.osEnv, owner); token != "" {
+			return svc.newClient(getHTTPClientForGitHub(ctx, token))
+		}
+	}
+	if svc.app != nil {
+		httpClient, err := svc.app.httpClient(ctx, svc.param.logE, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		if httpClient != nil {
+			return svc.newClient(httpClient)
+		}
+	}
+	return svc.newClient(getHTTPClientForGitHub(ctx, svc.param.defaultToken))
+}
+
+func (svc *RepositoriesService) Get(ctx context.Context, owner, repo string) (*Repository, *Response, error) {
+	client, err := svc.client(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.Get(ctx, owner, repo) //nolint:wrapcheck
+}
+
+func (svc *RepositoriesService) GetLatestRelease(ctx context.Context, repoOwner, repoName string) (*RepositoryRelease, *Response, error) {
+	client, err := svc.client(ctx, repoOwner, repoName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.GetLatestRelease(ctx, repoOwner, repoName) //nolint:wrapcheck
+}
+
+func (svc *RepositoriesService) GetReleaseByTag(ctx context.Context, owner, repoName, version string) (*RepositoryRelease, *Response, error) {
+	client, err := svc.client(ctx, owner, repoName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.GetReleaseByTag(ctx, owner, repoName, version) //nolint:wrapcheck
+}
+
+func (svc *RepositoriesService) ListReleases(ctx context.Context, owner, repo string, opts *ListOptions) ([]*RepositoryRelease, *Response, error) {
+	client, err := svc.client(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.ListReleases(ctx, owner, repo, opts) //nolint:wrapcheck
+}
+
+func (svc *RepositoriesService) ListReleaseAssets(ctx context.Context, owner, repo string, id int64, opts *ListOptions) ([]*ReleaseAsset, *Response, error) {
+	client, err := svc.client(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.ListReleaseAssets(ctx, owner, repo, id, opts) //nolint:wrapcheck
+}
+
+func (svc *RepositoriesService) ListTags(ctx context.Context, owner string, repo string, opts *ListOptions) ([]*RepositoryTag, *Response, error) {
+	client, err := svc.client(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.ListTags(ctx, owner, repo, opts) //nolint:wrapcheck
+}
+
+func (svc *RepositoriesService) DownloadReleaseAsset(ctx context.Context, owner, repoName string, assetID int64, httpClient *http.Client) (io.ReadCloser, string, error) {
+	client, err := svc.client(ctx, owner, repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	return client.DownloadReleaseAsset(ctx, owner, repoName, assetID, httpClient) //nolint:wrapcheck
+}
+
+func (svc *RepositoriesService) GetArchiveLink(ctx context.Context, owner, repo string, archiveformat ArchiveFormat, opts *RepositoryContentGetOptions, followRedirects bool) (*url.URL, *Response, error) {
+	client, err := svc.client(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.GetArchiveLink(ctx, owner, repo, archiveformat, opts, followRedirects) //nolint:wrapcheck
+}
+
+func (svc *RepositoriesService) GetContents(ctx context.Context, repoOwner, repoName, path string, opt *RepositoryContentGetOptions) (*RepositoryContent, []*RepositoryContent, *Response, error) {
+	client, err := svc.client(ctx, repoOwner, repoName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return client.GetContents(ctx, repoOwner, repoName, path, opt) //nolint:wrapcheck
+}
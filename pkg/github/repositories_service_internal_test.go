@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+	"golang.org/x/oauth2"
+)
+
+func verifyJWT(t *testing.T, key *rsa.PrivateKey, token string) bool {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:gomnd
+		return false
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	return rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig) == nil
+}
+
+// newServer returns a server emulating GitHub API.
+// Each repository returns the latest release whose tag is the access token used for the request.
+func newServer(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch r.URL.Path {
+		case "/api/v3/repos/app-installed/foo/installation":
+			if !verifyJWT(t, key, token) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"id": 5}`)
+		case "/api/v3/repos/app-not-installed/foo/installation", "/api/v3/repos/app-installed/bar/installation":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v3/repos/app-error/foo/installation":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/api/v3/app/installations/5/access_tokens":
+			if !verifyJWT(t, key, token) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"token": "installation-token", "expires_at": "2100-01-01T00:00:00Z"}`)
+		default:
+			fmt.Fprintf(w, `{"tag_name": %q}`, token)
+		}
+	}))
+}
+
+func TestRepositoriesService_GetLatestRelease(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 2048) //nolint:gomnd
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newServer(t, key)
+	defer server.Close()
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/app.pem", pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	osEnv := osenv.NewMock(map[string]string{
+		"AQUA_GITHUB_OWNER_TOKEN_OWNER_TOKEN": "owner-token",
+		"AQUA_GITHUB_APP_ID":                  "1",
+		"AQUA_GITHUB_APP_PRIVATE_KEY_FILE":    "/app.pem",
+	})
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, server.Client())
+	svc := newRepositoriesService(ctx, &clientParam{
+		fs:           fs,
+		osEnv:        osEnv,
+		baseURL:      server.URL + "/",
+		defaultToken: "default-token",
+		ownerToken:   true,
+		app:          getAppConfig(osEnv),
+	})
+	data := []struct {
+		owner string
+		repo  string
+		exp   string
+	}{
+		{
+			owner: "owner-token",
+			repo:  "foo",
+			exp:   "owner-token",
+		},
+		{
+			owner: "app-installed",
+			repo:  "foo",
+			exp:   "installation-token",
+		},
+		{
+			owner: "app-installed",
+			repo:  "bar",
+			exp:   "default-token",
+		},
+		{
+			owner: "app-not-installed",
+			repo:  "foo",
+			exp:   "default-token",
+		},
+		{
+			owner: "app-error",
+			repo:  "foo",
+			exp:   "default-token",
+		},
+	}
+	for _, d := range data {
+		release, _, err := svc.GetLatestRelease(ctx, d.owner, d.repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if release.GetTagName() != d.exp {
+			t.Fatalf("%s/%s: wanted %s, got %s", d.owner, d.repo, d.exp, release.GetTagName())
+		}
+	}
+}
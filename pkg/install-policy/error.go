@@ -0,0 +1,5 @@
+package installpolicy
+
+import "errors"
+
+var errSHA256Mismatch = errors.New("the sha256 digest of the policy file is unmatched")
@@ -0,0 +1,139 @@
+package installpolicy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/download"
+	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const (
+	dirPermission  os.FileMode = 0o775
+	filePermission os.FileMode = 0o600
+)
+
+// ConfigReader reads policy files.
+// Remote policy files are downloaded and cached like registries.
+type ConfigReader struct {
+	rootDir             string
+	ghContentDownloader domain.GitHubContentFileDownloader
+	httpDownloader      download.HTTPDownloader
+	fs                  afero.Fs
+	reader              *policy.ConfigReader
+	locker              domain.Locker
+}
+
+func (reader *ConfigReader) Read(ctx context.Context, logE *logrus.Entry, files []string) ([]*policy.Config, error) {
+	paths := make([]string, len(files))
+	for i, file := range files {
+		p, err := reader.install(ctx, logE, file)
+		if err != nil {
+			return nil, fmt.Errorf("install a policy file: %w", logerr.WithFields(err, logrus.Fields{
+				"policy_file": file,
+			}))
+		}
+		paths[i] = p
+	}
+	return reader.reader.Read(paths) //nolint:wrapcheck
+}
+
+// install downloads the remote policy file and returns the path of the cached file.
+// If the file is a local file, the file path is returned as is.
+// If the cached file is unmatched with the sha256 digest, the file is downloaded again.
+func (reader *ConfigReader) install(ctx context.Context, logE *logrus.Entry, file string) (string, error) {
+	rc, err := policy.ParseRemoteConfig(file)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	if rc == nil {
+		return file, nil
+	}
+	p, err := rc.GetFilePath(reader.rootDir)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	// Lock the policy file so that other processes don't read the policy file while it is being written.
+	if err := reader.fs.MkdirAll(filepath.Dir(p), dirPermission); err != nil {
+		return "", fmt.Errorf("create the parent directory of the policy file: %w", err)
+	}
+	unlock, err := reader.locker.Lock(ctx, logE, p+".lock")
+	if err != nil {
+		return "", fmt.Errorf("lock the policy file: %w", err)
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			logerr.WithError(logE, err).Warn("release the lock of the policy file")
+		}
+	}()
+	if b, err := afero.ReadFile(reader.fs, p); err == nil {
+		if sha256Sum(b) == rc.SHA256 {
+			return p, nil
+		}
+		logE.WithField("policy_file_path", p).Debug("the cached policy file is unmatched with the sha256 digest")
+	}
+	b, err := reader.download(ctx, logE, rc)
+	if err != nil {
+		return "", err
+	}
+	if s := sha256Sum(b); s != rc.SHA256 {
+		return "", logerr.WithFields(errSHA256Mismatch, logrus.Fields{ //nolint:wrapcheck
+			"expected_sha256": rc.SHA256,
+			"actual_sha256":   s,
+		})
+	}
+	if err := afero.WriteFile(reader.fs, p, b, filePermission); err != nil {
+		return "", fmt.Errorf("write the policy file: %w", err)
+	}
+	return p, nil
+}
+
+func (reader *ConfigReader) download(ctx context.Context, logE *logrus.Entry, rc *policy.RemoteConfig) ([]byte, error) {
+	if rc.Type == policy.RemoteTypeGitHubContent {
+		file, err := reader.ghContentDownloader.DownloadGitHubContentFile(ctx, logE, &domain.GitHubContentFileParam{
+			RepoOwner:  rc.RepoOwner,
+			RepoName:   rc.RepoName,
+			Ref:        rc.Ref,
+			Path:       rc.Path,
+			GitHubHost: rc.GitHubHost,
+		})
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		if file.String != "" {
+			return []byte(file.String), nil
+		}
+		defer file.ReadCloser.Close()
+		b, err := io.ReadAll(file.ReadCloser)
+		if err != nil {
+			return nil, fmt.Errorf("read the policy file: %w", err)
+		}
+		return b, nil
+	}
+	body, _, err := reader.httpDownloader.Download(ctx, rc.URL)
+	if err != nil {
+		return nil, fmt.Errorf("download the policy file: %w", logerr.WithFields(err, logrus.Fields{
+			"download_url": rc.URL,
+		}))
+	}
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("read the policy file: %w", err)
+	}
+	return b, nil
+}
+
+func sha256Sum(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
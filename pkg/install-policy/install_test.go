@@ -0,0 +1,119 @@
+package installpolicy_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/domain"
+	installpolicy "github.com/aquaproj/aqua/pkg/install-policy"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const policyFile = `registries:
+- type: standard
+  ref: semver(">= 3.0.0")
+packages:
+- registry: standard
+`
+
+type mockGitHubContentFileDownloader struct {
+	content string
+}
+
+func (dl *mockGitHubContentFileDownloader) DownloadGitHubContentFile(ctx context.Context, logE *logrus.Entry, param *domain.GitHubContentFileParam) (*domain.GitHubContentFile, error) {
+	return &domain.GitHubContentFile{
+		String: dl.content,
+	}, nil
+}
+
+type mockHTTPDownloader struct {
+	content string
+}
+
+func (dl *mockHTTPDownloader) Download(ctx context.Context, u string) (io.ReadCloser, int64, error) {
+	return io.NopCloser(strings.NewReader(dl.content)), int64(len(dl.content)), nil
+}
+
+func TestConfigReader_Read(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	// sha256 digest of policyFile
+	const digest = "6c24fe36e0e4d5bfec1ee9fab8dc26456acb51752b2aa6344e914d50daae8207"
+	data := []struct {
+		name    string
+		files   []string
+		cache   map[string]string
+		content string
+		isErr   bool
+	}{
+		{
+			name:    "github_content",
+			files:   []string{"github_content://aquaproj/aqua-policy/aqua-policy.yaml#ref=v1.0.0&sha256=" + digest},
+			content: policyFile,
+		},
+		{
+			name:    "http",
+			files:   []string{"https://example.com/aqua-policy.yaml#sha256=" + digest},
+			content: policyFile,
+		},
+		{
+			name:    "cache",
+			files:   []string{"https://example.com/aqua-policy.yaml#sha256=" + digest},
+			content: "invalid",
+			cache: map[string]string{
+				"/home/foo/.local/share/aquaproj-aqua/policies/http/example.com/aqua-policy.yaml": policyFile,
+			},
+		},
+		{
+			name:    "tampered cache is downloaded again",
+			files:   []string{"https://example.com/aqua-policy.yaml#sha256=" + digest},
+			content: policyFile,
+			cache: map[string]string{
+				"/home/foo/.local/share/aquaproj-aqua/policies/http/example.com/aqua-policy.yaml": "tampered",
+			},
+		},
+		{
+			name:    "sha256 is unmatched",
+			files:   []string{"https://example.com/aqua-policy.yaml#sha256=" + digest},
+			content: policyFile + "- name: cli/cli\n",
+			isErr:   true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			for name, body := range d.cache {
+				if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			reader := installpolicy.NewConfigReader(&config.Param{
+				RootDir: "/home/foo/.local/share/aquaproj-aqua",
+			}, &mockGitHubContentFileDownloader{
+				content: d.content,
+			}, &mockHTTPDownloader{
+				content: d.content,
+			}, fs, &domain.MockLocker{})
+			cfgs, err := reader.Read(ctx, logE, d.files)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if len(cfgs) != len(d.files) {
+				t.Fatalf("wanted %d policy files, got %d", len(d.files), len(cfgs))
+			}
+		})
+	}
+}
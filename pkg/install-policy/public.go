@@ -0,0 +1,20 @@
+package installpolicy
+
+import (
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/download"
+	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/spf13/afero"
+)
+
+func NewConfigReader(param *config.Param, ghContentDownloader domain.GitHubContentFileDownloader, httpDownloader download.HTTPDownloader, fs afero.Fs, locker domain.Locker) *ConfigReader {
+	return &ConfigReader{
+		rootDir:             param.RootDir,
+		ghContentDownloader: ghContentDownloader,
+		httpDownloader:      httpDownloader,
+		fs:                  fs,
+		reader:              policy.NewConfigReader(fs),
+		locker:              locker,
+	}
+}
@@ -24,6 +24,7 @@ type Installer struct {
 	registryDownloader domain.GitHubContentFileDownloader
 	param              *config.Param
 	fs                 afero.Fs
+	locker             domain.Locker
 }
 
 var errMaxParallelismMustBeGreaterThanZero = errors.New("MaxParallelism must be greater than zero")
@@ -99,10 +100,29 @@ const dirPermission os.FileMode = 0o775
 // installRegistry installs and reads the registry file and returns the registry content.
 // If the registry file already exists, the installation is skipped.
 func (inst *Installer) installRegistry(ctx context.Context, regist *aqua.Registry, cfgFilePath string, logE *logrus.Entry) (*registry.Config, error) {
+	if err := regist.Validate(); err != nil {
+		return nil, fmt.Errorf("validate the registry: %w", err)
+	}
 	registryFilePath, err := regist.GetFilePath(inst.param.RootDir, cfgFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("get a registry file path: %w", err)
 	}
+	// Local registries are never written by aqua, so only registries which are downloaded are locked.
+	if regist.Type != aqua.RegistryTypeLocal {
+		// Lock the registry file so that other processes don't read the registry file while it is being written.
+		if err := inst.fs.MkdirAll(filepath.Dir(registryFilePath), dirPermission); err != nil {
+			return nil, fmt.Errorf("create the parent directory of the configuration file: %w", err)
+		}
+		unlock, err := inst.locker.Lock(ctx, logE, registryFilePath+".lock")
+		if err != nil {
+			return nil, fmt.Errorf("lock the registry file: %w", err)
+		}
+		defer func() {
+			if err := unlock(); err != nil {
+				logerr.WithError(logE, err).Warn("release the lock of the registry file")
+			}
+		}()
+	}
 	if _, err := inst.fs.Stat(registryFilePath); err == nil {
 		registryContent := &registry.Config{}
 		if err := inst.readRegistry(registryFilePath, registryContent); err != nil {
@@ -110,9 +130,6 @@ func (inst *Installer) installRegistry(ctx context.Context, regist *aqua.Registr
 		}
 		return registryContent, nil
 	}
-	if err := inst.fs.MkdirAll(filepath.Dir(registryFilePath), dirPermission); err != nil {
-		return nil, fmt.Errorf("create the parent directory of the configuration file: %w", err)
-	}
 	return inst.getRegistry(ctx, regist, registryFilePath, logE)
 }
 
@@ -133,10 +150,11 @@ const registryFilePermission = 0o600
 
 func (inst *Installer) getGitHubContentRegistry(ctx context.Context, regist *aqua.Registry, registryFilePath string, logE *logrus.Entry) (*registry.Config, error) {
 	ghContentFile, err := inst.registryDownloader.DownloadGitHubContentFile(ctx, logE, &domain.GitHubContentFileParam{
-		RepoOwner: regist.RepoOwner,
-		RepoName:  regist.RepoName,
-		Ref:       regist.Ref,
-		Path:      regist.Path,
+		RepoOwner:  regist.RepoOwner,
+		RepoName:   regist.RepoName,
+		Ref:        regist.Ref,
+		Path:       regist.Path,
+		GitHubHost: regist.GitHubHost,
 	})
 	if err != nil {
 		return nil, err //nolint:wrapcheck
@@ -104,7 +104,7 @@ func TestInstaller_InstallRegistries(t *testing.T) { //nolint:funlen
 					},
 				},
 			},
-			downloader: download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(&http.Client{
+			downloader: download.NewGitHubContentFileDownloader(nil, nil, download.NewHTTPDownloader(&http.Client{
 				Transport: &flute.Transport{
 					Services: []flute.Service{
 						{
@@ -155,7 +155,7 @@ func TestInstaller_InstallRegistries(t *testing.T) { //nolint:funlen
 						},
 					},
 				},
-			})),
+			}, nil)),
 		},
 	}
 	logE := logrus.NewEntry(logrus.New())
@@ -170,7 +170,7 @@ func TestInstaller_InstallRegistries(t *testing.T) { //nolint:funlen
 					t.Fatal(err)
 				}
 			}
-			inst := registry.New(d.param, d.downloader, fs)
+			inst := registry.New(d.param, d.downloader, fs, &domain.MockLocker{})
 			registries, err := inst.InstallRegistries(ctx, d.cfg, d.cfgFilePath, logE)
 			if err != nil {
 				if d.isErr {
@@ -6,10 +6,11 @@ import (
 	"github.com/spf13/afero"
 )
 
-func New(param *config.Param, downloader domain.GitHubContentFileDownloader, fs afero.Fs) *Installer {
+func New(param *config.Param, downloader domain.GitHubContentFileDownloader, fs afero.Fs, locker domain.Locker) *Installer {
 	return &Installer{
 		param:              param,
 		registryDownloader: downloader,
 		fs:                 fs,
+		locker:             locker,
 	}
 }
@@ -11,17 +11,10 @@ import (
 	"github.com/aquaproj/aqua/pkg/checksum"
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/afero"
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
 func (inst *Installer) extractChecksum(pkg *config.Package, assetName string, checksumFile []byte) (string, error) {
-	pkgInfo := pkg.PackageInfo
-
-	if pkgInfo.Checksum.FileFormat == "raw" {
-		return strings.TrimSpace(string(checksumFile)), nil
-	}
-
 	m, s, err := inst.checksumFileParser.ParseChecksumFile(string(checksumFile), pkg)
 	if err != nil {
 		return "", fmt.Errorf("parse a checksum file: %w", err)
@@ -45,6 +38,10 @@ func (inst *Installer) dlAndExtractChecksum(ctx context.Context, logE *logrus.En
 		return "", fmt.Errorf("read a checksum file: %w", err)
 	}
 
+	if err := inst.verifier.VerifyChecksumFile(ctx, logE, inst.runtime, pkg, b); err != nil {
+		return "", fmt.Errorf("verify a checksum file: %w", err)
+	}
+
 	c, err := inst.extractChecksum(pkg, assetName, b)
 	if err != nil {
 		return "", err
@@ -57,39 +54,83 @@ func (inst *Installer) dlAndExtractChecksum(ctx context.Context, logE *logrus.En
 
 type ParamVerifyChecksum struct {
 	ChecksumID string
-	Checksum   *checksum.Checksum
-	Checksums  *checksum.Checksums
-	Pkg        *config.Package
-	AssetName  string
-	Body       io.Reader
-	TempDir    string
+	// KnownChecksums are checksums recorded in aqua-checksums.json.
+	KnownChecksums []*checksum.Checksum
+	Checksums      *checksum.Checksums
+	// SaveCalculatedChecksum is whether checksums calculated from the asset are saved.
+	// Checksums got from aqua-checksums.json and checksum files are always saved.
+	SaveCalculatedChecksum bool
+	Pkg                    *config.Package
+	AssetName              string
+	Body                   io.Reader
+	TempDir                string
 }
 
-func copyAsset(fs afero.Fs, tempFilePath string, body io.Reader) error {
-	file, err := fs.Create(tempFilePath)
+// copyAsset copies the asset to a temporal file and calculates checksums of the asset at the same time.
+func (inst *Installer) copyAsset(tempFilePath string, body io.Reader, algorithms []string) (map[string]string, error) {
+	file, err := inst.fs.Create(tempFilePath)
 	if err != nil {
-		return fmt.Errorf("create a temporal file: %w", logerr.WithFields(err, logrus.Fields{
+		return nil, fmt.Errorf("create a temporal file: %w", logerr.WithFields(err, logrus.Fields{
 			"temp_file": tempFilePath,
 		}))
 	}
 	defer file.Close()
 
-	if _, err := io.Copy(file, body); err != nil {
-		return err //nolint:wrapcheck
+	if len(algorithms) == 0 {
+		if _, err := io.Copy(file, body); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		return nil, nil //nolint:nilnil
+	}
+
+	sums, err := inst.checksumCalculator.Sum(io.TeeReader(body, file), algorithms)
+	if err != nil {
+		return nil, fmt.Errorf("calculate a checksum of downloaded file: %w", logerr.WithFields(err, logrus.Fields{
+			"temp_file": tempFilePath,
+		}))
+	}
+	return sums, nil
+}
+
+// getChecksumAlgorithms returns algorithms of known checksums and the algorithm of the registry.
+func getChecksumAlgorithms(knowns []*checksum.Checksum, algorithm string) []string {
+	algorithms := make([]string, 0, len(knowns)+1)
+	for _, chk := range knowns {
+		algorithms = append(algorithms, chk.Algorithm)
+	}
+	if findChecksum(knowns, algorithm) == nil {
+		algorithms = append(algorithms, algorithm)
+	}
+	return algorithms
+}
+
+func findChecksum(chksums []*checksum.Checksum, algorithm string) *checksum.Checksum {
+	for _, chk := range chksums {
+		if chk.Algorithm == algorithm {
+			return chk
+		}
 	}
 	return nil
 }
 
+func hasSLSAProvenance(chksums []*checksum.Checksum) bool {
+	for _, chk := range chksums {
+		if chk.GetSLSAProvenance() != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (inst *Installer) verifyChecksum(ctx context.Context, logE *logrus.Entry, param *ParamVerifyChecksum) (io.ReadCloser, error) { //nolint:cyclop,funlen
 	pkg := param.Pkg
 	pkgInfo := pkg.PackageInfo
 	checksums := param.Checksums
-	chksum := param.Checksum
+	knowns := param.KnownChecksums
 	checksumID := param.ChecksumID
 	tempDir := param.TempDir
 
-	// Download an asset in a temporal directory
-	// Calculate the checksum of download asset
+	// Download an asset in a temporal directory and calculate checksums of the asset at the same time
 	// Download a checksum file
 	// Extract the checksum from the checksum file
 	// Compare the checksum
@@ -106,11 +147,46 @@ func (inst *Installer) verifyChecksum(ctx context.Context, logE *logrus.Entry, p
 	if assetName == "" && (pkgInfo.Type == "github_archive" || pkgInfo.Type == "go") {
 		tempFilePath = filepath.Join(tempDir, "archive.tar.gz")
 	}
-	if err := copyAsset(inst.fs, tempFilePath, param.Body); err != nil {
+	algorithm := pkgInfo.Checksum.GetAlgorithm()
+	var algorithms []string
+	if checksums != nil {
+		// Checksums of known algorithms are verified and the checksum of the registry's algorithm is recorded.
+		algorithms = getChecksumAlgorithms(knowns, algorithm)
+	}
+	sums, err := inst.copyAsset(tempFilePath, param.Body, algorithms)
+	if err != nil {
 		return nil, err
 	}
 
-	if chksum == nil && pkgInfo.Checksum.GetEnabled() {
+	// The signature is verified only when the checksum isn't known yet.
+	// Known checksums come from aqua-checksums.json or the checksum file,
+	// so the file is trusted as much as them and the signature isn't verified again.
+	// aqua-checksums.json should be reviewed and the checksum file should be verified with checksum.cosign.
+	if len(knowns) == 0 && pkgInfo.Cosign.GetEnabled() {
+		if err := inst.verifyFileWithCosign(ctx, logE, pkg, pkgInfo.Cosign, tempFilePath); err != nil {
+			return nil, err
+		}
+	}
+
+	// If the checksum was verified with SLSA Provenance, the verification is skipped.
+	var slsaProvenance *checksum.SLSAProvenance
+	if pkgInfo.SLSAProvenance.GetEnabled() && !hasSLSAProvenance(knowns) {
+		sp, err := inst.verifySLSAProvenance(ctx, logE, pkg, tempFilePath)
+		if err != nil {
+			return nil, err
+		}
+		slsaProvenance = sp
+	}
+
+	if checksums == nil {
+		readFile, err := inst.fs.Open(tempFilePath)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		return readFile, nil
+	}
+
+	if len(knowns) == 0 && pkgInfo.Checksum.GetEnabled() {
 		logE.Info("downloading a checksum file")
 		c, err := inst.dlAndExtractChecksum(ctx, logE, pkg, assetName)
 		if err != nil {
@@ -118,49 +194,56 @@ func (inst *Installer) verifyChecksum(ctx context.Context, logE *logrus.Entry, p
 				"asset_name": assetName,
 			})
 		}
-		chksum = &checksum.Checksum{
-			ID:        checksumID,
-			Checksum:  c,
-			Algorithm: pkgInfo.Checksum.GetAlgorithm(),
+		knowns = []*checksum.Checksum{
+			{
+				ID:        checksumID,
+				Checksum:  c,
+				Algorithm: algorithm,
+			},
 		}
-		checksums.Set(checksumID, chksum)
 	}
 
-	if chksum != nil {
-		chksum.Checksum = strings.ToUpper(chksum.Checksum)
-	}
-
-	algorithm := "sha512"
-	if chksum != nil {
-		algorithm = chksum.Algorithm
-	}
-	calculatedSum, err := inst.checksumCalculator.Calculate(inst.fs, tempFilePath, algorithm)
-	if err != nil {
-		return nil, fmt.Errorf("calculate a checksum of downloaded file: %w", logerr.WithFields(err, logrus.Fields{
-			"temp_file": tempFilePath,
-		}))
-	}
-	calculatedSum = strings.ToUpper(calculatedSum)
-
-	if chksum != nil && calculatedSum != chksum.Checksum {
-		return nil, logerr.WithFields(errInvalidChecksum, logrus.Fields{ //nolint:wrapcheck
-			"actual_checksum":   calculatedSum,
-			"expected_checksum": chksum.Checksum,
-		})
+	for _, chksum := range knowns {
+		expectedSum := strings.ToUpper(chksum.Checksum)
+		calculatedSum := strings.ToUpper(sums[chksum.Algorithm])
+		if calculatedSum != expectedSum {
+			return nil, logerr.WithFields(errInvalidChecksum, logrus.Fields{ //nolint:wrapcheck
+				"algorithm":         chksum.Algorithm,
+				"actual_checksum":   calculatedSum,
+				"expected_checksum": expectedSum,
+			})
+		}
 	}
 
-	if chksum == nil {
-		logE.WithFields(logrus.Fields{
-			"checksum_id": checksumID,
-			"checksum":    calculatedSum,
-		}).Debug("set a calculated checksum")
-		chksum = &checksum.Checksum{
-			ID:        checksumID,
-			Checksum:  calculatedSum,
-			Algorithm: pkgInfo.Checksum.GetAlgorithm(),
+	// knowns can be shared with other goroutines through checksums,
+	// so they aren't modified but copied and the copies are recorded with checksums.Set.
+	for _, algo := range algorithms {
+		var chksum *checksum.Checksum
+		if known := findChecksum(knowns, algo); known != nil {
+			c := *known
+			c.Checksum = strings.ToUpper(c.Checksum)
+			chksum = &c
+		} else {
+			if !param.SaveCalculatedChecksum {
+				continue
+			}
+			calculatedSum := strings.ToUpper(sums[algo])
+			logE.WithFields(logrus.Fields{
+				"checksum_id": checksumID,
+				"checksum":    calculatedSum,
+				"algorithm":   algo,
+			}).Debug("set a calculated checksum")
+			chksum = &checksum.Checksum{
+				ID:        checksumID,
+				Checksum:  calculatedSum,
+				Algorithm: algo,
+			}
+		}
+		if slsaProvenance != nil {
+			chksum.SetSLSAProvenance(slsaProvenance)
 		}
+		checksums.Set(checksumID, chksum)
 	}
-	checksums.Set(checksumID, chksum)
 
 	readFile, err := inst.fs.Open(tempFilePath)
 	if err != nil {
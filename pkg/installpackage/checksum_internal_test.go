@@ -2,10 +2,12 @@ package installpackage
 
 import (
 	"context"
+	"errors"
 	"io"
 	"strings"
 	"testing"
 
+	verifier "github.com/aquaproj/aqua/pkg/artifact-verifier"
 	"github.com/aquaproj/aqua/pkg/checksum"
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/aquaproj/aqua/pkg/config/aqua"
@@ -191,6 +193,203 @@ ed2ed654e1afb92e5292a43213e17ecb0fe0ec50c19fe69f0d185316a17d39fa  gh_2.17.0_linu
 				},
 			},
 		},
+		{
+			name: "invalid signature of checksum file",
+			param: &ParamVerifyChecksum{
+				AssetName: "gh_2.17.0_macOS_amd64.tar.gz",
+				Pkg: &config.Package{
+					PackageInfo: &registry.PackageInfo{
+						Type: "github_release",
+						Checksum: &registry.Checksum{
+							Type:       "github_release",
+							Algorithm:  "sha256",
+							FileFormat: "raw",
+							Signature: &registry.ChecksumSignature{
+								Type:      "gpg",
+								Asset:     strP("{{.Asset}}.sha256.sig"),
+								PublicKey: "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+							},
+						},
+					},
+				},
+				Checksums:  checksum.New(),
+				ChecksumID: "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz",
+				TempDir:    "/tmp/verify_checksum",
+				Body:       io.NopCloser(strings.NewReader("")),
+			},
+			inst: &Installer{
+				fs: afero.NewMemMapFs(),
+				checksumDownloader: &domain.MockChecksumDownloader{
+					Body: "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+				},
+				runtime: &runtime.Runtime{
+					GOOS:   "darwin",
+					GOARCH: "arm64",
+				},
+				checksumFileParser: &checksum.FileParser{},
+				checksumCalculator: &MockChecksumCalculator{
+					Checksum: "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+				},
+				verifier: verifier.New(nil, nil, &MockSignatureVerifier{
+					Err: errors.New("verify a GPG signature"),
+				}),
+			},
+			isErr: true,
+		},
+		{
+			name: "invalid signature",
+			param: &ParamVerifyChecksum{
+				AssetName: "gh_2.17.0_macOS_amd64.tar.gz",
+				Pkg: &config.Package{
+					PackageInfo: &registry.PackageInfo{
+						Type: "github_release",
+						Cosign: &registry.Cosign{
+							Signature: &registry.DownloadedFile{
+								Type:  "github_release",
+								Asset: strP("{{.Asset}}.sig"),
+							},
+							PublicKey: "-----BEGIN PUBLIC KEY-----\n-----END PUBLIC KEY-----\n",
+						},
+					},
+				},
+				ChecksumID: "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz",
+				TempDir:    "/tmp/verify_checksum",
+				Body:       io.NopCloser(strings.NewReader("")),
+			},
+			inst: &Installer{
+				fs: afero.NewMemMapFs(),
+				runtime: &runtime.Runtime{
+					GOOS:   "darwin",
+					GOARCH: "arm64",
+				},
+				verifier: verifier.New(&MockCosignVerifier{
+					Err: errors.New("the signature is invalid"),
+				}, nil, nil),
+			},
+			isErr: true,
+		},
+		{
+			name: "slsa provenance was already verified",
+			param: &ParamVerifyChecksum{
+				AssetName: "gh_2.17.0_macOS_amd64.tar.gz",
+				Pkg: &config.Package{
+					PackageInfo: &registry.PackageInfo{
+						Type: "github_release",
+						SLSAProvenance: &registry.SLSAProvenance{
+							Type:  "github_release",
+							Asset: strP("multiple.intoto.jsonl"),
+						},
+					},
+				},
+				KnownChecksums: []*checksum.Checksum{
+					{
+						ID:        "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz",
+						Checksum:  "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+						Algorithm: "sha256",
+						Metadata: &checksum.Metadata{
+							SLSAProvenance: &checksum.SLSAProvenance{
+								SourceURI: "github.com/cli/cli",
+								BuilderID: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.4.0",
+							},
+						},
+					},
+				},
+				Checksums:  checksum.New(),
+				ChecksumID: "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz",
+				TempDir:    "/tmp/verify_checksum",
+				Body:       io.NopCloser(strings.NewReader("")),
+			},
+			inst: &Installer{
+				fs: afero.NewMemMapFs(),
+				runtime: &runtime.Runtime{
+					GOOS:   "darwin",
+					GOARCH: "arm64",
+				},
+				checksumCalculator: &MockChecksumCalculator{
+					Checksum: "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+				},
+				verifier: verifier.New(nil, &MockSLSAVerifier{
+					Err: errors.New("provenance must not be verified"),
+				}, nil),
+			},
+		},
+		{
+			name: "checksum of the other algorithm is invalid",
+			param: &ParamVerifyChecksum{
+				AssetName: "gh_2.17.0_macOS_amd64.tar.gz",
+				Pkg: &config.Package{
+					PackageInfo: &registry.PackageInfo{
+						Type: "github_release",
+						Checksum: &registry.Checksum{
+							Type:      "github_release",
+							Algorithm: "sha512",
+						},
+					},
+				},
+				KnownChecksums: []*checksum.Checksum{
+					{
+						ID:        "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz",
+						Checksum:  "c6ce28981a1fb9acb13ee091b5f3de8eb244a67dc99aff1d106985c1e94c72c6",
+						Algorithm: "sha256",
+					},
+				},
+				Checksums:  checksum.New(),
+				ChecksumID: "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz",
+				TempDir:    "/tmp/verify_checksum",
+				Body:       io.NopCloser(strings.NewReader("")),
+			},
+			inst: &Installer{
+				fs: afero.NewMemMapFs(),
+				runtime: &runtime.Runtime{
+					GOOS:   "darwin",
+					GOARCH: "arm64",
+				},
+				checksumCalculator: &MockChecksumCalculator{
+					Checksum: "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "invalid slsa provenance",
+			param: &ParamVerifyChecksum{
+				AssetName: "gh_2.17.0_macOS_amd64.tar.gz",
+				Pkg: &config.Package{
+					PackageInfo: &registry.PackageInfo{
+						Type: "github_release",
+						SLSAProvenance: &registry.SLSAProvenance{
+							Type:  "github_release",
+							Asset: strP("multiple.intoto.jsonl"),
+						},
+					},
+				},
+				KnownChecksums: []*checksum.Checksum{
+					{
+						ID:        "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz",
+						Checksum:  "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+						Algorithm: "sha256",
+					},
+				},
+				Checksums:  checksum.New(),
+				ChecksumID: "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz",
+				TempDir:    "/tmp/verify_checksum",
+				Body:       io.NopCloser(strings.NewReader("")),
+			},
+			inst: &Installer{
+				fs: afero.NewMemMapFs(),
+				runtime: &runtime.Runtime{
+					GOOS:   "darwin",
+					GOARCH: "arm64",
+				},
+				checksumCalculator: &MockChecksumCalculator{
+					Checksum: "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+				},
+				verifier: verifier.New(nil, &MockSLSAVerifier{
+					Err: errors.New("the provenance doesn't include the digest of the file"),
+				}, nil),
+			},
+			isErr: true,
+		},
 	}
 	ctx := context.Background()
 	logE := logrus.NewEntry(logrus.New())
@@ -213,3 +412,52 @@ ed2ed654e1afb92e5292a43213e17ecb0fe0ec50c19fe69f0d185316a17d39fa  gh_2.17.0_linu
 		})
 	}
 }
+
+func TestInstaller_verifyChecksum_knownChecksumsAreNotModified(t *testing.T) {
+	t.Parallel()
+	checksumID := "github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_darwin_arm64.tar.gz"
+	known := &checksum.Checksum{
+		ID:        checksumID,
+		Checksum:  "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+		Algorithm: "sha256",
+	}
+	checksums := checksum.New()
+	checksums.Set(checksumID, known)
+	inst := &Installer{
+		fs: afero.NewMemMapFs(),
+		runtime: &runtime.Runtime{
+			GOOS:   "darwin",
+			GOARCH: "arm64",
+		},
+		checksumCalculator: &MockChecksumCalculator{
+			Checksum: "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963",
+		},
+	}
+	rc, err := inst.verifyChecksum(context.Background(), logrus.NewEntry(logrus.New()), &ParamVerifyChecksum{
+		AssetName: "gh_2.17.0_macOS_amd64.tar.gz",
+		Pkg: &config.Package{
+			PackageInfo: &registry.PackageInfo{
+				Type: "github_release",
+				Checksum: &registry.Checksum{
+					Type:      "github_release",
+					Algorithm: "sha256",
+				},
+			},
+		},
+		KnownChecksums: checksums.GetAll(checksumID),
+		Checksums:      checksums,
+		ChecksumID:     checksumID,
+		TempDir:        "/tmp/verify_checksum",
+		Body:           io.NopCloser(strings.NewReader("")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if known.Checksum != "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963" {
+		t.Fatalf("the known checksum must not be modified: %s", known.Checksum)
+	}
+	if chk := checksums.Get(checksumID); chk.Checksum != "3516A4D84F7B69EA5752CA2416895A2705910AF3ED6815502AF789000FC7E963" {
+		t.Fatalf("the checksum must be recorded: %s", chk.Checksum)
+	}
+}
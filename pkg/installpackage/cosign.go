@@ -0,0 +1,19 @@
+package installpackage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+)
+
+func (inst *Installer) verifyFileWithCosign(ctx context.Context, logE *logrus.Entry, pkg *config.Package, cos *registry.Cosign, p string) error {
+	file, err := inst.fs.Open(p)
+	if err != nil {
+		return fmt.Errorf("open a file to verify the signature: %w", err)
+	}
+	defer file.Close()
+	return inst.verifier.VerifyWithCosign(ctx, logE, inst.runtime, pkg, cos, file) //nolint:wrapcheck
+}
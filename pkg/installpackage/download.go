@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"strings"
 
 	"github.com/aquaproj/aqua/pkg/checksum"
 	"github.com/aquaproj/aqua/pkg/config"
@@ -14,38 +13,19 @@ import (
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
-func (inst *Installer) downloadWithRetry(ctx context.Context, logE *logrus.Entry, param *DownloadParam) error {
+// downloadIfNotInstalled downloads the package if it isn't installed yet.
+// The caller must hold the lock of the install directory.
+func (inst *Installer) downloadIfNotInstalled(ctx context.Context, logE *logrus.Entry, param *DownloadParam) error {
 	logE = logE.WithFields(logrus.Fields{
 		"package_name":    param.Package.Package.Name,
 		"package_version": param.Package.Package.Version,
 		"registry":        param.Package.Package.Registry,
 	})
-	retryCount := 0
-	for {
-		logE.Debug("check if the package is already installed")
-		finfo, err := inst.fs.Stat(param.Dest)
-		if err != nil { //nolint:nestif
-			// file doesn't exist
-			if err := inst.download(ctx, logE, param); err != nil {
-				if strings.Contains(err.Error(), "file already exists") {
-					if retryCount >= maxRetryDownload {
-						return err
-					}
-					retryCount++
-					logerr.WithError(logE, err).WithFields(logrus.Fields{
-						"retry_count": retryCount,
-					}).Info("retry installing the package")
-					continue
-				}
-				return err
-			}
-			return nil
-		}
-		if !finfo.IsDir() {
-			return fmt.Errorf("%s isn't a directory", param.Dest)
-		}
+	logE.Debug("check if the package is already installed")
+	if inst.isInstalled(param.Dest) {
 		return nil
 	}
+	return inst.downloadAtomically(ctx, logE, param)
 }
 
 func (inst *Installer) download(ctx context.Context, logE *logrus.Entry, param *DownloadParam) error { //nolint:funlen,cyclop
@@ -68,10 +48,16 @@ func (inst *Installer) download(ctx context.Context, logE *logrus.Entry, param *
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
-	var chksum *checksum.Checksum
+	var knownChecksums []*checksum.Checksum
 	if param.Checksums != nil {
-		chksum = param.Checksums.Get(checksumID)
-		if chksum == nil && !pkgInfo.Checksum.GetEnabled() && param.RequireChecksum {
+		knownChecksums = param.Checksums.GetAll(checksumID)
+		if len(knownChecksums) == 0 && param.RequireChecksumInAdvance {
+			return logerr.WithFields(errChecksumIsRequiredInAdvance, logrus.Fields{ //nolint:wrapcheck
+				"checksum_id": checksumID,
+				"doc":         "https://aquaproj.github.io/docs/reference/codes/003",
+			})
+		}
+		if len(knownChecksums) == 0 && !pkgInfo.Checksum.GetEnabled() && param.RequireChecksum {
 			return logerr.WithFields(errChecksumIsRequired, logrus.Fields{ //nolint:wrapcheck
 				"doc": "https://aquaproj.github.io/docs/reference/codes/001",
 			})
@@ -88,20 +74,21 @@ func (inst *Installer) download(ctx context.Context, logE *logrus.Entry, param *
 
 	var readBody io.Reader = body
 
-	if param.Checksums != nil {
+	if param.Checksums != nil || pkgInfo.Cosign.GetEnabled() || pkgInfo.SLSAProvenance.GetEnabled() {
 		tempDir, err := afero.TempDir(inst.fs, "", "")
 		if err != nil {
 			return fmt.Errorf("create a temporal directory: %w", err)
 		}
 		defer inst.fs.RemoveAll(tempDir) //nolint:errcheck
 		readFile, err := inst.verifyChecksum(ctx, logE, &ParamVerifyChecksum{
-			ChecksumID: checksumID,
-			Checksum:   chksum,
-			Checksums:  param.Checksums,
-			Pkg:        ppkg,
-			AssetName:  param.Asset,
-			Body:       body,
-			TempDir:    tempDir,
+			ChecksumID:             checksumID,
+			KnownChecksums:         knownChecksums,
+			Checksums:              param.Checksums,
+			SaveCalculatedChecksum: param.SaveCalculatedChecksum,
+			Pkg:                    ppkg,
+			AssetName:              param.Asset,
+			Body:                   body,
+			TempDir:                tempDir,
 		})
 		if err != nil {
 			return err
@@ -88,6 +88,38 @@ ed2ed654e1afb92e5292a43213e17ecb0fe0ec50c19fe69f0d185316a17d39fa  gh_2.17.0_linu
 				},
 			},
 		},
+		{
+			name: "checksum isn't recorded in advance",
+			param: &DownloadParam{
+				Package: &config.Package{
+					Package: &aqua.Package{
+						Name:    "cli/cli",
+						Version: "v2.17.0",
+					},
+					PackageInfo: &registry.PackageInfo{
+						Type:      "github_release",
+						RepoOwner: "cli",
+						RepoName:  "cli",
+						Asset:     strP("gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.{{.Format}}"),
+					},
+				},
+				Checksums:                checksum.New(),
+				RequireChecksumInAdvance: true,
+				Asset:                    "gh_2.17.0_macOS_amd64.tar.gz",
+			},
+			inst: &Installer{
+				runtime: &runtime.Runtime{
+					GOOS:   "darwin",
+					GOARCH: "arm64",
+				},
+				fs: afero.NewMemMapFs(),
+				packageDownloader: &domain.MockPackageDownloader{
+					Body: "hello",
+				},
+				unarchiver: &MockUnarchiver{},
+			},
+			isErr: true,
+		},
 	}
 	logE := logrus.NewEntry(logrus.New())
 	ctx := context.Background()
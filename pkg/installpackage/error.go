@@ -3,10 +3,14 @@ package installpackage
 import "errors"
 
 var (
-	errExePathIsDirectory    = errors.New("exe_path is directory")
-	errChmod                 = errors.New("add the permission to execute the command")
-	errInstallFailure        = errors.New("it failed to install some packages")
-	errGoInstallForbidLatest = errors.New(`the version "latest" is forbidden. Please specify Git tag or commit sha`)
-	errInvalidChecksum       = errors.New("checksum is invalid")
-	errChecksumIsRequired    = errors.New("checksum is required")
+	errExePathIsDirectory          = errors.New("exe_path is directory")
+	errChmod                       = errors.New("add the permission to execute the command")
+	errInstallFailure              = errors.New("it failed to install some packages")
+	errGoInstallForbidLatest       = errors.New(`the version "latest" is forbidden. Please specify Git tag or commit sha`)
+	errInvalidChecksum             = errors.New("checksum is invalid")
+	errChecksumIsRequired          = errors.New("checksum is required")
+	errListPackagesForPruneShims   = errors.New("stale shims aren't removed because it failed to list some packages")
+	errInvalidShimName             = errors.New("the command name must be a file name without path separators and shell metacharacters")
+	errChecksumIsRequiredInAdvance = errors.New("checksum must be recorded in aqua-checksums.json in advance. Please run `aqua update-checksum`")
+	errUnverifiableGoSource        = errors.New("it can't be checked whether the source code was extracted completely")
 )
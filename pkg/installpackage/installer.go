@@ -8,13 +8,18 @@ import (
 	"path/filepath"
 	"sync"
 
+	verifier "github.com/aquaproj/aqua/pkg/artifact-verifier"
 	"github.com/aquaproj/aqua/pkg/checksum"
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/aquaproj/aqua/pkg/config/aqua"
 	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/cosign"
 	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/manifest"
 	"github.com/aquaproj/aqua/pkg/policy"
 	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/aquaproj/aqua/pkg/signature"
+	"github.com/aquaproj/aqua/pkg/slsa"
 	"github.com/aquaproj/aqua/pkg/unarchive"
 	"github.com/aquaproj/aqua/pkg/util"
 	"github.com/sirupsen/logrus"
@@ -41,6 +46,8 @@ type Installer struct {
 	isTest             bool
 	copyDir            string
 	policyChecker      domain.PolicyChecker
+	verifier           *verifier.Verifier
+	locker             domain.Locker
 }
 
 type Unarchiver interface {
@@ -48,15 +55,62 @@ type Unarchiver interface {
 }
 
 type MockUnarchiver struct {
-	Err error
+	// Files are created in the destination directory.
+	Files []string
+	Err   error
 }
 
 func (unarchiver *MockUnarchiver) Unarchive(src *unarchive.File, dest string, logE *logrus.Entry, fs afero.Fs, prgOpts *unarchive.ProgressBarOpts) error {
-	return unarchiver.Err
+	if unarchiver.Err != nil {
+		return unarchiver.Err
+	}
+	for _, file := range unarchiver.Files {
+		if err := afero.WriteFile(fs, filepath.Join(dest, file), nil, filePermission); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+	return nil
+}
+
+type CosignVerifier interface {
+	Verify(ctx context.Context, logE *logrus.Entry, param *cosign.ParamVerify) error
+}
+
+type MockCosignVerifier struct {
+	Err error
+}
+
+func (verifier *MockCosignVerifier) Verify(ctx context.Context, logE *logrus.Entry, param *cosign.ParamVerify) error {
+	return verifier.Err
+}
+
+type SLSAVerifier interface {
+	Verify(ctx context.Context, logE *logrus.Entry, param *slsa.ParamVerify) (*slsa.Result, error)
+}
+
+type MockSLSAVerifier struct {
+	Result *slsa.Result
+	Err    error
+}
+
+func (verifier *MockSLSAVerifier) Verify(ctx context.Context, logE *logrus.Entry, param *slsa.ParamVerify) (*slsa.Result, error) {
+	return verifier.Result, verifier.Err
+}
+
+type SignatureVerifier interface {
+	Verify(ctx context.Context, logE *logrus.Entry, param *signature.ParamVerify) error
+}
+
+type MockSignatureVerifier struct {
+	Err error
+}
+
+func (verifier *MockSignatureVerifier) Verify(ctx context.Context, logE *logrus.Entry, param *signature.ParamVerify) error {
+	return verifier.Err
 }
 
 type ChecksumCalculator interface {
-	Calculate(fs afero.Fs, filename, algorithm string) (string, error)
+	Sum(file io.Reader, algorithms []string) (map[string]string, error)
 }
 
 type MockChecksumCalculator struct {
@@ -64,8 +118,15 @@ type MockChecksumCalculator struct {
 	Err      error
 }
 
-func (calc *MockChecksumCalculator) Calculate(fs afero.Fs, filename, algorithm string) (string, error) {
-	return calc.Checksum, calc.Err
+func (calc *MockChecksumCalculator) Sum(file io.Reader, algorithms []string) (map[string]string, error) {
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	sums := make(map[string]string, len(algorithms))
+	for _, algorithm := range algorithms {
+		sums[algorithm] = calc.Checksum
+	}
+	return sums, calc.Err
 }
 
 func isWindows(goos string) bool {
@@ -77,10 +138,16 @@ func (inst *Installer) SetCopyDir(copyDir string) {
 }
 
 func (inst *Installer) InstallPackages(ctx context.Context, logE *logrus.Entry, param *domain.ParamInstallPackages) error { //nolint:funlen,cyclop
-	pkgs, failed := config.ListPackages(logE, param.Config, inst.runtime, param.Registries)
+	pkgs, listFailed := config.ListPackages(logE, param.Config, inst.runtime, param.Registries)
+	failed := listFailed
 	if !param.SkipLink {
-		if failedCreateLinks := inst.createLinks(logE, pkgs); !failedCreateLinks {
-			failed = failedCreateLinks
+		if inst.createLinks(logE, pkgs) {
+			failed = true
+		}
+	}
+	if param.ProjectBin {
+		if inst.createProjectBin(logE, pkgs, param.ConfigFilePath, listFailed) {
+			failed = true
 		}
 	}
 
@@ -149,6 +216,7 @@ func (inst *Installer) InstallPackages(ctx context.Context, logE *logrus.Entry,
 				Pkg:             pkg,
 				Checksums:       checksums,
 				RequireChecksum: param.Config.RequireChecksum(),
+				ChecksumConfig:  param.Config.Checksum,
 				PolicyConfigs:   param.PolicyConfigs,
 			}); err != nil {
 				logerr.WithError(logE, err).Error("install the package")
@@ -164,9 +232,10 @@ func (inst *Installer) InstallPackages(ctx context.Context, logE *logrus.Entry,
 	return nil
 }
 
-func (inst *Installer) InstallPackage(ctx context.Context, logE *logrus.Entry, param *domain.ParamInstallPackage) error { //nolint:cyclop
+func (inst *Installer) InstallPackage(ctx context.Context, logE *logrus.Entry, param *domain.ParamInstallPackage) error { //nolint:cyclop,funlen
 	pkg := param.Pkg
 	checksums := param.Checksums
+	requireChecksum := param.RequireChecksum
 	pkgInfo := pkg.PackageInfo
 	logE = logE.WithFields(logrus.Fields{
 		"package_name":    pkg.Package.Name,
@@ -175,7 +244,14 @@ func (inst *Installer) InstallPackage(ctx context.Context, logE *logrus.Entry, p
 	})
 	logE.Debug("install the package")
 
-	if err := inst.policyChecker.ValidatePackage(&policy.ParamValidatePackage{
+	chkCfg := param.ChecksumConfig
+	if checksums != nil && chkCfg.Excluded(pkg.Package, inst.runtime.GOOS, inst.runtime.GOARCH) {
+		logE.Debug("skip verifying the checksum because the package is excluded")
+		checksums = nil
+		requireChecksum = false
+	}
+
+	if err := inst.policyChecker.ValidatePackage(logE, &policy.ParamValidatePackage{
 		Pkg:           param.Pkg,
 		PolicyConfigs: param.PolicyConfigs,
 	}); err != nil {
@@ -200,30 +276,79 @@ func (inst *Installer) InstallPackage(ctx context.Context, logE *logrus.Entry, p
 		return fmt.Errorf("get the package install path: %w", err)
 	}
 
-	if err := inst.downloadWithRetry(ctx, logE, &DownloadParam{
+	unlock, err := inst.lockPackage(ctx, logE, pkgPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	installed, err := inst.checkInstalled(logE, pkg, pkgPath)
+	if err != nil {
+		return err
+	}
+
+	if !installed && len(pkgInfo.PostInstall) != 0 {
+		if err := inst.policyChecker.ValidatePostInstall(logE, &policy.ParamValidatePackage{
+			Pkg:           param.Pkg,
+			PolicyConfigs: param.PolicyConfigs,
+		}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	if err := inst.downloadIfNotInstalled(ctx, logE, &DownloadParam{
 		Package:         pkg,
 		Dest:            pkgPath,
 		Asset:           assetName,
 		Checksums:       checksums,
-		RequireChecksum: param.RequireChecksum,
+		RequireChecksum: requireChecksum,
+		// If checksums aren't verified, the configuration isn't applied.
+		RequireChecksumInAdvance: checksums != nil && chkCfg.GetRequireChecksumInAdvance(),
+		SaveCalculatedChecksum:   chkCfg.GetSaveCalculatedChecksum(),
 	}); err != nil {
 		return err
 	}
 
-	for _, file := range pkgInfo.GetFiles() {
+	files := pkgInfo.GetFiles()
+	exePaths := make([]string, 0, len(files))
+	for _, file := range files {
 		file := file
 		logE := logE.WithField("file_name", file.Name)
-		if err := inst.checkAndCopyFile(ctx, pkg, file, logE); err != nil {
+		exePath, err := inst.checkAndCopyFile(ctx, pkg, file, logE)
+		if err != nil {
 			if inst.isTest {
 				return fmt.Errorf("check file_src is correct: %w", err)
 			}
 			logerr.WithError(logE, err).Warn("check file_src is correct")
 		}
+		if exePath != "" {
+			exePaths = append(exePaths, exePath)
+		}
+	}
+
+	// The manifest is recorded only when the package is installed,
+	// because files which were installed before may be already tampered.
+	if !installed {
+		if err := inst.writeManifest(pkgPath, exePaths); err != nil {
+			logerr.WithError(logE, err).Warn("record checksums of executables")
+		}
 	}
 
 	return nil
 }
 
+func (inst *Installer) writeManifest(pkgPath string, exePaths []string) error {
+	p, err := manifest.GetPath(inst.rootDir, pkgPath)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	m, err := manifest.Create(inst.fs, inst.rootDir, exePaths)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	return manifest.Write(inst.fs, p, m) //nolint:wrapcheck
+}
+
 func (inst *Installer) createLinks(logE *logrus.Entry, pkgs []*config.Package) bool {
 	failed := false
 	for _, pkg := range pkgs {
@@ -242,23 +367,27 @@ func (inst *Installer) createLinks(logE *logrus.Entry, pkgs []*config.Package) b
 				continue
 			}
 		}
+		if err := inst.createShareLinks(logE, pkg); err != nil {
+			logerr.WithError(logE, err).Error("create links of man pages, shell completions, and data")
+			failed = true
+		}
 	}
 	return failed
 }
 
-const maxRetryDownload = 1
-
 type DownloadParam struct {
-	Package         *config.Package
-	Checksums       *checksum.Checksums
-	Dest            string
-	Asset           string
-	RequireChecksum bool
+	Package                  *config.Package
+	Checksums                *checksum.Checksums
+	Dest                     string
+	Asset                    string
+	RequireChecksum          bool
+	RequireChecksumInAdvance bool
+	SaveCalculatedChecksum   bool
 }
 
 func (inst *Installer) checkFileSrcGo(ctx context.Context, pkg *config.Package, file *registry.File, logE *logrus.Entry) (string, error) {
 	pkgInfo := pkg.PackageInfo
-	exePath := filepath.Join(inst.rootDir, "pkgs", pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version, "bin", file.Name)
+	exePath := filepath.Join(inst.rootDir, "pkgs", pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version, "bin", file.Name)
 	if isWindows(inst.runtime.GOOS) {
 		exePath += ".exe"
 	}
@@ -266,7 +395,7 @@ func (inst *Installer) checkFileSrcGo(ctx context.Context, pkg *config.Package,
 	if err != nil {
 		return "", fmt.Errorf("render file dir: %w", err)
 	}
-	exeDir := filepath.Join(inst.rootDir, "pkgs", pkgInfo.GetType(), "github.com", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version, "src", dir)
+	exeDir := filepath.Join(inst.rootDir, "pkgs", pkgInfo.GetType(), pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version, "src", dir)
 	if _, err := inst.fs.Stat(exePath); err == nil {
 		return exePath, nil
 	}
@@ -285,23 +414,23 @@ func (inst *Installer) checkFileSrcGo(ctx context.Context, pkg *config.Package,
 	return exePath, nil
 }
 
-func (inst *Installer) checkAndCopyFile(ctx context.Context, pkg *config.Package, file *registry.File, logE *logrus.Entry) error {
+func (inst *Installer) checkAndCopyFile(ctx context.Context, pkg *config.Package, file *registry.File, logE *logrus.Entry) (string, error) {
 	exePath, err := inst.checkFileSrc(ctx, pkg, file, logE)
 	if err != nil {
 		if inst.isTest {
-			return fmt.Errorf("check file_src is correct: %w", err)
+			return "", fmt.Errorf("check file_src is correct: %w", err)
 		}
 		logerr.WithError(logE, err).Warn("check file_src is correct")
 	}
 	if inst.copyDir == "" {
-		return nil
+		return exePath, nil
 	}
 	logE.Info("copying an executable file")
 	if err := inst.Copy(filepath.Join(inst.copyDir, file.Name), exePath); err != nil {
-		return err
+		return exePath, err
 	}
 
-	return nil
+	return exePath, nil
 }
 
 func (inst *Installer) checkFileSrc(ctx context.Context, pkg *config.Package, file *registry.File, logE *logrus.Entry) (string, error) {
@@ -314,6 +443,11 @@ func (inst *Installer) checkFileSrc(ctx context.Context, pkg *config.Package, fi
 		return "", fmt.Errorf("get the package install path: %w", err)
 	}
 
+	return inst.checkExeFile(logE, pkg, pkgPath, file)
+}
+
+// checkExeFile checks if the file exists in the directory pkgPath and adds the permission to execute it.
+func (inst *Installer) checkExeFile(logE *logrus.Entry, pkg *config.Package, pkgPath string, file *registry.File) (string, error) {
 	fileSrc, err := pkg.RenameFile(logE, inst.fs, pkgPath, file, inst.runtime)
 	if err != nil {
 		return "", fmt.Errorf("get file_src: %w", err)
@@ -340,7 +474,8 @@ func (inst *Installer) checkFileSrc(ctx context.Context, pkg *config.Package, fi
 }
 
 const (
-	filePermission os.FileMode = 0o755
+	filePermission       os.FileMode = 0o755
+	markerFilePermission os.FileMode = 0o644
 )
 
 func (inst *Installer) Copy(dest, src string) error {
@@ -2,6 +2,7 @@ package installpackage_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/aquaproj/aqua/pkg/config/registry"
 	"github.com/aquaproj/aqua/pkg/domain"
 	"github.com/aquaproj/aqua/pkg/download"
+	"github.com/aquaproj/aqua/pkg/exec"
 	"github.com/aquaproj/aqua/pkg/installpackage"
 	"github.com/aquaproj/aqua/pkg/runtime"
 	"github.com/aquaproj/aqua/pkg/unarchive"
@@ -202,8 +204,8 @@ func Test_installer_InstallPackages(t *testing.T) { //nolint:funlen
 					t.Fatal(err)
 				}
 			}
-			downloader := download.NewPackageDownloader(nil, d.rt, download.NewHTTPDownloader(http.DefaultClient))
-			ctrl := installpackage.New(d.param, downloader, d.rt, fs, linker, d.executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{})
+			downloader := download.NewPackageDownloader(nil, nil, d.rt, download.NewHTTPDownloader(http.DefaultClient, nil))
+			ctrl := installpackage.New(d.param, downloader, d.rt, fs, linker, d.executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{}, nil, nil, nil, &domain.MockLocker{})
 			if err := ctrl.InstallPackages(ctx, logE, &domain.ParamInstallPackages{
 				Config:         d.cfg,
 				Registries:     d.registries,
@@ -259,6 +261,41 @@ func Test_installer_InstallPackage(t *testing.T) { //nolint:funlen
 				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/suzuki-shunsuke/ci-info/v2.0.3/ci-info_2.0.3_linux_amd64.tar.gz/ci-info": ``,
 			},
 		},
+		{
+			name: "post_install isn't run if the package is already installed",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "suzuki-shunsuke",
+					RepoName:  "ci-info",
+					Asset:     stringP("ci-info_{{trimV .Version}}_{{.OS}}_amd64.tar.gz"),
+					PostInstall: []*registry.PostInstall{
+						{
+							Command: "./ci-info",
+							Args:    []string{"init"},
+						},
+					},
+				},
+				Package: &aqua.Package{
+					Name:     "suzuki-shunsuke/ci-info",
+					Registry: "standard",
+					Version:  "v2.0.3",
+				},
+			},
+			param: &config.Param{
+				RootDir: "/home/foo/.local/share/aquaproj-aqua",
+			},
+			files: map[string]string{
+				"/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/suzuki-shunsuke/ci-info/v2.0.3/ci-info_2.0.3_linux_amd64.tar.gz/ci-info": ``,
+			},
+			executor: &exec.Mock{
+				Err: errors.New("post_install must not be run"),
+			},
+		},
 	}
 	logE := logrus.NewEntry(logrus.New())
 	ctx := context.Background()
@@ -272,8 +309,8 @@ func Test_installer_InstallPackage(t *testing.T) { //nolint:funlen
 					t.Fatal(err)
 				}
 			}
-			downloader := download.NewPackageDownloader(nil, d.rt, download.NewHTTPDownloader(http.DefaultClient))
-			ctrl := installpackage.New(d.param, downloader, d.rt, fs, nil, d.executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{})
+			downloader := download.NewPackageDownloader(nil, nil, d.rt, download.NewHTTPDownloader(http.DefaultClient, nil))
+			ctrl := installpackage.New(d.param, downloader, d.rt, fs, nil, d.executor, nil, &checksum.Calculator{}, unarchive.New(), &domain.MockPolicyChecker{}, nil, nil, nil, &domain.MockLocker{})
 			if err := ctrl.InstallPackage(ctx, logE, &domain.ParamInstallPackage{
 				Pkg: d.pkg,
 			}); err != nil {
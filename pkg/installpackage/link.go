@@ -1,6 +1,7 @@
 package installpackage
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -67,6 +68,81 @@ func (inst *Installer) recreateLink(linkPath, linkDest string, logE *logrus.Entr
 	return nil
 }
 
+// PruneLinks removes links of commands which aren't provided by any configuration file and returns removed commands.
+// Only links to aqua-proxy (proxy files on Windows) are removed.
+// If dryRun is true, links are reported but aren't removed.
+func (inst *Installer) PruneLinks(logE *logrus.Entry, commands map[string]struct{}, dryRun bool) ([]string, error) {
+	binDir := filepath.Join(inst.rootDir, "bin")
+	fileInfos, err := afero.ReadDir(inst.fs, binDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read the directory %s: %w", binDir, err)
+	}
+	orphans := []string{}
+	for _, fileInfo := range fileInfos {
+		cmd := fileInfo.Name()
+		if cmd == proxyName {
+			continue
+		}
+		if _, ok := commands[cmd]; ok {
+			continue
+		}
+		linkPath := filepath.Join(binDir, cmd)
+		managed, err := inst.isProxyLink(linkPath)
+		if err != nil {
+			return nil, err
+		}
+		if !managed {
+			continue
+		}
+		orphans = append(orphans, cmd)
+		logE := logE.WithField("command", cmd)
+		if dryRun {
+			logE.Info("[dry-run] remove an orphaned link")
+			continue
+		}
+		logE.Info("remove an orphaned link")
+		if err := inst.fs.Remove(linkPath); err != nil {
+			return nil, fmt.Errorf("remove a link (%s): %w", linkPath, err)
+		}
+		if isWindows(inst.runtime.GOOS) {
+			batPath := filepath.Join(inst.rootDir, "bat", cmd+".bat")
+			if err := inst.fs.Remove(batPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("remove a proxy file (%s): %w", batPath, err)
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// isProxyLink returns true if the file is a link to aqua-proxy created by aqua.
+func (inst *Installer) isProxyLink(p string) (bool, error) {
+	fileInfo, err := inst.linker.Lstat(p)
+	if err != nil {
+		return false, fmt.Errorf("get a file stat (%s): %w", p, err)
+	}
+	if isWindows(inst.runtime.GOOS) {
+		if !fileInfo.Mode().IsRegular() {
+			return false, nil
+		}
+		b, err := afero.ReadFile(inst.fs, p)
+		if err != nil {
+			return false, fmt.Errorf("read a file (%s): %w", p, err)
+		}
+		return string(b) == scrTemplate, nil
+	}
+	if fileInfo.Mode()&os.ModeSymlink == 0 {
+		return false, nil
+	}
+	dest, err := inst.linker.Readlink(p)
+	if err != nil {
+		return false, fmt.Errorf("read a symbolic link (%s): %w", p, err)
+	}
+	return dest == proxyName, nil
+}
+
 const (
 	batTemplate = `@echo off
 aqua exec -- <COMMAND> %*
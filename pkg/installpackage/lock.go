@@ -0,0 +1,27 @@
+package installpackage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// lockPackage acquires the lock of the install directory so that only one process installs the package at the same time.
+// Other processes wait until the package is installed and then find the package already installed.
+func (inst *Installer) lockPackage(ctx context.Context, logE *logrus.Entry, pkgPath string) (func(), error) {
+	if err := inst.fs.MkdirAll(filepath.Dir(pkgPath), dirPermission); err != nil {
+		return nil, fmt.Errorf("create the parent directory of the install directory: %w", err)
+	}
+	unlock, err := inst.locker.Lock(ctx, logE, pkgPath+".lock")
+	if err != nil {
+		return nil, fmt.Errorf("lock the install directory: %w", err)
+	}
+	return func() {
+		if err := unlock(); err != nil {
+			logerr.WithError(logE, err).Warn("release the lock of the install directory")
+		}
+	}, nil
+}
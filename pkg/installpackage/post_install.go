@@ -0,0 +1,48 @@
+package installpackage
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// runPostInstall runs post_install hooks of the package in the directory workDir where the package is extracted.
+// Templates are rendered with the install directory pkgPath, which doesn't exist until workDir is renamed to it.
+// Only a few environment variables are passed to hooks so that secrets such as GITHUB_TOKEN aren't passed to them.
+// This isn't a sandbox. Hooks can still access the file system and the network, so they must be restricted by policies.
+func (inst *Installer) runPostInstall(ctx context.Context, logE *logrus.Entry, pkg *config.Package, pkgPath, workDir string) error {
+	hooks, err := pkg.RenderPostInstall(pkgPath, workDir, inst.runtime)
+	if err != nil {
+		return fmt.Errorf("render post_install: %w", err)
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+	envs := getHookEnvs()
+	for _, hook := range hooks {
+		logE := logE.WithField("post_install_command", hook.Command)
+		logE.Info("run post_install")
+		if _, err := inst.executor.ExecHook(ctx, hook.Command, hook.Args, workDir, envs); err != nil {
+			return fmt.Errorf("run post_install: %w", logerr.WithFields(err, logrus.Fields{
+				"post_install_command": hook.Command,
+			}))
+		}
+	}
+	return nil
+}
+
+// getHookEnvs returns environment variables passed to post_install hooks.
+func getHookEnvs() []string {
+	names := []string{"PATH", "HOME", "TMPDIR", "TMP", "TEMP", "LANG", "SystemRoot"}
+	envs := make([]string, 0, len(names))
+	for _, name := range names {
+		if v, ok := os.LookupEnv(name); ok {
+			envs = append(envs, name+"="+v)
+		}
+	}
+	return envs
+}
@@ -0,0 +1,157 @@
+package installpackage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/util"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// shimMarker is written in shims so that aqua removes only shims created by aqua.
+const shimMarker = "Generated by aqua. Don't edit this file."
+
+const (
+	shimTemplate = `#!/bin/sh
+# ` + shimMarker + `
+AQUA_CONFIG=<CONFIG> exec aqua exec -- <COMMAND> "$@"
+`
+	shimTemplateWindows = `@echo off
+rem ` + shimMarker + `
+setlocal
+set AQUA_CONFIG=<CONFIG>
+aqua exec -- <COMMAND> %*
+`
+	dirPermission os.FileMode = 0o775
+)
+
+// createProjectBin creates shims of commands in the project-local bin directory.
+// Shims are bound to the configuration file, so commands are executed according to it regardless of the current directory.
+// Shims of commands which aren't provided by the configuration file are removed.
+// If it failed to list some packages, shims aren't removed because shims of the packages can't be distinguished from stale shims.
+func (inst *Installer) createProjectBin(logE *logrus.Entry, pkgs []*config.Package, cfgFilePath string, listFailed bool) bool {
+	binDir := config.GetProjectBinDir(cfgFilePath)
+	logE = logE.WithField("project_bin_dir", binDir)
+	if err := inst.fs.MkdirAll(binDir, dirPermission); err != nil {
+		logerr.WithError(logE, err).Error("create the project bin directory")
+		return true
+	}
+	shims := make(map[string]string, len(pkgs))
+	failed := false
+	for _, pkg := range pkgs {
+		for _, file := range pkg.PackageInfo.GetFiles() {
+			shimName, content, err := inst.renderShim(file.Name, cfgFilePath)
+			if err != nil {
+				logerr.WithError(logE, err).Error("create the shim")
+				failed = true
+				continue
+			}
+			shims[shimName] = content
+		}
+	}
+	for shimName, content := range shims {
+		if err := inst.writeShim(filepath.Join(binDir, shimName), content, logE); err != nil {
+			logerr.WithError(logE, err).Error("create the shim")
+			failed = true
+		}
+	}
+	if listFailed {
+		logerr.WithError(logE, errListPackagesForPruneShims).Warn("skip removing stale shims")
+		return failed
+	}
+	if err := inst.removeStaleShims(binDir, shims, logE); err != nil {
+		logerr.WithError(logE, err).Error("remove stale shims")
+		failed = true
+	}
+	return failed
+}
+
+func (inst *Installer) renderShim(cmd, cfgFilePath string) (string, string, error) {
+	if err := validateShimName(cmd); err != nil {
+		return "", "", err
+	}
+	if isWindows(inst.runtime.GOOS) {
+		return cmd + ".bat", strings.NewReplacer("<CONFIG>", escapeBatch(cfgFilePath), "<COMMAND>", escapeBatch(cmd)).Replace(shimTemplateWindows), nil
+	}
+	return cmd, strings.NewReplacer("<CONFIG>", util.QuoteShell(cfgFilePath), "<COMMAND>", util.QuoteShell(cmd)).Replace(shimTemplate), nil
+}
+
+// validateShimName validates the command name.
+// The shim must be created in the project bin directory and the name is embedded in the shim,
+// so the name must be a file name without path separators and shell metacharacters.
+func validateShimName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\ \t\r\n\"'`$&|;,=<>()^%!*?") {
+		return logerr.WithFields(errInvalidShimName, logrus.Fields{ //nolint:wrapcheck
+			"command": name,
+		})
+	}
+	return nil
+}
+
+// escapeBatch escapes characters which cmd.exe interprets in batch files.
+// % is doubled because it is expanded even in quoted strings, and the other characters are escaped with ^.
+func escapeBatch(s string) string {
+	return strings.NewReplacer(
+		"%", "%%",
+		"^", "^^",
+		"&", "^&",
+		"|", "^|",
+		"<", "^<",
+		">", "^>",
+	).Replace(s)
+}
+
+func (inst *Installer) writeShim(shimPath, content string, logE *logrus.Entry) error {
+	if fileInfo, err := inst.linker.Lstat(shimPath); err == nil {
+		if !fileInfo.Mode().IsRegular() && fileInfo.Mode()&os.ModeSymlink == 0 {
+			return fmt.Errorf("%s has already existed and isn't a regular file", shimPath)
+		}
+		if b, err := afero.ReadFile(inst.fs, shimPath); err == nil && string(b) == content {
+			return nil
+		}
+		if err := inst.fs.Remove(shimPath); err != nil {
+			return fmt.Errorf("remove a file to create a shim (%s): %w", shimPath, err)
+		}
+	}
+	logE.WithFields(logrus.Fields{
+		"command": filepath.Base(shimPath),
+	}).Info("create a shim")
+	if err := afero.WriteFile(inst.fs, shimPath, []byte(content), proxyPermission); err != nil {
+		return fmt.Errorf("create a shim (%s): %w", shimPath, err)
+	}
+	return nil
+}
+
+func (inst *Installer) removeStaleShims(binDir string, shims map[string]string, logE *logrus.Entry) error {
+	fileInfos, err := afero.ReadDir(inst.fs, binDir)
+	if err != nil {
+		return fmt.Errorf("read the directory %s: %w", binDir, err)
+	}
+	for _, fileInfo := range fileInfos {
+		if _, ok := shims[fileInfo.Name()]; ok {
+			continue
+		}
+		if !fileInfo.Mode().IsRegular() {
+			continue
+		}
+		p := filepath.Join(binDir, fileInfo.Name())
+		b, err := afero.ReadFile(inst.fs, p)
+		if err != nil {
+			return fmt.Errorf("read a file (%s): %w", p, err)
+		}
+		if !strings.Contains(string(b), shimMarker) {
+			continue
+		}
+		logE.WithField("command", fileInfo.Name()).Info("remove a stale shim")
+		if err := inst.fs.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove a shim (%s): %w", p, err)
+		}
+	}
+	return nil
+}
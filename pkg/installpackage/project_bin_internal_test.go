@@ -0,0 +1,95 @@
+package installpackage
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInstaller_renderShim(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name        string
+		goos        string
+		cmd         string
+		cfgFilePath string
+		expName     string
+		exp         string
+		isErr       bool
+	}{
+		{
+			name:        "normal",
+			goos:        "linux",
+			cmd:         "gh",
+			cfgFilePath: "/home/foo/workspace/aqua.yaml",
+			expName:     "gh",
+			exp: `#!/bin/sh
+# Generated by aqua. Don't edit this file.
+AQUA_CONFIG='/home/foo/workspace/aqua.yaml' exec aqua exec -- 'gh' "$@"
+`,
+		},
+		{
+			name:        "windows",
+			goos:        "windows",
+			cmd:         "gh",
+			cfgFilePath: `C:\Users\foo\100%^&\aqua.yaml`,
+			expName:     "gh.bat",
+			exp: `@echo off
+rem Generated by aqua. Don't edit this file.
+setlocal
+set AQUA_CONFIG=C:\Users\foo\100%%^^^&\aqua.yaml
+aqua exec -- gh %*
+`,
+		},
+		{
+			name:        "path traversal",
+			goos:        "linux",
+			cmd:         "../gh",
+			cfgFilePath: "/home/foo/workspace/aqua.yaml",
+			isErr:       true,
+		},
+		{
+			name:        "metacharacters",
+			goos:        "windows",
+			cmd:         "gh&calc",
+			cfgFilePath: `C:\Users\foo\aqua.yaml`,
+			isErr:       true,
+		},
+		{
+			name:        "percent",
+			goos:        "windows",
+			cmd:         "%PATH%",
+			cfgFilePath: `C:\Users\foo\aqua.yaml`,
+			isErr:       true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			inst := &Installer{
+				runtime: &runtime.Runtime{
+					GOOS:   d.goos,
+					GOARCH: "amd64",
+				},
+			}
+			shimName, content, err := inst.renderShim(d.cmd, d.cfgFilePath)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if shimName != d.expName {
+				t.Fatalf("wanted %s, got %s", d.expName, shimName)
+			}
+			if diff := cmp.Diff(d.exp, content); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
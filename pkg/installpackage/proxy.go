@@ -52,20 +52,24 @@ func (inst *Installer) InstallProxy(ctx context.Context, logE *logrus.Entry) err
 		return err //nolint:wrapcheck
 	}
 	logE.Debug("check if aqua-proxy is already installed")
-	finfo, err := inst.fs.Stat(pkgPath)
+	unlock, err := inst.lockPackage(ctx, logE, pkgPath)
 	if err != nil {
-		// file doesn't exist
-		if err := inst.downloadWithRetry(ctx, logE, &DownloadParam{
+		return err
+	}
+	defer unlock()
+
+	installed, err := inst.checkInstalled(logE, pkg, pkgPath)
+	if err != nil {
+		return err
+	}
+	if !installed {
+		if err := inst.downloadIfNotInstalled(ctx, logE, &DownloadParam{
 			Package: pkg,
 			Dest:    pkgPath,
 			Asset:   assetName,
 		}); err != nil {
 			return err
 		}
-	} else {
-		if !finfo.IsDir() {
-			return fmt.Errorf("%s isn't a directory", pkgPath)
-		}
 	}
 
 	// create a symbolic link
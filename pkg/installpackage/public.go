@@ -3,6 +3,7 @@ package installpackage
 import (
 	"context"
 
+	verifier "github.com/aquaproj/aqua/pkg/artifact-verifier"
 	"github.com/aquaproj/aqua/pkg/checksum"
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/aquaproj/aqua/pkg/domain"
@@ -13,9 +14,10 @@ import (
 type Executor interface {
 	GoBuild(ctx context.Context, exePath, src, exeDir string) (int, error)
 	GoInstall(ctx context.Context, path, gobin string) (int, error)
+	ExecHook(ctx context.Context, exePath string, args []string, dir string, envs []string) (int, error)
 }
 
-func New(param *config.Param, downloader domain.PackageDownloader, rt *runtime.Runtime, fs afero.Fs, linker domain.Linker, executor Executor, chkDL domain.ChecksumDownloader, chkCalc ChecksumCalculator, unarchiver Unarchiver, policyChecker domain.PolicyChecker) *Installer {
+func New(param *config.Param, downloader domain.PackageDownloader, rt *runtime.Runtime, fs afero.Fs, linker domain.Linker, executor Executor, chkDL domain.ChecksumDownloader, chkCalc ChecksumCalculator, unarchiver Unarchiver, policyChecker domain.PolicyChecker, cosignVerifier CosignVerifier, slsaVerifier SLSAVerifier, signatureVerifier SignatureVerifier, locker domain.Locker) *Installer {
 	return &Installer{
 		rootDir:            param.RootDir,
 		maxParallelism:     param.MaxParallelism,
@@ -33,5 +35,7 @@ func New(param *config.Param, downloader domain.PackageDownloader, rt *runtime.R
 		copyDir:            param.Dest,
 		unarchiver:         unarchiver,
 		policyChecker:      policyChecker,
+		verifier:           verifier.New(cosignVerifier, slsaVerifier, signatureVerifier),
+		locker:             locker,
 	}
 }
@@ -0,0 +1,90 @@
+package installpackage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// createShareLinks creates links of man pages, shell completions, and data of the package in $AQUA_ROOT_DIR/share.
+// If the package is upgraded, links are recreated because the destination is changed.
+// On Windows, links aren't created because creating symbolic links requires the privilege.
+func (inst *Installer) createShareLinks(logE *logrus.Entry, pkg *config.Package) error {
+	if isWindows(inst.runtime.GOOS) {
+		return nil
+	}
+	links, err := pkg.GetShareLinks(inst.rootDir, inst.runtime)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	for _, link := range links {
+		if err := inst.fs.MkdirAll(filepath.Dir(link.Path), dirPermission); err != nil {
+			return fmt.Errorf("create the directory: %w", err)
+		}
+		if err := inst.createLink(link.Path, link.Dest, logE); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PruneShareLinks removes links in $AQUA_ROOT_DIR/share which aren't provided by any configuration file and returns removed links.
+// links is a set of paths of links.
+// Only links to files in $AQUA_ROOT_DIR/pkgs are removed.
+// If dryRun is true, links are reported but aren't removed.
+func (inst *Installer) PruneShareLinks(logE *logrus.Entry, links map[string]struct{}, dryRun bool) ([]string, error) {
+	if isWindows(inst.runtime.GOOS) {
+		return nil, nil
+	}
+	shareDir := filepath.Join(inst.rootDir, "share")
+	pkgsDir := filepath.Join(inst.rootDir, "pkgs") + string(filepath.Separator)
+	orphans := []string{}
+	if err := afero.Walk(inst.fs, shareDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if _, ok := links[p]; ok {
+			return nil
+		}
+		fileInfo, err := inst.linker.Lstat(p)
+		if err != nil {
+			return fmt.Errorf("get a file stat (%s): %w", p, err)
+		}
+		if fileInfo.Mode()&os.ModeSymlink == 0 {
+			return nil
+		}
+		dest, err := inst.linker.Readlink(p)
+		if err != nil {
+			return fmt.Errorf("read a symbolic link (%s): %w", p, err)
+		}
+		if !strings.HasPrefix(dest, pkgsDir) {
+			return nil
+		}
+		orphans = append(orphans, p)
+		logE := logE.WithField("link_file", p)
+		if dryRun {
+			logE.Info("[dry-run] remove an orphaned link")
+			return nil
+		}
+		logE.Info("remove an orphaned link")
+		if err := inst.fs.Remove(p); err != nil {
+			return fmt.Errorf("remove a link (%s): %w", p, err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("walk the directory %s: %w", shareDir, err)
+	}
+	return orphans, nil
+}
@@ -0,0 +1,19 @@
+package installpackage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/pkg/checksum"
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+func (inst *Installer) verifySLSAProvenance(ctx context.Context, logE *logrus.Entry, pkg *config.Package, p string) (*checksum.SLSAProvenance, error) {
+	file, err := inst.fs.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("open a file to verify it with SLSA Provenance: %w", err)
+	}
+	defer file.Close()
+	return inst.verifier.VerifySLSAProvenance(ctx, logE, inst.runtime, pkg, file) //nolint:wrapcheck
+}
@@ -0,0 +1,122 @@
+package installpackage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// installedMarkerName is the name of the file which is created in the install directory when the package is installed completely.
+const installedMarkerName = ".aqua-installed"
+
+// isInstalled returns true if the marker file exists in the install directory.
+func (inst *Installer) isInstalled(pkgPath string) bool {
+	_, err := inst.fs.Stat(filepath.Join(pkgPath, installedMarkerName))
+	return err == nil
+}
+
+func (inst *Installer) writeInstalledMarker(pkgPath string) error {
+	if err := afero.WriteFile(inst.fs, filepath.Join(pkgPath, installedMarkerName), nil, markerFilePermission); err != nil {
+		return fmt.Errorf("create the marker file of the installation: %w", err)
+	}
+	return nil
+}
+
+// checkInstalled returns true if the package is already installed.
+// Install directories created by old aqua don't have the marker file.
+// If all files of the package exist in the directory, the directory is regarded as installed and the marker file is created.
+// Otherwise, the directory is regarded as half-extracted and is removed so that the package is installed again.
+// Directories of the package type "go" are always removed because it can't be checked whether the source code was extracted completely.
+func (inst *Installer) checkInstalled(logE *logrus.Entry, pkg *config.Package, pkgPath string) (bool, error) {
+	if inst.isInstalled(pkgPath) {
+		return true, nil
+	}
+	finfo, err := inst.fs.Stat(pkgPath)
+	if err != nil {
+		return false, nil
+	}
+	if !finfo.IsDir() {
+		return false, fmt.Errorf("%s isn't a directory", pkgPath)
+	}
+	if err := inst.validateLegacyFiles(logE, pkg, pkgPath); err != nil {
+		logerr.WithError(logE, err).Warn("remove the incomplete install directory")
+		if err := inst.fs.RemoveAll(pkgPath); err != nil {
+			return false, fmt.Errorf("remove the incomplete install directory: %w", err)
+		}
+		return false, nil
+	}
+	if err := inst.writeInstalledMarker(pkgPath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// validateLegacyFiles checks if the install directory created by old aqua is complete.
+func (inst *Installer) validateLegacyFiles(logE *logrus.Entry, pkg *config.Package, pkgPath string) error {
+	if pkg.PackageInfo.Type == config.PkgInfoTypeGo {
+		return errUnverifiableGoSource
+	}
+	return inst.validateFiles(logE, pkg, pkgPath)
+}
+
+// validateFiles checks if all files of the package exist in the directory and adds the permission to execute them.
+// Files of the package type "go" aren't checked because they are built after the source code is downloaded.
+func (inst *Installer) validateFiles(logE *logrus.Entry, pkg *config.Package, pkgPath string) error {
+	if pkg.PackageInfo.Type == config.PkgInfoTypeGo {
+		return nil
+	}
+	for _, file := range pkg.PackageInfo.GetFiles() {
+		logE := logE.WithField("file_name", file.Name)
+		if _, err := inst.checkExeFile(logE, pkg, pkgPath, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadAtomically downloads the package in a temporal directory next to the install directory,
+// validates files, runs post_install hooks, and renames the temporal directory to the install directory.
+// So the install directory never becomes half-extracted even if the installation is interrupted,
+// and hooks are run again next time if they fail or are interrupted.
+func (inst *Installer) downloadAtomically(ctx context.Context, logE *logrus.Entry, param *DownloadParam) error {
+	parentDir := filepath.Dir(param.Dest)
+	if err := inst.fs.MkdirAll(parentDir, dirPermission); err != nil {
+		return fmt.Errorf("create the parent directory of the install directory: %w", err)
+	}
+	stagingDir, err := afero.TempDir(inst.fs, parentDir, "."+filepath.Base(param.Dest)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("create a temporal directory: %w", err)
+	}
+	defer inst.fs.RemoveAll(stagingDir) //nolint:errcheck
+	if err := inst.fs.Chmod(stagingDir, dirPermission); err != nil {
+		return fmt.Errorf("change the permission of the temporal directory: %w", err)
+	}
+
+	stagingParam := *param
+	stagingParam.Dest = stagingDir
+	if err := inst.download(ctx, logE, &stagingParam); err != nil {
+		return err
+	}
+	if err := inst.validateFiles(logE, param.Package, stagingDir); err != nil {
+		return fmt.Errorf("validate files of the package: %w", err)
+	}
+	if err := inst.runPostInstall(ctx, logE, param.Package, param.Dest, stagingDir); err != nil {
+		return err
+	}
+	if err := inst.writeInstalledMarker(stagingDir); err != nil {
+		return err
+	}
+	if err := inst.fs.Rename(stagingDir, param.Dest); err != nil {
+		if inst.isInstalled(param.Dest) {
+			// the package was installed by other process concurrently
+			return nil
+		}
+		return fmt.Errorf("rename the temporal directory to the install directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package installpackage
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestInstaller_checkInstalled(t *testing.T) {
+	t.Parallel()
+	pkgPath := "/home/foo/.local/share/aquaproj-aqua/pkgs/github_archive/github.com/suzuki-shunsuke/foo/v1.0.0"
+	data := []struct {
+		name      string
+		files     []string
+		exp       bool
+		isRemoved bool
+	}{
+		{
+			name:  "installed",
+			files: []string{"foo/main.go", ".aqua-installed"},
+			exp:   true,
+		},
+		{
+			name: "not installed",
+		},
+		{
+			name:      "directory created by old aqua is removed",
+			files:     []string{"foo/main.go", "bin/foo"},
+			isRemoved: true,
+		},
+	}
+	pkg := &config.Package{
+		PackageInfo: &registry.PackageInfo{
+			Type:      config.PkgInfoTypeGo,
+			RepoOwner: "suzuki-shunsuke",
+			RepoName:  "foo",
+		},
+		Package: &aqua.Package{
+			Name:    "suzuki-shunsuke/foo",
+			Version: "v1.0.0",
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			for _, name := range d.files {
+				if err := afero.WriteFile(fs, pkgPath+"/"+name, nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			inst := &Installer{
+				fs: fs,
+			}
+			installed, err := inst.checkInstalled(logE, pkg, pkgPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if installed != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, installed)
+			}
+			if !d.isRemoved {
+				return
+			}
+			if f, err := afero.Exists(fs, pkgPath); err != nil {
+				t.Fatal(err)
+			} else if f {
+				t.Fatal("the directory must be removed")
+			}
+		})
+	}
+}
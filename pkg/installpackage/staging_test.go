@@ -0,0 +1,242 @@
+package installpackage_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/checksum"
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/exec"
+	"github.com/aquaproj/aqua/pkg/flock"
+	"github.com/aquaproj/aqua/pkg/installpackage"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func Test_installer_InstallPackage_staging(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name        string
+		files       map[string]string
+		extracted   []string
+		postInstall []*registry.PostInstall
+		executor    installpackage.Executor
+		exp         []string
+		notExists   []string
+		isErr       bool
+	}{
+		{
+			name:      "normal",
+			extracted: []string{"foo"},
+			exp:       []string{"foo", ".aqua-installed"},
+		},
+		{
+			name: "partial directory is repaired",
+			files: map[string]string{
+				"README.md": "",
+			},
+			extracted: []string{"foo"},
+			exp:       []string{"foo", ".aqua-installed"},
+			notExists: []string{"README.md"},
+		},
+		{
+			name: "directory installed by old aqua is kept",
+			files: map[string]string{
+				"foo":       "",
+				"README.md": "",
+			},
+			extracted: []string{"foo"},
+			exp:       []string{"foo", "README.md", ".aqua-installed"},
+		},
+		{
+			name:      "post_install is run before the package is installed",
+			extracted: []string{"foo"},
+			postInstall: []*registry.PostInstall{
+				{
+					Command: "./foo",
+					Args:    []string{"init", "{{.PkgPath}}"},
+				},
+			},
+			executor: &hookExecutor{},
+			exp:      []string{"foo", ".aqua-installed"},
+		},
+		{
+			name:      "install is rolled back if post_install fails",
+			extracted: []string{"foo"},
+			postInstall: []*registry.PostInstall{
+				{
+					Command: "./foo",
+					Args:    []string{"init"},
+				},
+			},
+			executor: &exec.Mock{
+				Err: errors.New("post_install failed"),
+			},
+			notExists: []string{"."},
+			isErr:     true,
+		},
+		{
+			name:      "file isn't found",
+			extracted: []string{"README.md"},
+			notExists: []string{"."},
+			isErr:     true,
+		},
+	}
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			rootDir := t.TempDir()
+			pkgPath := filepath.Join(rootDir, "pkgs", "github_release", "github.com", "suzuki-shunsuke", "foo", "v1.0.0", "foo.tar.gz")
+			fs := afero.NewOsFs()
+			for name, body := range d.files {
+				if err := fs.MkdirAll(pkgPath, 0o775); err != nil {
+					t.Fatal(err)
+				}
+				if err := afero.WriteFile(fs, filepath.Join(pkgPath, name), []byte(body), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ctrl := installpackage.New(&config.Param{
+				RootDir: rootDir,
+			}, &domain.MockPackageDownloader{
+				Body: "hello",
+			}, rt, fs, nil, d.executor, nil, &checksum.Calculator{}, &installpackage.MockUnarchiver{
+				Files: d.extracted,
+			}, &domain.MockPolicyChecker{}, nil, nil, nil, flock.New(fs))
+			err := ctrl.InstallPackage(ctx, logE, &domain.ParamInstallPackage{
+				Pkg: &config.Package{
+					PackageInfo: &registry.PackageInfo{
+						Type:        "github_release",
+						RepoOwner:   "suzuki-shunsuke",
+						RepoName:    "foo",
+						Asset:       stringP("foo.tar.gz"),
+						PostInstall: d.postInstall,
+					},
+					Package: &aqua.Package{
+						Name:     "suzuki-shunsuke/foo",
+						Registry: "standard",
+						Version:  "v1.0.0",
+					},
+				},
+			})
+			if err != nil {
+				if !d.isErr {
+					t.Fatal(err)
+				}
+			} else if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			for _, name := range d.exp {
+				if _, err := fs.Stat(filepath.Join(pkgPath, name)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if finfo, err := fs.Stat(filepath.Join(pkgPath, ".aqua-installed")); err == nil && finfo.Mode().Perm()&0o111 != 0 {
+				t.Fatalf("the marker file must not be executable: %s", finfo.Mode())
+			}
+			for _, name := range d.notExists {
+				if _, err := fs.Stat(filepath.Join(pkgPath, name)); !os.IsNotExist(err) {
+					t.Fatalf("%s must not exist", name)
+				}
+			}
+			entries, err := afero.ReadDir(fs, filepath.Dir(pkgPath))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, entry := range entries {
+				if strings.HasPrefix(entry.Name(), "."+filepath.Base(pkgPath)+".tmp-") {
+					t.Fatalf("the temporal directory %s must be removed", entry.Name())
+				}
+			}
+		})
+	}
+}
+
+// hookExecutor fails if post_install is run after the package is installed.
+type hookExecutor struct {
+	exec.Mock
+}
+
+func (exe *hookExecutor) ExecHook(ctx context.Context, exePath string, args []string, dir string, envs []string) (int, error) {
+	if !strings.Contains(filepath.Base(dir), ".tmp-") {
+		return 0, fmt.Errorf("post_install must be run in the temporal directory: %s", dir)
+	}
+	if exePath != filepath.Join(dir, "foo") {
+		return 0, fmt.Errorf("the command must be in the temporal directory: %s", exePath)
+	}
+	if pkgPath := args[len(args)-1]; strings.Contains(pkgPath, ".tmp-") || filepath.Dir(pkgPath) != filepath.Dir(dir) {
+		return 0, fmt.Errorf("PkgPath must be the install directory: %s", pkgPath)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".aqua-installed")); err == nil {
+		return 0, errors.New("post_install must be run before the marker file is created")
+	}
+	return 0, nil
+}
+
+func Test_installer_InstallPackage_concurrent(t *testing.T) {
+	t.Parallel()
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	logE := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
+	rootDir := t.TempDir()
+	pkg := &config.Package{
+		PackageInfo: &registry.PackageInfo{
+			Type:      "github_release",
+			RepoOwner: "suzuki-shunsuke",
+			RepoName:  "foo",
+			Asset:     stringP("foo.tar.gz"),
+		},
+		Package: &aqua.Package{
+			Name:     "suzuki-shunsuke/foo",
+			Registry: "standard",
+			Version:  "v1.0.0",
+		},
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// each installer imitates a separate process
+			fs := afero.NewOsFs()
+			ctrl := installpackage.New(&config.Param{
+				RootDir: rootDir,
+			}, &domain.MockPackageDownloader{
+				Body: "hello",
+			}, rt, fs, nil, nil, nil, &checksum.Calculator{}, &installpackage.MockUnarchiver{
+				Files: []string{"foo"},
+			}, &domain.MockPolicyChecker{}, nil, nil, nil, flock.New(fs))
+			errs <- ctrl.InstallPackage(ctx, logE, &domain.ParamInstallPackage{
+				Pkg: pkg,
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/checksum"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const (
+	algorithm     = "sha256"
+	dirPermission = 0o775
+)
+
+var (
+	errPackageIsOutsideRootDir = errors.New("the package isn't installed in the root directory")
+	errFileIsOutsideRootDir    = errors.New("the file in the manifest is outside the root directory")
+)
+
+// Manifest records checksums of executables of an installed package.
+// `aqua verify` compares executables with the manifest to detect the tampering and corruption.
+type Manifest struct {
+	Files []*File `json:"files"`
+}
+
+type File struct {
+	// Path is a relative path from the root directory.
+	Path      string `json:"path"`
+	Algorithm string `json:"algorithm"`
+	Checksum  string `json:"checksum"`
+}
+
+// GetPath returns the path of the manifest of the package.
+// Manifests are stored outside the package directory.
+// e.g. ${AQUA_ROOT_DIR}/manifests/github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_linux_amd64.tar.gz.json
+func GetPath(rootDir, pkgPath string) (string, error) {
+	rel, err := filepath.Rel(filepath.Join(rootDir, "pkgs"), pkgPath)
+	if err != nil {
+		return "", fmt.Errorf("get a relative path of the package: %w", err)
+	}
+	if rel == "." || strings.HasPrefix(rel, "..") {
+		return "", errPackageIsOutsideRootDir
+	}
+	return filepath.Join(rootDir, "manifests", rel+".json"), nil
+}
+
+// Create calculates checksums of executables and creates a manifest.
+func Create(fs afero.Fs, rootDir string, exePaths []string) (*Manifest, error) {
+	files := make([]*File, 0, len(exePaths))
+	for _, exePath := range exePaths {
+		rel, err := filepath.Rel(rootDir, exePath)
+		if err != nil {
+			return nil, fmt.Errorf("get a relative path of the executable: %w", err)
+		}
+		chk, err := calculate(fs, exePath)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &File{
+			Path:      filepath.ToSlash(rel),
+			Algorithm: algorithm,
+			Checksum:  chk,
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return &Manifest{
+		Files: files,
+	}, nil
+}
+
+func calculate(fs afero.Fs, p string) (string, error) {
+	f, err := fs.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("open a file: %w", err)
+	}
+	defer f.Close()
+	chk, err := checksum.CalculateReader(f, algorithm)
+	if err != nil {
+		return "", fmt.Errorf("calculate a checksum: %w", err)
+	}
+	return chk, nil
+}
+
+// Read reads a manifest.
+// If the manifest doesn't exist, nil is returned.
+func Read(fs afero.Fs, p string) (*Manifest, error) {
+	f, err := fs.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil //nolint:nilnil
+		}
+		return nil, fmt.Errorf("open a manifest: %w", err)
+	}
+	defer f.Close()
+	m := &Manifest{}
+	if err := json.NewDecoder(f).Decode(m); err != nil {
+		return nil, fmt.Errorf("parse a manifest as JSON: %w", err)
+	}
+	return m, nil
+}
+
+// Write writes a manifest.
+func Write(fs afero.Fs, p string, m *Manifest) error {
+	if err := fs.MkdirAll(filepath.Dir(p), dirPermission); err != nil {
+		return fmt.Errorf("create a directory: %w", err)
+	}
+	f, err := fs.Create(p)
+	if err != nil {
+		return fmt.Errorf("create a manifest: %w", err)
+	}
+	defer f.Close()
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(m); err != nil {
+		return fmt.Errorf("write a manifest as JSON: %w", err)
+	}
+	return nil
+}
+
+// Verify re-calculates checksums of executables and returns paths of corrupted files.
+// If the file doesn't exist, the file is regarded as corrupted.
+// Returned paths are removed by `aqua verify`, so the manifest is rejected if a path escapes the root directory.
+func (m *Manifest) Verify(fs afero.Fs, rootDir string) ([]string, error) {
+	var corrupted []string
+	for _, file := range m.Files {
+		p, err := getFilePath(rootDir, file.Path)
+		if err != nil {
+			return nil, err
+		}
+		f, err := fs.Open(p)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				corrupted = append(corrupted, p)
+				continue
+			}
+			return nil, fmt.Errorf("open a file: %w", err)
+		}
+		chk, err := checksum.CalculateReader(f, file.Algorithm)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("calculate a checksum: %w", err)
+		}
+		if !strings.EqualFold(chk, file.Checksum) {
+			corrupted = append(corrupted, p)
+		}
+	}
+	return corrupted, nil
+}
+
+// getFilePath returns the absolute path of the file in the manifest.
+func getFilePath(rootDir, p string) (string, error) {
+	if filepath.IsAbs(p) || filepath.VolumeName(p) != "" {
+		return "", logerr.WithFields(errFileIsOutsideRootDir, logrus.Fields{ //nolint:wrapcheck
+			"file_path": p,
+		})
+	}
+	rel := filepath.Clean(filepath.FromSlash(p))
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", logerr.WithFields(errFileIsOutsideRootDir, logrus.Fields{ //nolint:wrapcheck
+			"file_path": p,
+		})
+	}
+	return filepath.Join(rootDir, rel), nil
+}
@@ -0,0 +1,165 @@
+package manifest_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/manifest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+)
+
+func TestGetPath(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name    string
+		rootDir string
+		pkgPath string
+		exp     string
+		isErr   bool
+	}{
+		{
+			name:    "normal",
+			rootDir: "/home/foo/.local/share/aquaproj-aqua",
+			pkgPath: "/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_linux_amd64.tar.gz",
+			exp:     "/home/foo/.local/share/aquaproj-aqua/manifests/github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_linux_amd64.tar.gz.json",
+		},
+		{
+			name:    "outside the root directory",
+			rootDir: "/home/foo/.local/share/aquaproj-aqua",
+			pkgPath: "/tmp/gh",
+			isErr:   true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := manifest.GetPath(d.rootDir, d.pkgPath)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if p != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, p)
+			}
+		})
+	}
+}
+
+func TestManifest_Verify(t *testing.T) {
+	t.Parallel()
+	rootDir := "/home/foo/.local/share/aquaproj-aqua"
+	ghPath := rootDir + "/pkgs/github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_linux_amd64.tar.gz/bin/gh"
+	toolPath := rootDir + "/pkgs/github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_linux_amd64.tar.gz/bin/tool"
+	fs := afero.NewMemMapFs()
+	for _, p := range []string{ghPath, toolPath} {
+		if err := afero.WriteFile(fs, p, []byte("hello"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, err := manifest.Create(fs, rootDir, []string{ghPath, toolPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := rootDir + "/manifests/gh.json"
+	if err := manifest.Write(fs, manifestPath, m); err != nil {
+		t.Fatal(err)
+	}
+	m, err = manifest.Read(fs, manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(m.Files[0], &manifest.File{
+		Path:      "pkgs/github_release/github.com/cli/cli/v2.17.0/gh_2.17.0_linux_amd64.tar.gz/bin/gh",
+		Algorithm: "sha256",
+		Checksum:  "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+	}); diff != "" {
+		t.Fatal(diff)
+	}
+
+	corrupted, err := m.Verify(fs, rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrupted) != 0 {
+		t.Fatalf("files must not be corrupted: %v", corrupted)
+	}
+
+	if err := afero.WriteFile(fs, ghPath, []byte("tampered"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Remove(toolPath); err != nil {
+		t.Fatal(err)
+	}
+	corrupted, err = m.Verify(fs, rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(corrupted, []string{ghPath, toolPath}); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestManifest_Verify_outsideRootDir(t *testing.T) {
+	t.Parallel()
+	rootDir := "/home/foo/.local/share/aquaproj-aqua"
+	data := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "parent directory",
+			path: "../../.ssh/authorized_keys",
+		},
+		{
+			name: "parent directory in the middle",
+			path: "pkgs/../../.bashrc",
+		},
+		{
+			name: "absolute path",
+			path: "/etc/passwd",
+		},
+		{
+			name: "root directory",
+			path: ".",
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			m := &manifest.Manifest{
+				Files: []*manifest.File{
+					{
+						Path:      d.path,
+						Algorithm: "sha256",
+						Checksum:  "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+					},
+				},
+			}
+			corrupted, err := m.Verify(afero.NewMemMapFs(), rootDir)
+			if err == nil {
+				t.Fatal("error must be returned")
+			}
+			if len(corrupted) != 0 {
+				t.Fatalf("paths outside the root directory must not be returned: %v", corrupted)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	m, err := manifest.Read(afero.NewMemMapFs(), "/home/foo/manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != nil {
+		t.Fatal("manifest must be nil if the file doesn't exist")
+	}
+}
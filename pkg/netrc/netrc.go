@@ -0,0 +1,91 @@
+package netrc
+
+import (
+	"bufio"
+	"strings"
+)
+
+type Netrc struct {
+	machines []*Machine
+	def      *Machine
+}
+
+type Machine struct {
+	Name     string
+	Login    string
+	Password string
+}
+
+// Parse parses the content of .netrc.
+// https://www.gnu.org/software/inetutils/manual/html_node/The-_002enetrc-file.html
+// macdef is skipped because aqua doesn't use macros.
+func Parse(content string) *Netrc {
+	n := &Netrc{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	var machine *Machine
+	inMacro := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if inMacro {
+			// A macro definition ends with an empty line.
+			if strings.TrimSpace(line) == "" {
+				inMacro = false
+			}
+			continue
+		}
+		tokens := strings.Fields(line)
+		for i := 0; i < len(tokens); i++ {
+			token := tokens[i]
+			if strings.HasPrefix(token, "#") {
+				break
+			}
+			switch token {
+			case "machine":
+				if i+1 >= len(tokens) {
+					continue
+				}
+				i++
+				machine = &Machine{
+					Name: tokens[i],
+				}
+				n.machines = append(n.machines, machine)
+			case "default":
+				machine = &Machine{}
+				n.def = machine
+			case "login", "password", "account":
+				if i+1 >= len(tokens) {
+					continue
+				}
+				i++
+				if machine == nil {
+					continue
+				}
+				switch token {
+				case "login":
+					machine.Login = tokens[i]
+				case "password":
+					machine.Password = tokens[i]
+				}
+			case "macdef":
+				inMacro = true
+				i = len(tokens)
+			}
+		}
+	}
+	return n
+}
+
+// Find returns the entry of the given host.
+// If the host isn't found, the default entry is returned.
+// If the default entry doesn't exist either, nil is returned.
+func (n *Netrc) Find(host string) *Machine {
+	if n == nil {
+		return nil
+	}
+	for _, machine := range n.machines {
+		if machine.Name == host {
+			return machine
+		}
+	}
+	return n.def
+}
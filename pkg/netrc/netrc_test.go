@@ -0,0 +1,79 @@
+package netrc_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/netrc"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNetrc_Find(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name    string
+		content string
+		host    string
+		exp     *netrc.Machine
+	}{
+		{
+			name: "empty",
+			host: "example.com",
+		},
+		{
+			name: "normal",
+			content: `machine example.com
+  login foo
+  password bar
+machine example.org login yoo password zoo
+`,
+			host: "example.org",
+			exp: &netrc.Machine{
+				Name:     "example.org",
+				Login:    "yoo",
+				Password: "zoo",
+			},
+		},
+		{
+			name: "default",
+			content: `machine example.com login foo password bar
+default login anonymous password secret
+`,
+			host: "example.org",
+			exp: &netrc.Machine{
+				Login:    "anonymous",
+				Password: "secret",
+			},
+		},
+		{
+			name: "not found",
+			content: `machine example.com login foo password bar
+`,
+			host: "example.org",
+		},
+		{
+			name: "comment and macdef",
+			content: `# machine example.org login yoo password zoo
+macdef init
+machine example.org login yoo password zoo
+
+machine example.org login foo password bar # comment
+`,
+			host: "example.org",
+			exp: &netrc.Machine{
+				Name:     "example.org",
+				Login:    "foo",
+				Password: "bar",
+			},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			machine := netrc.Parse(d.content).Find(d.host)
+			if diff := cmp.Diff(d.exp, machine); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
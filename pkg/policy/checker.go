@@ -11,6 +11,11 @@ var errUnAllowedPackage = logerr.WithFields(errors.New("this package isn't allow
 	"doc": "https://aquaproj.github.io/docs/reference/codes/002",
 })
 
+var (
+	errDeniedPackage         = errors.New("this package is denied by the policy")
+	errPostInstallNotAllowed = errors.New("post_install of this package isn't allowed by the policy")
+)
+
 type Checker struct{}
 
 func NewChecker() *Checker {
@@ -9,6 +9,8 @@ import (
 
 const (
 	registryTypeStandard = "standard"
+	// ModeAudit is the mode of policy files whose violations are logged but not enforced.
+	ModeAudit = "audit"
 )
 
 var (
@@ -22,8 +24,12 @@ type Config struct {
 }
 
 type ConfigYAML struct {
+	// Mode is "audit" or empty. If Mode is "audit", violations are logged but not enforced.
+	Mode       string      `json:"mode,omitempty" jsonschema:"enum=audit"`
 	Registries []*Registry `json:"registries"`
 	Packages   []*Package  `json:"packages,omitempty"`
+	// Deny are packages which aren't allowed even if they match Packages.
+	Deny []*DenyPackage `json:"deny,omitempty"`
 }
 
 type Registry struct {
@@ -33,6 +39,9 @@ type Registry struct {
 	RepoName  string `yaml:"repo_name" json:"repo_name,omitempty"`
 	Ref       string `json:"ref,omitempty"`
 	Path      string `validate:"required" json:"path,omitempty"`
+	// PostInstall is whether post_install hooks of packages in the registry are allowed.
+	// By default, hooks are allowed only for the standard registry.
+	PostInstall *bool `yaml:"post_install" json:"post_install,omitempty"`
 }
 
 type Package struct {
@@ -40,12 +49,31 @@ type Package struct {
 	Version      string    `json:"version,omitempty"`
 	RegistryName string    `yaml:"registry" json:"registry,omitempty"`
 	Registry     *Registry `yaml:"-" json:"-"`
+	// If is an expression evaluated with the package metadata.
+	// e.g. PackageType == "github_release" && RepoOwner in ["aquaproj"] && ChecksumEnabled
+	If string `json:"if,omitempty"`
+}
+
+// DenyPackage is a package denied by the policy.
+// If the registry is empty, packages of any registries are denied.
+type DenyPackage struct {
+	Name         string    `json:"name,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	RegistryName string    `yaml:"registry" json:"registry,omitempty"`
+	Registry     *Registry `yaml:"-" json:"-"`
+	If           string    `json:"if,omitempty"`
+	// Reason is outputted when the package is denied. e.g. CVE ID
+	Reason string `json:"reason,omitempty"`
 }
 
 func (cfg *Config) Init() error {
 	m := make(map[string]*Registry, len(cfg.YAML.Registries))
 	for _, rgst := range cfg.YAML.Registries {
 		rgst := rgst
+		if rgst.PostInstall == nil {
+			postInstall := rgst.Type == registryTypeStandard
+			rgst.PostInstall = &postInstall
+		}
 		if rgst.Type == registryTypeStandard {
 			rgst.Type = "github_content"
 			rgst.RepoOwner = "aquaproj"
@@ -76,5 +104,16 @@ func (cfg *Config) Init() error {
 		}
 		pkg.Registry = rgst
 	}
+	for _, pkg := range cfg.YAML.Deny {
+		pkg := pkg
+		if pkg.RegistryName == "" {
+			continue
+		}
+		rgst, ok := m[pkg.RegistryName]
+		if !ok {
+			return errUnknownRegistry
+		}
+		pkg.Registry = rgst
+	}
 	return nil
 }
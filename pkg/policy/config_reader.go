@@ -3,6 +3,7 @@ package policy
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v2"
@@ -48,8 +49,10 @@ func (reader *ConfigReader) read(cfg *Config) error {
 	return nil
 }
 
+// ParseEnv parses AQUA_POLICY_CONFIG.
+// References to remote policy files such as https://example.com/aqua-policy.yaml are also accepted.
 func ParseEnv(env string) []string {
-	src := filepath.SplitList(env)
+	src := joinURLs(filepath.SplitList(env))
 	paths := make([]string, 0, len(src))
 	m := make(map[string]struct{}, len(src))
 	for _, s := range src {
@@ -64,3 +67,54 @@ func ParseEnv(env string) []string {
 	}
 	return paths
 }
+
+// joinURLs joins URLs split by the list separator ":".
+// e.g. ["https", "//example.com/aqua-policy.yaml"] => ["https://example.com/aqua-policy.yaml"]
+// The port is also joined.
+// e.g. ["https", "//example.com", "8443/aqua-policy.yaml"] => ["https://example.com:8443/aqua-policy.yaml"]
+func joinURLs(src []string) []string {
+	if filepath.ListSeparator != ':' {
+		return src
+	}
+	dest := make([]string, 0, len(src))
+	for i := 0; i < len(src); i++ {
+		s := src[i]
+		if i+1 < len(src) && isScheme(s) && strings.HasPrefix(src[i+1], "//") {
+			s += ":" + src[i+1]
+			i++
+			// If the host is followed by nothing, the next element is the port.
+			if i+1 < len(src) && !strings.ContainsAny(strings.TrimPrefix(src[i], "//"), "/?#") && isPort(src[i+1]) {
+				s += ":" + src[i+1]
+				i++
+			}
+		}
+		dest = append(dest, s)
+	}
+	return dest
+}
+
+// isPort returns true if s starts with a port number followed by a path, a query, or a fragment.
+// e.g. 8443/aqua-policy.yaml
+func isPort(s string) bool {
+	idx := strings.IndexAny(s, "/?#")
+	if idx == -1 {
+		idx = len(s)
+	}
+	if idx == 0 {
+		return false
+	}
+	for _, c := range s[:idx] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isScheme(s string) bool {
+	switch s {
+	case RemoteTypeGitHubContent, "http", "https":
+		return true
+	}
+	return false
+}
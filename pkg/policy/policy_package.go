@@ -2,9 +2,12 @@ package policy
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/aquaproj/aqua/pkg/expr"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
 type ParamValidatePackage struct {
@@ -12,20 +15,87 @@ type ParamValidatePackage struct {
 	PolicyConfigs []*Config
 }
 
-func (pc *Checker) ValidatePackage(param *ParamValidatePackage) error {
-	if len(param.PolicyConfigs) == 0 {
+// ValidatePackage returns an error if the package violates policies.
+// Violations of policies in the audit mode are logged but not enforced.
+func (pc *Checker) ValidatePackage(logE *logrus.Entry, param *ParamValidatePackage) error {
+	violations, err := pc.Check(param)
+	if err != nil {
+		return err
+	}
+	var enforced *Violation
+	for _, violation := range violations {
+		if violation.Audit {
+			logE.WithFields(violation.fields()).Warn("[audit] " + violation.Message)
+			continue
+		}
+		enforced = violation
+	}
+	if enforced == nil {
 		return nil
 	}
+	return logerr.WithFields(enforced.err, enforced.fields()) //nolint:wrapcheck
+}
+
+// Check evaluates policies and returns violations.
+// Policy files in the audit mode are evaluated separately from other policy files,
+// so they don't affect whether the package is allowed.
+func (pc *Checker) Check(param *ParamValidatePackage) ([]*Violation, error) {
+	if len(param.PolicyConfigs) == 0 {
+		return nil, nil
+	}
+	enforcedCfgs := make([]*Config, 0, len(param.PolicyConfigs))
+	auditedCfgs := make([]*Config, 0, len(param.PolicyConfigs))
 	for _, policyCfg := range param.PolicyConfigs {
+		if policyCfg.YAML != nil && policyCfg.YAML.Mode == ModeAudit {
+			auditedCfgs = append(auditedCfgs, policyCfg)
+			continue
+		}
+		enforcedCfgs = append(enforcedCfgs, policyCfg)
+	}
+	violations := []*Violation{}
+	for _, group := range []struct {
+		cfgs  []*Config
+		audit bool
+	}{
+		{cfgs: auditedCfgs, audit: true},
+		{cfgs: enforcedCfgs},
+	} {
+		if len(group.cfgs) == 0 {
+			continue
+		}
+		violation, err := pc.check(param.Pkg, group.cfgs)
+		if err != nil {
+			return nil, err
+		}
+		if violation != nil {
+			violation.Audit = group.audit
+			violations = append(violations, violation)
+		}
+	}
+	return violations, nil
+}
+
+func (pc *Checker) check(pkg *config.Package, policyCfgs []*Config) (*Violation, error) {
+	// deny rules take precedence over allow rules of all policy files.
+	for _, policyCfg := range policyCfgs {
+		denyPkg, err := pc.denyPackage(pkg, policyCfg)
+		if err != nil {
+			return nil, err
+		}
+		if denyPkg != nil {
+			return newViolation(pkg, errDeniedPackage, denyPkg.Reason, []*Config{policyCfg}), nil
+		}
+	}
+	for _, policyCfg := range policyCfgs {
 		policyCfg := policyCfg
 		if err := pc.validatePackage(&paramValidatePackage{
-			Pkg:          param.Pkg,
+			Pkg:          pkg,
 			PolicyConfig: policyCfg.YAML,
 		}); err == nil {
-			return nil
+			return nil, nil //nolint:nilnil
 		}
 	}
-	return errUnAllowedPackage
+	return newViolation(pkg, errUnAllowedPackage, "", policyCfgs), nil
 }
 
 type paramValidatePackage struct {
@@ -49,6 +119,27 @@ func (pc *Checker) validatePackage(param *paramValidatePackage) error {
 	return errUnAllowedPackage
 }
 
+func (pc *Checker) denyPackage(pkg *config.Package, policyCfg *Config) (*DenyPackage, error) {
+	if policyCfg.YAML == nil {
+		return nil, nil //nolint:nilnil
+	}
+	for _, denyPkg := range policyCfg.YAML.Deny {
+		f, err := pc.matchPkg(pkg, &Package{
+			Name:     denyPkg.Name,
+			Version:  denyPkg.Version,
+			Registry: denyPkg.Registry,
+			If:       denyPkg.If,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if f {
+			return denyPkg, nil
+		}
+	}
+	return nil, nil //nolint:nilnil
+}
+
 func (pc *Checker) matchPkg(pkg *config.Package, policyPkg *Package) (bool, error) {
 	if policyPkg.Name != "" && pkg.Package.Name != policyPkg.Name {
 		return false, nil
@@ -62,5 +153,52 @@ func (pc *Checker) matchPkg(pkg *config.Package, policyPkg *Package) (bool, erro
 			return false, nil
 		}
 	}
-	return pc.matchRegistry(pkg.Registry, policyPkg.Registry)
+	if policyPkg.Registry != nil {
+		matched, err := pc.matchRegistry(pkg.Registry, policyPkg.Registry)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	if policyPkg.If == "" {
+		return true, nil
+	}
+	matched, err := expr.EvaluatePolicyCondition(policyPkg.If, getPackageMetadata(pkg))
+	if err != nil {
+		return false, fmt.Errorf("evaluate the condition of package: %w", err)
+	}
+	return matched, nil
+}
+
+func getPackageMetadata(pkg *config.Package) *expr.PackageMetadata {
+	pkgInfo := pkg.PackageInfo
+	if pkgInfo == nil {
+		return &expr.PackageMetadata{}
+	}
+	supportedEnvs := []string(pkgInfo.SupportedEnvs)
+	if supportedEnvs == nil {
+		supportedEnvs = []string{"all"}
+	}
+	metadata := &expr.PackageMetadata{
+		PackageType:     pkgInfo.Type,
+		RepoOwner:       pkgInfo.RepoOwner,
+		RepoName:        pkgInfo.RepoName,
+		ChecksumEnabled: pkgInfo.Checksum.GetEnabled(),
+		SupportedEnvs:   supportedEnvs,
+	}
+	if pkgInfo.URL != nil {
+		metadata.URLHost = getURLHost(*pkgInfo.URL)
+	}
+	return metadata
+}
+
+// getURLHost returns the host of the URL template.
+// The URL isn't rendered because the host rarely depends on the template.
+func getURLHost(u string) string {
+	if idx := strings.Index(u, "://"); idx != -1 {
+		u = u[idx+3:]
+	}
+	if idx := strings.IndexAny(u, "/?#"); idx != -1 {
+		u = u[:idx]
+	}
+	return u
 }
@@ -5,9 +5,15 @@ import (
 
 	"github.com/aquaproj/aqua/pkg/config"
 	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/config/registry"
 	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/sirupsen/logrus"
 )
 
+func strP(s string) *string {
+	return &s
+}
+
 func TestChecker_ValidatePackage(t *testing.T) { //nolint:funlen
 	t.Parallel()
 	data := []struct {
@@ -63,13 +69,213 @@ func TestChecker_ValidatePackage(t *testing.T) { //nolint:funlen
 				},
 			},
 		},
+		{
+			name:  "denied",
+			isErr: true,
+			param: &policy.ParamValidatePackage{
+				Pkg: &config.Package{
+					Package: &aqua.Package{
+						Name:    "suzuki-shunsuke/tfcmt",
+						Version: "v4.0.0",
+					},
+					Registry: &aqua.Registry{
+						Type:      "github_content",
+						Name:      registryTypeStandard,
+						RepoOwner: "aquaproj",
+						RepoName:  "aqua",
+						Path:      "registry.yaml",
+						Ref:       "v1.90.0",
+					},
+				},
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Packages: []*policy.Package{
+								{
+									Name:         "suzuki-shunsuke/tfcmt",
+									Version:      `semver(">= 3.0.0")`,
+									RegistryName: "standard",
+									Registry: &policy.Registry{
+										Type:      "github_content",
+										Name:      registryTypeStandard,
+										RepoOwner: "aquaproj",
+										RepoName:  "aqua",
+										Path:      "registry.yaml",
+									},
+								},
+							},
+							Deny: []*policy.DenyPackage{
+								{
+									Name:    "suzuki-shunsuke/tfcmt",
+									Version: `semver("< 4.0.1")`,
+									Reason:  "CVE-2022-0000",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "deny doesn't match",
+			param: &policy.ParamValidatePackage{
+				Pkg: &config.Package{
+					Package: &aqua.Package{
+						Name:    "suzuki-shunsuke/tfcmt",
+						Version: "v4.0.0",
+					},
+					Registry: &aqua.Registry{
+						Type:      "github_content",
+						Name:      registryTypeStandard,
+						RepoOwner: "aquaproj",
+						RepoName:  "aqua",
+						Path:      "registry.yaml",
+						Ref:       "v1.90.0",
+					},
+				},
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Packages: []*policy.Package{
+								{
+									Name:         "suzuki-shunsuke/tfcmt",
+									Version:      `semver(">= 3.0.0")`,
+									RegistryName: "standard",
+									Registry: &policy.Registry{
+										Type:      "github_content",
+										Name:      registryTypeStandard,
+										RepoOwner: "aquaproj",
+										RepoName:  "aqua",
+										Path:      "registry.yaml",
+									},
+								},
+							},
+							Deny: []*policy.DenyPackage{
+								{
+									Name:    "suzuki-shunsuke/tfcmt",
+									Version: `semver("< 4.0.0")`,
+									Reason:  "CVE-2022-0000",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "condition matches",
+			param: &policy.ParamValidatePackage{
+				Pkg: &config.Package{
+					Package: &aqua.Package{
+						Name:    "example/foo",
+						Version: "v1.0.0",
+					},
+					PackageInfo: &registry.PackageInfo{
+						Type: "http",
+						URL:  strP("https://example.com/foo/{{.Version}}/foo_{{.OS}}_{{.Arch}}.tar.gz"),
+					},
+					Registry: &aqua.Registry{
+						Type:      "github_content",
+						Name:      registryTypeStandard,
+						RepoOwner: "aquaproj",
+						RepoName:  "aqua",
+						Path:      "registry.yaml",
+					},
+				},
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Packages: []*policy.Package{
+								{
+									RegistryName: "standard",
+									Registry: &policy.Registry{
+										Type:      "github_content",
+										Name:      registryTypeStandard,
+										RepoOwner: "aquaproj",
+										RepoName:  "aqua",
+										Path:      "registry.yaml",
+									},
+									If: `PackageType == "http" && URLHost == "example.com"`,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "condition doesn't match",
+			isErr: true,
+			param: &policy.ParamValidatePackage{
+				Pkg: &config.Package{
+					Package: &aqua.Package{
+						Name:    "example/foo",
+						Version: "v1.0.0",
+					},
+					PackageInfo: &registry.PackageInfo{
+						Type: "go_install",
+						URL:  strP("https://example.com/foo/{{.Version}}/foo_{{.OS}}_{{.Arch}}.tar.gz"),
+					},
+					Registry: &aqua.Registry{
+						Type:      "github_content",
+						Name:      registryTypeStandard,
+						RepoOwner: "aquaproj",
+						RepoName:  "aqua",
+						Path:      "registry.yaml",
+					},
+				},
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Packages: []*policy.Package{
+								{
+									RegistryName: "standard",
+									Registry: &policy.Registry{
+										Type:      "github_content",
+										Name:      registryTypeStandard,
+										RepoOwner: "aquaproj",
+										RepoName:  "aqua",
+										Path:      "registry.yaml",
+									},
+									If: `PackageType == "http" && URLHost == "example.com"`,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "audit",
+			param: &policy.ParamValidatePackage{
+				Pkg: &config.Package{
+					Package: &aqua.Package{
+						Name:    "suzuki-shunsuke/tfcmt",
+						Version: "v4.0.0",
+					},
+				},
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Mode: policy.ModeAudit,
+							Packages: []*policy.Package{
+								{
+									Name: "cli/cli",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	checker := &policy.Checker{}
+	logE := logrus.NewEntry(logrus.New())
 	for _, d := range data {
 		d := d
 		t.Run(d.name, func(t *testing.T) {
 			t.Parallel()
-			if err := checker.ValidatePackage(d.param); err != nil {
+			if err := checker.ValidatePackage(logE, d.param); err != nil {
 				if d.isErr {
 					return
 				}
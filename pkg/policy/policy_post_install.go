@@ -0,0 +1,85 @@
+package policy
+
+import (
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// ValidatePostInstall returns an error if post_install hooks of the package aren't allowed by policies.
+// Hooks are allowed if the registry of the package matches a registry whose post_install is true.
+// If no policy file is enforced, hooks are allowed only for the standard registry.
+// Violations of policies in the audit mode are logged but not enforced.
+func (pc *Checker) ValidatePostInstall(logE *logrus.Entry, param *ParamValidatePackage) error {
+	enforcedCfgs := make([]*Config, 0, len(param.PolicyConfigs))
+	for _, policyCfg := range param.PolicyConfigs {
+		if policyCfg.YAML != nil && policyCfg.YAML.Mode == ModeAudit {
+			allowed, err := pc.allowPostInstall(param.Pkg, []*Config{policyCfg})
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				violation := newViolation(param.Pkg, errPostInstallNotAllowed, "", []*Config{policyCfg})
+				logE.WithFields(violation.fields()).Warn("[audit] " + violation.Message)
+			}
+			continue
+		}
+		enforcedCfgs = append(enforcedCfgs, policyCfg)
+	}
+	if len(enforcedCfgs) == 0 {
+		enforcedCfgs = []*Config{getDefaultPostInstallConfig()}
+	}
+	allowed, err := pc.allowPostInstall(param.Pkg, enforcedCfgs)
+	if err != nil {
+		return err
+	}
+	if allowed {
+		return nil
+	}
+	violation := newViolation(param.Pkg, errPostInstallNotAllowed, "", enforcedCfgs)
+	return logerr.WithFields(violation.err, violation.fields()) //nolint:wrapcheck
+}
+
+func (pc *Checker) allowPostInstall(pkg *config.Package, policyCfgs []*Config) (bool, error) {
+	if pkg.Registry == nil {
+		return false, nil
+	}
+	for _, policyCfg := range policyCfgs {
+		if policyCfg.YAML == nil {
+			continue
+		}
+		for _, rgst := range policyCfg.YAML.Registries {
+			if rgst.PostInstall == nil || !*rgst.PostInstall {
+				continue
+			}
+			matched, err := pc.matchRegistry(pkg.Registry, rgst)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// getDefaultPostInstallConfig returns the policy which is applied when no policy file is enforced.
+// It allows hooks only for the standard registry.
+func getDefaultPostInstallConfig() *Config {
+	postInstall := true
+	return &Config{
+		YAML: &ConfigYAML{
+			Registries: []*Registry{
+				{
+					Name:        registryTypeStandard,
+					Type:        "github_content",
+					RepoOwner:   "aquaproj",
+					RepoName:    "aqua-registry",
+					Path:        "registry.yaml",
+					PostInstall: &postInstall,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,162 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/sirupsen/logrus"
+)
+
+func boolP(b bool) *bool {
+	return &b
+}
+
+func TestChecker_ValidatePostInstall(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	pkg := &config.Package{
+		Package: &aqua.Package{
+			Name:    "suzuki-shunsuke/tfcmt",
+			Version: "v4.0.0",
+		},
+		Registry: &aqua.Registry{
+			Type: "local",
+			Name: "local",
+			Path: "/home/foo/registry.yaml",
+		},
+	}
+	standardPkg := &config.Package{
+		Package: &aqua.Package{
+			Name:    "suzuki-shunsuke/tfcmt",
+			Version: "v4.0.0",
+		},
+		Registry: &aqua.Registry{
+			Type:      "github_content",
+			Name:      "standard",
+			RepoOwner: "aquaproj",
+			RepoName:  "aqua-registry",
+			Ref:       "v3.90.0",
+			Path:      "registry.yaml",
+		},
+	}
+	data := []struct {
+		name  string
+		isErr bool
+		param *policy.ParamValidatePackage
+	}{
+		{
+			name: "no policy",
+			param: &policy.ParamValidatePackage{
+				Pkg: standardPkg,
+			},
+		},
+		{
+			name:  "no policy and not standard registry",
+			isErr: true,
+			param: &policy.ParamValidatePackage{
+				Pkg: pkg,
+			},
+		},
+		{
+			name: "allowed",
+			param: &policy.ParamValidatePackage{
+				Pkg: pkg,
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Registries: []*policy.Registry{
+								{
+									Name:        "local",
+									Type:        "local",
+									Path:        "/home/foo/registry.yaml",
+									PostInstall: boolP(true),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "not allowed",
+			isErr: true,
+			param: &policy.ParamValidatePackage{
+				Pkg: pkg,
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Registries: []*policy.Registry{
+								{
+									Name:        "local",
+									Type:        "local",
+									Path:        "/home/foo/registry.yaml",
+									PostInstall: boolP(false),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "registry doesn't match",
+			isErr: true,
+			param: &policy.ParamValidatePackage{
+				Pkg: pkg,
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Registries: []*policy.Registry{
+								{
+									Name:        "local",
+									Type:        "local",
+									Path:        "/home/foo/other-registry.yaml",
+									PostInstall: boolP(true),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "audit",
+			param: &policy.ParamValidatePackage{
+				Pkg: standardPkg,
+				PolicyConfigs: []*policy.Config{
+					{
+						YAML: &policy.ConfigYAML{
+							Mode: policy.ModeAudit,
+							Registries: []*policy.Registry{
+								{
+									Name:        "local",
+									Type:        "local",
+									Path:        "/home/foo/registry.yaml",
+									PostInstall: boolP(false),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	checker := &policy.Checker{}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if err := checker.ValidatePostInstall(logE, d.param); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/util"
+)
+
+const (
+	RemoteTypeGitHubContent = "github_content"
+	RemoteTypeHTTP          = "http"
+)
+
+var (
+	errRemotePolicySHA256IsRequired = errors.New("sha256 is required to download a policy file")
+	errRemotePolicyRefIsRequired    = errors.New("ref is required to download a policy file from GitHub")
+	errInvalidRemotePolicy          = errors.New("the reference to the remote policy file is invalid")
+)
+
+// RemoteConfig is a policy file downloaded from a remote source.
+// The content is pinned with the SHA256 digest.
+// The policy file on GitHub Enterprise Server is specified by github_host.
+//
+// e.g.
+//
+//	github_content://aquaproj/aqua-policy/aqua-policy.yaml#ref=v1.0.0&sha256=<sha256>
+//	github_content://aquaproj/aqua-policy/aqua-policy.yaml#ref=v1.0.0&sha256=<sha256>&github_host=ghe.example.com
+//	https://example.com/aqua-policy.yaml#sha256=<sha256>
+type RemoteConfig struct {
+	Type       string
+	RepoOwner  string
+	RepoName   string
+	Path       string
+	Ref        string
+	GitHubHost string
+	URL        string
+	SHA256     string
+}
+
+// ParseRemoteConfig parses a reference to a remote policy file.
+// If the reference is a local file path, nil is returned.
+func ParseRemoteConfig(s string) (*RemoteConfig, error) {
+	idx := strings.Index(s, "://")
+	if idx == -1 {
+		return nil, nil //nolint:nilnil
+	}
+	scheme := s[:idx]
+	if scheme != RemoteTypeGitHubContent && scheme != "http" && scheme != "https" {
+		return nil, nil //nolint:nilnil
+	}
+	// The scheme github_content isn't valid as URL, so the reference is parsed without url.Parse.
+	ref, fragment, _ := strings.Cut(s, "#")
+	opts, err := url.ParseQuery(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("parse options of the remote policy file: %w", err)
+	}
+	rc := &RemoteConfig{
+		SHA256: strings.ToLower(opts.Get("sha256")),
+	}
+	if rc.SHA256 == "" {
+		return nil, errRemotePolicySHA256IsRequired
+	}
+	if scheme != RemoteTypeGitHubContent {
+		rc.Type = RemoteTypeHTTP
+		rc.URL = ref
+		return rc, nil
+	}
+	rc.Type = RemoteTypeGitHubContent
+	repoOwner, rest, _ := strings.Cut(ref[idx+3:], "/")
+	repoName, p, _ := strings.Cut(rest, "/")
+	if repoOwner == "" || repoName == "" || p == "" {
+		return nil, errInvalidRemotePolicy
+	}
+	rc.RepoOwner = repoOwner
+	rc.RepoName = repoName
+	rc.Path = p
+	rc.Ref = opts.Get("ref")
+	if rc.Ref == "" {
+		return nil, errRemotePolicyRefIsRequired
+	}
+	rc.GitHubHost = opts.Get("github_host")
+	if rc.GitHubHost != "" && !util.IsHost(rc.GitHubHost) {
+		return nil, errInvalidRemotePolicy
+	}
+	return rc, nil
+}
+
+// GetGitHubHost returns the host of GitHub.
+func (rc *RemoteConfig) GetGitHubHost() string {
+	if rc.GitHubHost == "" {
+		return "github.com"
+	}
+	return rc.GitHubHost
+}
+
+// GetFilePath returns the path where the policy file is cached.
+func (rc *RemoteConfig) GetFilePath(rootDir string) (string, error) {
+	if rc.Type == RemoteTypeGitHubContent {
+		return filepath.Join(rootDir, "policies", rc.Type, rc.GetGitHubHost(), rc.RepoOwner, rc.RepoName, rc.Ref, filepath.FromSlash(rc.Path)), nil
+	}
+	u, err := url.Parse(rc.URL)
+	if err != nil {
+		return "", fmt.Errorf("parse the URL of the policy file: %w", err)
+	}
+	return filepath.Join(rootDir, "policies", rc.Type, u.Host, filepath.FromSlash(path.Clean("/"+u.Path))), nil
+}
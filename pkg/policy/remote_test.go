@@ -0,0 +1,138 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/policy"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRemoteConfig(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name  string
+		s     string
+		exp   *policy.RemoteConfig
+		isErr bool
+	}{
+		{
+			name: "local",
+			s:    "/home/foo/aqua-policy.yaml",
+		},
+		{
+			name: "github_content",
+			s:    "github_content://aquaproj/aqua-policy/policies/aqua-policy.yaml#ref=v1.0.0&sha256=2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824",
+			exp: &policy.RemoteConfig{
+				Type:      "github_content",
+				RepoOwner: "aquaproj",
+				RepoName:  "aqua-policy",
+				Path:      "policies/aqua-policy.yaml",
+				Ref:       "v1.0.0",
+				SHA256:    "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			},
+		},
+		{
+			name: "github_content on GitHub Enterprise Server",
+			s:    "github_content://aquaproj/aqua-policy/aqua-policy.yaml#ref=v1.0.0&sha256=2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824&github_host=ghe.example.com",
+			exp: &policy.RemoteConfig{
+				Type:       "github_content",
+				RepoOwner:  "aquaproj",
+				RepoName:   "aqua-policy",
+				Path:       "aqua-policy.yaml",
+				Ref:        "v1.0.0",
+				GitHubHost: "ghe.example.com",
+				SHA256:     "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			},
+		},
+		{
+			name:  "invalid github_host",
+			s:     "github_content://aquaproj/aqua-policy/aqua-policy.yaml#ref=v1.0.0&sha256=2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824&github_host=../foo",
+			isErr: true,
+		},
+		{
+			name: "http",
+			s:    "https://example.com/aqua-policy.yaml?foo=bar#sha256=2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			exp: &policy.RemoteConfig{
+				Type:   "http",
+				URL:    "https://example.com/aqua-policy.yaml?foo=bar",
+				SHA256: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			},
+		},
+		{
+			name:  "sha256 is required",
+			s:     "https://example.com/aqua-policy.yaml",
+			isErr: true,
+		},
+		{
+			name:  "ref is required",
+			s:     "github_content://aquaproj/aqua-policy/aqua-policy.yaml#sha256=2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			isErr: true,
+		},
+		{
+			name:  "path is required",
+			s:     "github_content://aquaproj/aqua-policy#ref=v1.0.0&sha256=2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			rc, err := policy.ParseRemoteConfig(d.s)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, rc); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		env  string
+		exp  []string
+	}{
+		{
+			name: "empty",
+			exp:  []string{},
+		},
+		{
+			name: "remote",
+			env:  "/home/foo/aqua-policy.yaml:https://example.com/aqua-policy.yaml#sha256=xxx::/home/foo/aqua-policy.yaml:github_content://aquaproj/aqua-policy/aqua-policy.yaml#ref=v1.0.0&sha256=xxx",
+			exp: []string{
+				"/home/foo/aqua-policy.yaml",
+				"https://example.com/aqua-policy.yaml#sha256=xxx",
+				"github_content://aquaproj/aqua-policy/aqua-policy.yaml#ref=v1.0.0&sha256=xxx",
+			},
+		},
+		{
+			name: "port",
+			env:  "https://example.com:8443/aqua-policy.yaml#sha256=xxx:/home/foo/aqua-policy.yaml:http://localhost:8080#sha256=xxx:https://example.com:8443",
+			exp: []string{
+				"https://example.com:8443/aqua-policy.yaml#sha256=xxx",
+				"/home/foo/aqua-policy.yaml",
+				"http://localhost:8080#sha256=xxx",
+				"https://example.com:8443",
+			},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(d.exp, policy.ParseEnv(d.env)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
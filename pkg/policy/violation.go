@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// Violation is a package which violates policies.
+type Violation struct {
+	ConfigFilePath string   `json:"config_file_path,omitempty"`
+	PackageName    string   `json:"package_name"`
+	PackageVersion string   `json:"package_version"`
+	Registry       string   `json:"registry"`
+	Message        string   `json:"message"`
+	Reason         string   `json:"reason,omitempty"`
+	PolicyFiles    []string `json:"policy_files"`
+	// Audit is true if the violation isn't enforced because policy files are in the audit mode.
+	Audit bool `json:"audit"`
+	err   error
+}
+
+func newViolation(pkg *config.Package, err error, reason string, policyCfgs []*Config) *Violation {
+	policyFiles := make([]string, len(policyCfgs))
+	for i, policyCfg := range policyCfgs {
+		policyFiles[i] = policyCfg.Path
+	}
+	violation := &Violation{
+		Message:     err.Error(),
+		Reason:      reason,
+		PolicyFiles: policyFiles,
+		err:         err,
+	}
+	if pkg.Package != nil {
+		violation.PackageName = pkg.Package.Name
+		violation.PackageVersion = pkg.Package.Version
+		violation.Registry = pkg.Package.Registry
+	}
+	return violation
+}
+
+func (violation *Violation) fields() logrus.Fields {
+	fields := logrus.Fields{
+		"package_name":    violation.PackageName,
+		"package_version": violation.PackageVersion,
+		"registry":        violation.Registry,
+		"policy_files":    strings.Join(violation.PolicyFiles, ", "),
+	}
+	if violation.Reason != "" {
+		fields["reason"] = violation.Reason
+	}
+	return fields
+}
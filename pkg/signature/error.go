@@ -0,0 +1,14 @@
+package signature
+
+import "errors"
+
+var (
+	errPublicKeyIsRequired     = errors.New("public_key is required")
+	errUnknownSignatureType    = errors.New("the signature type is unknown")
+	errInvalidMinisignKey      = errors.New("the minisign public key is invalid")
+	errInvalidMinisignFile     = errors.New("the minisign signature is invalid")
+	errMinisignKeyIDMismatch   = errors.New("the file was signed with another minisign key")
+	errInvalidSignature        = errors.New("the signature is invalid")
+	errInvalidTrustedComment   = errors.New("the trusted comment of the minisign signature is invalid")
+	errUnsupportedMinisignAlgo = errors.New("the signature algorithm of minisign is unsupported")
+)
@@ -0,0 +1,28 @@
+package signature
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// VerifyGPG verifies a detached GPG signature with an armored public key.
+// Both binary (*.sig) and armored (*.asc) signatures are supported.
+func VerifyGPG(publicKey string, signed, sig []byte) error {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	if err != nil {
+		return fmt.Errorf("read a GPG public key: %w", err)
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(sig), []byte("-----BEGIN PGP SIGNATURE-----")) {
+		if _, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(signed), bytes.NewReader(sig), nil); err != nil {
+			return fmt.Errorf("verify a GPG signature: %w", err)
+		}
+		return nil
+	}
+	if _, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(signed), bytes.NewReader(sig), nil); err != nil {
+		return fmt.Errorf("verify a GPG signature: %w", err)
+	}
+	return nil
+}
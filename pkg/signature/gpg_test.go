@@ -0,0 +1,106 @@
+package signature_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/aquaproj/aqua/pkg/signature"
+)
+
+func newGPGEntity(t *testing.T) (*openpgp.Entity, string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("aqua", "", "aqua@example.com", &packet.Config{
+		RSABits: 2048, //nolint:gomnd
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return entity, buf.String()
+}
+
+func TestVerifyGPG(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	entity, publicKey := newGPGEntity(t)
+	_, otherPublicKey := newGPGEntity(t)
+	checksumFile := []byte("3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963  gh_2.17.0_macOS_amd64.tar.gz\n")
+
+	sig := &bytes.Buffer{}
+	if err := openpgp.DetachSign(sig, entity, bytes.NewReader(checksumFile), nil); err != nil {
+		t.Fatal(err)
+	}
+	armoredSig := &bytes.Buffer{}
+	if err := openpgp.ArmoredDetachSign(armoredSig, entity, bytes.NewReader(checksumFile), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []struct {
+		name      string
+		publicKey string
+		signed    []byte
+		sig       []byte
+		isErr     bool
+	}{
+		{
+			name:      "binary",
+			publicKey: publicKey,
+			signed:    checksumFile,
+			sig:       sig.Bytes(),
+		},
+		{
+			name:      "armored",
+			publicKey: publicKey,
+			signed:    checksumFile,
+			sig:       armoredSig.Bytes(),
+		},
+		{
+			name:      "tampered",
+			publicKey: publicKey,
+			signed:    []byte("c6ce28981a1fb9acb13ee091b5f3de8eb244a67dc99aff1d106985c1e94c72c6  gh_2.17.0_macOS_amd64.tar.gz\n"),
+			sig:       sig.Bytes(),
+			isErr:     true,
+		},
+		{
+			name:      "other key",
+			publicKey: otherPublicKey,
+			signed:    checksumFile,
+			sig:       armoredSig.Bytes(),
+			isErr:     true,
+		},
+		{
+			name:      "invalid public key",
+			publicKey: "foo",
+			signed:    checksumFile,
+			sig:       sig.Bytes(),
+			isErr:     true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if err := signature.VerifyGPG(d.publicKey, d.signed, d.sig); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
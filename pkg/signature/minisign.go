@@ -0,0 +1,91 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// https://jedisct1.github.io/minisign/#signature-format
+const (
+	minisignAlgoLegacy    = "Ed"
+	minisignAlgoPrehashed = "ED"
+	minisignKeyIDSize     = 8
+	untrustedCommentLabel = "untrusted comment:"
+	trustedCommentLabel   = "trusted comment: "
+)
+
+type minisignPublicKey struct {
+	keyID []byte
+	key   ed25519.PublicKey
+}
+
+// parseMinisignPublicKey parses a minisign public key.
+// Both the content of a public key file and the base64 encoded key are supported.
+func parseMinisignPublicKey(s string) (*minisignPublicKey, error) {
+	var encoded string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, untrustedCommentLabel) {
+			continue
+		}
+		encoded = line
+	}
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(b) != 2+minisignKeyIDSize+ed25519.PublicKeySize || string(b[:2]) != minisignAlgoLegacy {
+		return nil, errInvalidMinisignKey
+	}
+	return &minisignPublicKey{
+		keyID: b[2 : 2+minisignKeyIDSize],
+		key:   ed25519.PublicKey(b[2+minisignKeyIDSize:]),
+	}, nil
+}
+
+// VerifyMinisign verifies a minisign signature (*.minisig).
+func VerifyMinisign(publicKey string, signed, sig []byte) error { //nolint:cyclop
+	key, err := parseMinisignPublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSpace(string(sig)), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], trustedCommentLabel) { //nolint:gomnd
+		return errInvalidMinisignFile
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(b) != 2+minisignKeyIDSize+ed25519.SignatureSize {
+		return errInvalidMinisignFile
+	}
+	algo := string(b[:2])
+	keyID := b[2 : 2+minisignKeyIDSize]
+	signature := b[2+minisignKeyIDSize:]
+	if !bytes.Equal(keyID, key.keyID) {
+		return errMinisignKeyIDMismatch
+	}
+
+	message := signed
+	switch algo {
+	case minisignAlgoLegacy:
+	case minisignAlgoPrehashed:
+		h := blake2b.Sum512(signed)
+		message = h[:]
+	default:
+		return errUnsupportedMinisignAlgo
+	}
+	if !ed25519.Verify(key.key, message, signature) {
+		return errInvalidSignature
+	}
+
+	// The global signature covers the signature and the trusted comment.
+	trustedComment := strings.TrimSuffix(strings.TrimPrefix(lines[2], trustedCommentLabel), "\r")
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil {
+		return errInvalidMinisignFile
+	}
+	if !ed25519.Verify(key.key, append(append([]byte{}, signature...), trustedComment...), globalSig) {
+		return errInvalidTrustedComment
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package signature_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/signature"
+	"golang.org/x/crypto/blake2b"
+)
+
+type minisignKey struct {
+	keyID      []byte
+	privateKey ed25519.PrivateKey
+	publicKey  string
+}
+
+func newMinisignKey(t *testing.T, keyID string) *minisignKey {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := append(append([]byte("Ed"), keyID...), pub...)
+	return &minisignKey{
+		keyID:      []byte(keyID),
+		privateKey: priv,
+		publicKey:  fmt.Sprintf("untrusted comment: minisign public key\n%s\n", base64.StdEncoding.EncodeToString(b)),
+	}
+}
+
+// sign creates a signature like `minisign -S`.
+func (key *minisignKey) sign(message []byte, prehashed bool, trustedComment string) []byte {
+	algo := "Ed"
+	if prehashed {
+		algo = "ED"
+		h := blake2b.Sum512(message)
+		message = h[:]
+	}
+	sig := ed25519.Sign(key.privateKey, message)
+	globalSig := ed25519.Sign(key.privateKey, append(append([]byte{}, sig...), trustedComment...))
+	return []byte(fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte(algo), key.keyID...), sig...)),
+		trustedComment,
+		base64.StdEncoding.EncodeToString(globalSig)))
+}
+
+func TestVerifyMinisign(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	key := newMinisignKey(t, "12345678")
+	otherKey := newMinisignKey(t, "87654321")
+	checksumFile := []byte("3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963  gh_2.17.0_macOS_amd64.tar.gz\n")
+	sig := key.sign(checksumFile, true, "timestamp:1670000000\tfile:SHA256SUMS")
+
+	data := []struct {
+		name      string
+		publicKey string
+		signed    []byte
+		sig       []byte
+		isErr     bool
+	}{
+		{
+			name:      "prehashed",
+			publicKey: key.publicKey,
+			signed:    checksumFile,
+			sig:       sig,
+		},
+		{
+			name:      "legacy",
+			publicKey: key.publicKey,
+			signed:    checksumFile,
+			sig:       key.sign(checksumFile, false, "timestamp:1670000000"),
+		},
+		{
+			name:      "tampered",
+			publicKey: key.publicKey,
+			signed:    []byte("c6ce28981a1fb9acb13ee091b5f3de8eb244a67dc99aff1d106985c1e94c72c6  gh_2.17.0_macOS_amd64.tar.gz\n"),
+			sig:       sig,
+			isErr:     true,
+		},
+		{
+			name:      "other key",
+			publicKey: otherKey.publicKey,
+			signed:    checksumFile,
+			sig:       sig,
+			isErr:     true,
+		},
+		{
+			name:      "tampered trusted comment",
+			publicKey: key.publicKey,
+			signed:    checksumFile,
+			sig:       []byte(strings.Replace(string(sig), "trusted comment: timestamp:1670000000\tfile:SHA256SUMS", "trusted comment: foo", 1)),
+			isErr:     true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if err := signature.VerifyMinisign(d.publicKey, d.signed, d.sig); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
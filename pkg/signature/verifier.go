@@ -0,0 +1,73 @@
+package signature
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const (
+	TypeGPG      = "gpg"
+	TypeMinisign = "minisign"
+)
+
+type FileDownloader interface {
+	DownloadFile(ctx context.Context, logE *logrus.Entry, rt *runtime.Runtime, pkg *config.Package, file *registry.DownloadedFile) (io.ReadCloser, error)
+}
+
+// Verifier downloads a detached signature of a checksum file and verifies the checksum file with it.
+type Verifier struct {
+	downloader FileDownloader
+}
+
+func NewVerifier(downloader FileDownloader) *Verifier {
+	return &Verifier{
+		downloader: downloader,
+	}
+}
+
+type ParamVerify struct {
+	Runtime *runtime.Runtime
+	Package *config.Package
+	// ChecksumFile is the content of the checksum file.
+	ChecksumFile []byte
+}
+
+func (verifier *Verifier) Verify(ctx context.Context, logE *logrus.Entry, param *ParamVerify) error {
+	chksum := param.Package.PackageInfo.Checksum
+	sig := chksum.Signature
+	if sig.PublicKey == "" {
+		return errPublicKeyIsRequired
+	}
+	rc, err := verifier.downloader.DownloadFile(ctx, logE, param.Runtime, param.Package, &registry.DownloadedFile{
+		Type:  chksum.Type,
+		Asset: sig.Asset,
+		URL:   sig.URL,
+	})
+	if rc != nil {
+		defer rc.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("download a signature of a checksum file: %w", err)
+	}
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return fmt.Errorf("read a signature of a checksum file: %w", err)
+	}
+	switch sig.Type {
+	case TypeGPG:
+		return VerifyGPG(sig.PublicKey, param.ChecksumFile, b)
+	case TypeMinisign:
+		return VerifyMinisign(sig.PublicKey, param.ChecksumFile, b)
+	default:
+		return logerr.WithFields(errUnknownSignatureType, logrus.Fields{ //nolint:wrapcheck
+			"signature_type": sig.Type,
+		})
+	}
+}
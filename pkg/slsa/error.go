@@ -0,0 +1,15 @@
+package slsa
+
+import "errors"
+
+var (
+	errSubjectNotFound         = errors.New("the provenance doesn't include the digest of the file")
+	errInvalidPayloadType      = errors.New("the payload type of the provenance is invalid")
+	errSignatureIsRequired     = errors.New("the provenance isn't signed")
+	errInvalidSignature        = errors.New("the signature of the provenance is invalid")
+	errBuilderIDMismatch       = errors.New("the builder of the provenance isn't trusted")
+	errSourceURIMismatch       = errors.New("the source repository of the provenance doesn't match")
+	errInvalidOIDCIssuer       = errors.New("the provenance wasn't signed on GitHub Actions")
+	errCertificateMismatch     = errors.New("the certificate wasn't issued to the builder")
+	errTransparencyLogNotFound = errors.New("the provenance isn't recorded in the transparency log")
+)
@@ -0,0 +1,235 @@
+package slsa
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquaproj/aqua/pkg/cosign"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const (
+	payloadTypeInToto       = "application/vnd.in-toto+json"
+	defaultBuilderIDPrefix  = "https://github.com/slsa-framework/slsa-github-generator/"
+	githubActionsOIDCIssuer = "https://token.actions.githubusercontent.com"
+)
+
+// Expectation is what the provenance must satisfy.
+type Expectation struct {
+	// Digest is the hex encoded SHA256 digest of the file.
+	Digest string
+	// SourceURI is the repository where the file must be built. e.g. github.com/aquaproj/aqua
+	SourceURI string
+	// BuilderID is the trusted builder.
+	// If BuilderID is empty, builders of slsa-github-generator are trusted.
+	BuilderID string
+}
+
+// Result is the verified builder and source repository.
+type Result struct {
+	SourceURI string
+	BuilderID string
+}
+
+// envelope is a DSSE envelope.
+// https://github.com/secure-systems-lab/dsse/blob/master/envelope.md
+type envelope struct {
+	PayloadType string       `json:"payloadType"`
+	Payload     string       `json:"payload"`
+	Signatures  []*signature `json:"signatures"`
+}
+
+type signature struct {
+	Sig  string `json:"sig"`
+	Cert string `json:"cert"`
+}
+
+// statement is an in-toto statement whose predicate is SLSA Provenance v0.2.
+type statement struct {
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	Predicate struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		Invocation struct {
+			ConfigSource struct {
+				URI string `json:"uri"`
+			} `json:"configSource"`
+		} `json:"invocation"`
+	} `json:"predicate"`
+}
+
+func (stmt *statement) hasSubject(digest string) bool {
+	for _, subject := range stmt.Subject {
+		if strings.EqualFold(subject.Digest["sha256"], digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify verifies the provenance outputted by slsa-github-generator (*.intoto.jsonl).
+// Each line of the provenance is a DSSE envelope signed with a certificate issued by Fulcio.
+//
+// entries are transparency log entries related to the file.
+// Fulcio certificates are valid for only 10 minutes,
+// so the certificate is verified at the time when the envelope was recorded in the transparency log.
+// If the trusted root has no key of Rekor, entries are ignored and the certificate is verified at the current time.
+func Verify(tr *cosign.TrustedRoot, provenance []byte, entries []*cosign.RekorBundle, exp *Expectation) (*Result, error) {
+	for _, line := range bytes.Split(provenance, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		env := &envelope{}
+		if err := json.Unmarshal(line, env); err != nil {
+			return nil, fmt.Errorf("parse a provenance as DSSE envelope: %w", err)
+		}
+		if env.PayloadType != payloadTypeInToto {
+			return nil, logerr.WithFields(errInvalidPayloadType, logrus.Fields{ //nolint:wrapcheck
+				"payload_type": env.PayloadType,
+			})
+		}
+		payload, err := base64.StdEncoding.DecodeString(env.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("decode a payload of a provenance: %w", err)
+		}
+		stmt := &statement{}
+		if err := json.Unmarshal(payload, stmt); err != nil {
+			return nil, fmt.Errorf("parse a payload of a provenance: %w", err)
+		}
+		if !stmt.hasSubject(exp.Digest) {
+			continue
+		}
+		if err := verifyEnvelope(tr, env, payload, entries, stmt.Predicate.Builder.ID); err != nil {
+			return nil, err
+		}
+		return verifyStatement(stmt, exp)
+	}
+	return nil, logerr.WithFields(errSubjectNotFound, logrus.Fields{ //nolint:wrapcheck
+		"sha256": exp.Digest,
+	})
+}
+
+// pae returns the Pre-Authentication Encoding of DSSE.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// verifyEnvelope verifies that the envelope was signed by the builder on GitHub Actions.
+func verifyEnvelope(tr *cosign.TrustedRoot, env *envelope, payload []byte, entries []*cosign.RekorBundle, builderID string) error {
+	if len(env.Signatures) == 0 {
+		return errSignatureIsRequired
+	}
+	digest := sha256.Sum256(pae(env.PayloadType, payload))
+	var lastErr error
+	for _, s := range env.Signatures {
+		if err := verifySignature(tr, digest[:], payload, entries, s, builderID); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+func verifySignature(tr *cosign.TrustedRoot, digest, payload []byte, entries []*cosign.RekorBundle, s *signature, builderID string) error {
+	sig, err := base64.StdEncoding.DecodeString(s.Sig)
+	if err != nil {
+		return errInvalidSignature
+	}
+	cert, err := cosign.ParseCertificate([]byte(s.Cert))
+	if err != nil {
+		return fmt.Errorf("parse a certificate of a provenance: %w", err)
+	}
+	if err := cosign.VerifySignature(cert.PublicKey, digest, sig); err != nil {
+		return fmt.Errorf("verify a signature of a provenance: %w", err)
+	}
+	verifiedAt, err := getIntegratedTime(tr, entries, payload, cert)
+	if err != nil {
+		return err
+	}
+	if err := tr.VerifyCertificate(cert, verifiedAt); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if issuer := cosign.GetOIDCIssuer(cert); issuer != githubActionsOIDCIssuer {
+		return logerr.WithFields(errInvalidOIDCIssuer, logrus.Fields{ //nolint:wrapcheck
+			"certificate_oidc_issuer": issuer,
+		})
+	}
+	// The certificate is issued to the reusable workflow of the builder.
+	for _, u := range cert.URIs {
+		if u.String() == builderID {
+			return nil
+		}
+	}
+	return logerr.WithFields(errCertificateMismatch, logrus.Fields{ //nolint:wrapcheck
+		"builder_id": builderID,
+	})
+}
+
+// getIntegratedTime returns the time when the envelope was recorded in the transparency log.
+func getIntegratedTime(tr *cosign.TrustedRoot, entries []*cosign.RekorBundle, payload []byte, cert *x509.Certificate) (time.Time, error) {
+	if len(tr.RekorKeys) == 0 {
+		return time.Now(), nil
+	}
+	for _, entry := range entries {
+		t, err := tr.VerifyIntotoEntry(entry, payload, cert)
+		if err != nil {
+			continue
+		}
+		return t, nil
+	}
+	return time.Time{}, errTransparencyLogNotFound
+}
+
+func verifyStatement(stmt *statement, exp *Expectation) (*Result, error) {
+	builderID := stmt.Predicate.Builder.ID
+	if !matchBuilderID(builderID, exp.BuilderID) {
+		return nil, logerr.WithFields(errBuilderIDMismatch, logrus.Fields{ //nolint:wrapcheck
+			"builder_id":          builderID,
+			"expected_builder_id": exp.BuilderID,
+		})
+	}
+	sourceURI := normalizeSourceURI(stmt.Predicate.Invocation.ConfigSource.URI)
+	if sourceURI != normalizeSourceURI(exp.SourceURI) {
+		return nil, logerr.WithFields(errSourceURIMismatch, logrus.Fields{ //nolint:wrapcheck
+			"source_uri":          sourceURI,
+			"expected_source_uri": exp.SourceURI,
+		})
+	}
+	return &Result{
+		SourceURI: sourceURI,
+		BuilderID: builderID,
+	}, nil
+}
+
+// matchBuilderID returns true if the builder is trusted.
+// The ref of the builder can be omitted. e.g. <builder id>@refs/tags/v1.5.0
+func matchBuilderID(builderID, expected string) bool {
+	if expected == "" {
+		return strings.HasPrefix(builderID, defaultBuilderIDPrefix)
+	}
+	return builderID == expected || strings.HasPrefix(builderID, expected+"@")
+}
+
+// normalizeSourceURI converts a source URI to <host>/<owner>/<repo>.
+// e.g. git+https://github.com/aquaproj/aqua@refs/tags/v1.0.0 => github.com/aquaproj/aqua
+func normalizeSourceURI(uri string) string {
+	uri = strings.TrimPrefix(uri, "git+")
+	uri = strings.TrimPrefix(uri, "https://")
+	if idx := strings.Index(uri, "@"); idx != -1 {
+		uri = uri[:idx]
+	}
+	return strings.TrimSuffix(uri, ".git")
+}
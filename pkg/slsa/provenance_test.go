@@ -0,0 +1,462 @@
+package slsa_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/pkg/cosign"
+	"github.com/aquaproj/aqua/pkg/slsa"
+)
+
+const (
+	builderID = "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.4.0"
+	digest    = "3516a4d84f7b69ea5752ca2416895a2705910af3ed6815502af789000fc7e963"
+)
+
+type signer struct {
+	caCert    *x509.Certificate
+	key       *ecdsa.PrivateKey
+	cert      []byte
+	notBefore time.Time
+	rekorKey  *ecdsa.PrivateKey
+}
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newSigner(t *testing.T, san string) *signer {
+	t.Helper()
+	caKey := generateKey(t)
+	caTpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTpl, caTpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := generateKey(t)
+	u, err := url.Parse(san)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := asn1.Marshal("https://token.actions.githubusercontent.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The certificate expired like certificates issued by Fulcio.
+	notBefore := time.Now().Add(-time.Hour)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(10 * time.Minute), //nolint:gomnd
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{u},
+		ExtraExtensions: []pkix.Extension{
+			{
+				Id:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8},
+				Value: issuer,
+			},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &signer{
+		caCert:    caCert,
+		key:       key,
+		cert:      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		notBefore: notBefore,
+		rekorKey:  generateKey(t),
+	}
+}
+
+func (s *signer) trustedRoot() *cosign.TrustedRoot {
+	roots := x509.NewCertPool()
+	roots.AddCert(s.caCert)
+	return &cosign.TrustedRoot{
+		Roots:     roots,
+		RekorKeys: []crypto.PublicKey{&s.rekorKey.PublicKey},
+	}
+}
+
+// entry creates a transparency log entry of the provenance signed by the fake Rekor.
+// apiVersion is the version of the intoto type of Rekor.
+func (s *signer) entry(t *testing.T, provenance []byte, apiVersion string, integratedTime time.Time) *cosign.RekorBundle {
+	t.Helper()
+	env := struct {
+		Payload string `json:"payload"`
+	}{}
+	if err := json.Unmarshal(bytes.SplitN(provenance, []byte("\n"), 2)[0], &env); err != nil { //nolint:gomnd
+		t.Fatal(err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadHash := sha256.Sum256(payload)
+	publicKey := base64.StdEncoding.EncodeToString(s.cert)
+	content := map[string]interface{}{
+		"payloadHash": map[string]string{
+			"algorithm": "sha256",
+			"value":     hex.EncodeToString(payloadHash[:]),
+		},
+	}
+	spec := map[string]interface{}{
+		"content": content,
+	}
+	if apiVersion == "0.0.2" {
+		content["envelope"] = map[string]interface{}{
+			"payloadType": "application/vnd.in-toto+json",
+			"signatures": []map[string]string{
+				{"publicKey": publicKey},
+			},
+		}
+	} else {
+		spec["publicKey"] = publicKey
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       "intoto",
+		"spec":       spec,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&s.rekorKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(der)
+	rekorPayload := &cosign.RekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: integratedTime.Unix(),
+		LogID:          hex.EncodeToString(logID[:]),
+		LogIndex:       1,
+	}
+	b, err := json.Marshal(rekorPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(b)
+	set, err := ecdsa.SignASN1(rand.Reader, s.rekorKey, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &cosign.RekorBundle{
+		SignedEntryTimestamp: base64.StdEncoding.EncodeToString(set),
+		Payload:              rekorPayload,
+	}
+}
+
+// provenance creates a provenance like slsa-github-generator.
+func (s *signer) provenance(t *testing.T, builderID, sourceURI string) []byte {
+	t.Helper()
+	payload, err := json.Marshal(map[string]interface{}{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://slsa.dev/provenance/v0.2",
+		"subject": []map[string]interface{}{
+			{
+				"name": "foo_linux_amd64.tar.gz",
+				"digest": map[string]string{
+					"sha256": digest,
+				},
+			},
+		},
+		"predicate": map[string]interface{}{
+			"builder": map[string]string{
+				"id": builderID,
+			},
+			"invocation": map[string]interface{}{
+				"configSource": map[string]string{
+					"uri": sourceURI,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadType := "application/vnd.in-toto+json"
+	h := sha256.Sum256([]byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)))
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"payloadType": payloadType,
+		"payload":     base64.StdEncoding.EncodeToString(payload),
+		"signatures": []map[string]string{
+			{
+				"keyid": "",
+				"sig":   base64.StdEncoding.EncodeToString(sig),
+				"cert":  string(s.cert),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(b, '\n')
+}
+
+// tamper replaces the payload of the provenance.
+func tamper(t *testing.T, provenance, other []byte) []byte {
+	t.Helper()
+	env := map[string]interface{}{}
+	if err := json.Unmarshal(provenance, &env); err != nil {
+		t.Fatal(err)
+	}
+	otherEnv := map[string]interface{}{}
+	if err := json.Unmarshal(other, &otherEnv); err != nil {
+		t.Fatal(err)
+	}
+	env["payload"] = otherEnv["payload"]
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVerify(t *testing.T) { //nolint:funlen,maintidx
+	t.Parallel()
+	sgn := newSigner(t, builderID)
+	otherSigner := newSigner(t, "https://github.com/suzuki-shunsuke/evil/.github/workflows/release.yaml@refs/heads/main")
+	provenance := sgn.provenance(t, builderID, "git+https://github.com/suzuki-shunsuke/foo@refs/tags/v1.0.0")
+	// The payload is replaced but the signature isn't.
+	tampered := tamper(t, provenance, sgn.provenance(t, builderID, "git+https://github.com/suzuki-shunsuke/bar@refs/tags/v1.0.0"))
+	otherProvenance := otherSigner.provenance(t, builderID, "git+https://github.com/suzuki-shunsuke/foo@refs/tags/v1.0.0")
+	// The provenance was recorded in the transparency log while the certificate was valid.
+	recordedAt := sgn.notBefore.Add(time.Minute)
+	entries := []*cosign.RekorBundle{sgn.entry(t, provenance, "0.0.1", recordedAt)}
+	rekorFree := sgn.trustedRoot()
+	rekorFree.RekorKeys = nil
+
+	data := []struct {
+		name        string
+		trustedRoot *cosign.TrustedRoot
+		provenance  []byte
+		entries     []*cosign.RekorBundle
+		exp         *slsa.Expectation
+		result      *slsa.Result
+		isErr       bool
+	}{
+		{
+			name:        "normal",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     entries,
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			result: &slsa.Result{
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+				BuilderID: builderID,
+			},
+		},
+		{
+			name:        "intoto v0.0.2",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     []*cosign.RekorBundle{sgn.entry(t, provenance, "0.0.2", recordedAt)},
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			result: &slsa.Result{
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+				BuilderID: builderID,
+			},
+		},
+		{
+			name:        "builder id without ref",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     entries,
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+				BuilderID: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml",
+			},
+			result: &slsa.Result{
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+				BuilderID: builderID,
+			},
+		},
+		{
+			name:        "builder id mismatch",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     entries,
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+				BuilderID: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_go_slsa3.yml",
+			},
+			isErr: true,
+		},
+		{
+			name:        "source mismatch",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     entries,
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/bar",
+			},
+			isErr: true,
+		},
+		{
+			name:        "digest isn't found",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     entries,
+			exp: &slsa.Expectation{
+				Digest:    "c6ce28981a1fb9acb13ee091b5f3de8eb244a67dc99aff1d106985c1e94c72c6",
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			isErr: true,
+		},
+		{
+			name:        "untrusted certificate",
+			trustedRoot: otherSigner.trustedRoot(),
+			provenance:  provenance,
+			entries:     entries,
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			isErr: true,
+		},
+		{
+			name:        "certificate isn't issued to the builder",
+			trustedRoot: otherSigner.trustedRoot(),
+			provenance:  otherProvenance,
+			entries:     []*cosign.RekorBundle{otherSigner.entry(t, otherProvenance, "0.0.1", otherSigner.notBefore.Add(time.Minute))},
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			isErr: true,
+		},
+		{
+			name:        "tampered",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  tampered,
+			entries:     entries,
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/bar",
+			},
+			isErr: true,
+		},
+		{
+			name:        "transparency log entry isn't found",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			isErr: true,
+		},
+		{
+			name:        "provenance was recorded after the certificate expired",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     []*cosign.RekorBundle{sgn.entry(t, provenance, "0.0.1", time.Now())},
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			isErr: true,
+		},
+		{
+			name:        "transparency log entry signed by unknown rekor",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     []*cosign.RekorBundle{otherSigner.entry(t, provenance, "0.0.1", recordedAt)},
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			isErr: true,
+		},
+		{
+			name:        "transparency log entry records another provenance",
+			trustedRoot: sgn.trustedRoot(),
+			provenance:  provenance,
+			entries:     []*cosign.RekorBundle{sgn.entry(t, sgn.provenance(t, builderID, "git+https://github.com/suzuki-shunsuke/bar@refs/tags/v1.0.0"), "0.0.1", recordedAt)},
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			isErr: true,
+		},
+		{
+			name:        "expired certificate without rekor",
+			trustedRoot: rekorFree,
+			provenance:  provenance,
+			exp: &slsa.Expectation{
+				Digest:    digest,
+				SourceURI: "github.com/suzuki-shunsuke/foo",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := slsa.Verify(d.trustedRoot, d.provenance, d.entries, d.exp)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if d.result != nil && *result != *d.result {
+				t.Fatalf("wanted %+v, got %+v", d.result, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package slsa
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/registry"
+	"github.com/aquaproj/aqua/pkg/cosign"
+	"github.com/aquaproj/aqua/pkg/runtime"
+	"github.com/sirupsen/logrus"
+)
+
+type FileDownloader interface {
+	DownloadFile(ctx context.Context, logE *logrus.Entry, rt *runtime.Runtime, pkg *config.Package, file *registry.DownloadedFile) (io.ReadCloser, error)
+}
+
+// TransparencyLog searches transparency log entries related to a file.
+type TransparencyLog interface {
+	SearchEntries(ctx context.Context, digest string) ([]*cosign.RekorBundle, error)
+}
+
+// Verifier downloads SLSA Provenance and verifies files with it.
+type Verifier struct {
+	downloader  FileDownloader
+	tlog        TransparencyLog
+	trustedRoot *cosign.TrustedRoot
+	initErr     error
+}
+
+func NewVerifier(downloader FileDownloader, tlog TransparencyLog) *Verifier {
+	trustedRoot, err := cosign.DefaultTrustedRoot()
+	return &Verifier{
+		downloader:  downloader,
+		tlog:        tlog,
+		trustedRoot: trustedRoot,
+		initErr:     err,
+	}
+}
+
+type ParamVerify struct {
+	Runtime        *runtime.Runtime
+	Package        *config.Package
+	SLSAProvenance *registry.SLSAProvenance
+	Artifact       io.Reader
+}
+
+func (verifier *Verifier) Verify(ctx context.Context, logE *logrus.Entry, param *ParamVerify) (*Result, error) {
+	if verifier.initErr != nil {
+		return nil, verifier.initErr
+	}
+	sp := param.SLSAProvenance
+	rc, err := verifier.downloader.DownloadFile(ctx, logE, param.Runtime, param.Package, sp.ToDownloadedFile())
+	if rc != nil {
+		defer rc.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("download a provenance: %w", err)
+	}
+	provenance, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read a provenance: %w", err)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, param.Artifact); err != nil {
+		return nil, fmt.Errorf("calculate a digest of the file: %w", err)
+	}
+	digest := hex.EncodeToString(h.Sum(nil))
+	entries, err := verifier.tlog.SearchEntries(ctx, digest)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return Verify(verifier.trustedRoot, provenance, entries, &Expectation{
+		Digest:    digest,
+		SourceURI: getSourceURI(param.Package, sp),
+		BuilderID: sp.BuilderID,
+	})
+}
+
+func getSourceURI(pkg *config.Package, sp *registry.SLSAProvenance) string {
+	if sp.SourceURI != nil {
+		return *sp.SourceURI
+	}
+	pkgInfo := pkg.PackageInfo
+	return path.Join(pkgInfo.GetGitHubHost(), pkgInfo.RepoOwner, pkgInfo.RepoName)
+}
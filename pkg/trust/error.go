@@ -0,0 +1,5 @@
+package trust
+
+import "errors"
+
+var errUntrustedConfig = errors.New("the configuration file isn't trusted. Please review it and run `aqua policy allow`")
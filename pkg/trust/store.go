@@ -0,0 +1,192 @@
+package trust
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	"github.com/aquaproj/aqua/pkg/config/aqua"
+	"github.com/aquaproj/aqua/pkg/domain"
+	"github.com/aquaproj/aqua/pkg/util"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ModeStrict refuses configuration files which aren't trusted.
+	// This is the default mode, so configuration files have to be trusted by `aqua policy allow` unless AQUA_TRUST_MODE is "permissive".
+	ModeStrict = "strict"
+	// ModePermissive trusts all configuration files.
+	ModePermissive = "permissive"
+)
+
+const (
+	dirPermission  os.FileMode = 0o775
+	filePermission os.FileMode = 0o644
+)
+
+// Store manages configuration files approved by `aqua policy allow`.
+// A configuration file is approved with the hash of its content, imported files, and local registries,
+// so the approval is revoked when any of them is changed.
+type Store struct {
+	fs           afero.Fs
+	rootDir      string
+	pwd          string
+	mode         string
+	configReader domain.ConfigReader
+}
+
+func NewStore(fs afero.Fs, param *config.Param, configReader domain.ConfigReader) *Store {
+	return &Store{
+		fs:           fs,
+		rootDir:      param.RootDir,
+		pwd:          param.PWD,
+		mode:         param.TrustMode,
+		configReader: configReader,
+	}
+}
+
+// Allow trusts the current content of the configuration file.
+func (store *Store) Allow(cfgFilePath string) error {
+	cfgFilePath = util.Abs(store.pwd, cfgFilePath)
+	hash, err := store.Hash(cfgFilePath)
+	if err != nil {
+		return err
+	}
+	p := store.getPath(cfgFilePath, hash)
+	if err := store.fs.MkdirAll(filepath.Dir(p), dirPermission); err != nil {
+		return fmt.Errorf("create a directory: %w", err)
+	}
+	if err := afero.WriteFile(store.fs, p, []byte(cfgFilePath+"\n"), filePermission); err != nil {
+		return fmt.Errorf("write a file to trust the configuration file: %w", err)
+	}
+	return nil
+}
+
+// Check returns an error if the configuration file isn't trusted.
+func (store *Store) Check(cfgFilePath string) error {
+	if store.mode == ModePermissive {
+		return nil
+	}
+	cfgFilePath = util.Abs(store.pwd, cfgFilePath)
+	hash, err := store.Hash(cfgFilePath)
+	if err != nil {
+		return err
+	}
+	if _, err := store.fs.Stat(store.getPath(cfgFilePath, hash)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return logerr.WithFields(errUntrustedConfig, logrus.Fields{ //nolint:wrapcheck
+				"config_file_path": cfgFilePath,
+			})
+		}
+		return fmt.Errorf("check if the configuration file is trusted: %w", err)
+	}
+	return nil
+}
+
+// Hash returns the hash of the configuration file, imported files, and local registries.
+func (store *Store) Hash(cfgFilePath string) (string, error) {
+	files, err := store.listFiles(cfgFilePath)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, file := range files {
+		fileHash, err := store.hashFile(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s  %s\n", fileHash, file)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// getPath returns the path of the file which records that the configuration file is trusted.
+// The file name is the hash of the configuration file path and the hash of the content.
+func (store *Store) getPath(cfgFilePath, hash string) string {
+	h := sha256.Sum256([]byte(cfgFilePath + "\n" + hash))
+	return filepath.Join(store.rootDir, "trust", hex.EncodeToString(h[:]))
+}
+
+func (store *Store) hashFile(p string) (string, error) {
+	file, err := store.fs.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("open a file: %w", logerr.WithFields(err, logrus.Fields{
+			"file_path": p,
+		}))
+	}
+	defer file.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("read a file: %w", logerr.WithFields(err, logrus.Fields{
+			"file_path": p,
+		}))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// listFiles returns the configuration file, imported files, and local registries.
+// Local registries in imported files are ignored because aqua doesn't use them.
+func (store *Store) listFiles(cfgFilePath string) ([]string, error) {
+	cfg := &aqua.Config{}
+	if err := store.configReader.Read(cfgFilePath, cfg); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	m := map[string]struct{}{
+		cfgFilePath: {},
+	}
+	for _, rgst := range cfg.Registries {
+		if rgst.Type == "local" {
+			m[rgst.Path] = struct{}{}
+		}
+	}
+	if err := store.listImports(cfgFilePath, m); err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(m))
+	for file := range m {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (store *Store) listImports(cfgFilePath string, m map[string]struct{}) error {
+	file, err := store.fs.Open(cfgFilePath)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer file.Close()
+	cfg := &aqua.Config{}
+	if err := yaml.NewDecoder(file).Decode(cfg); err != nil {
+		return fmt.Errorf("parse a configuration file as YAML %s: %w", cfgFilePath, err)
+	}
+	for _, pkg := range cfg.Packages {
+		if pkg == nil || pkg.Import == "" {
+			continue
+		}
+		p := filepath.Join(filepath.Dir(cfgFilePath), pkg.Import)
+		filePaths, err := afero.Glob(store.fs, p)
+		if err != nil {
+			return fmt.Errorf("read files with glob pattern (%s): %w", p, err)
+		}
+		for _, filePath := range filePaths {
+			if _, ok := m[filePath]; ok {
+				continue
+			}
+			m[filePath] = struct{}{}
+			if err := store.listImports(filePath, m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package trust_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/config"
+	reader "github.com/aquaproj/aqua/pkg/config-reader"
+	"github.com/aquaproj/aqua/pkg/trust"
+	"github.com/spf13/afero"
+)
+
+func TestStore_Check(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name    string
+		mode    string
+		files   map[string]string
+		updated map[string]string
+		isErr   bool
+	}{
+		{
+			name: "trusted",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v3.90.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.4.1
+`,
+			},
+		},
+		{
+			name: "configuration file is changed",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v3.90.0
+packages:
+`,
+			},
+			updated: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v3.90.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.4.1
+`,
+			},
+			isErr: true,
+		},
+		{
+			name: "imported file is changed",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v3.90.0
+packages:
+- import: aqua/*.yaml
+`,
+				"/home/foo/workspace/aqua/tfcmt.yaml": `packages:
+- name: suzuki-shunsuke/tfcmt@v3.4.1
+`,
+			},
+			updated: map[string]string{
+				"/home/foo/workspace/aqua/tfcmt.yaml": `packages:
+- name: suzuki-shunsuke/tfcmt@v3.4.0
+`,
+			},
+			isErr: true,
+		},
+		{
+			name: "local registry is changed",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: local
+  path: registry.yaml
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.4.1
+  registry: local
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_release
+  repo_owner: suzuki-shunsuke
+  repo_name: tfcmt
+`,
+			},
+			updated: map[string]string{
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: http
+  repo_owner: suzuki-shunsuke
+  repo_name: tfcmt
+  url: https://example.com/tfcmt.tar.gz
+`,
+			},
+			isErr: true,
+		},
+		{
+			name: "permissive",
+			mode: trust.ModePermissive,
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v3.90.0
+packages:
+`,
+			},
+			updated: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v3.90.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.4.1
+`,
+			},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			for name, body := range d.files {
+				if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			param := &config.Param{
+				RootDir:   "/home/foo/.local/share/aquaproj-aqua",
+				PWD:       "/home/foo/workspace",
+				TrustMode: d.mode,
+			}
+			store := trust.NewStore(fs, param, reader.New(fs, param))
+			if err := store.Allow("aqua.yaml"); err != nil {
+				t.Fatal(err)
+			}
+			for name, body := range d.updated {
+				if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := store.Check("/home/foo/workspace/aqua.yaml"); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
@@ -101,7 +101,7 @@ func TestUnarchiver_Unarchive(t *testing.T) {
 	}
 	logE := logrus.NewEntry(logrus.New())
 	ctx := context.Background()
-	httpDownloader := download.NewHTTPDownloader(http.DefaultClient)
+	httpDownloader := download.NewHTTPDownloader(http.DefaultClient, nil)
 	unarchiver := &unarchive.Unarchiver{}
 	for _, d := range data {
 		d := d
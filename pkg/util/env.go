@@ -0,0 +1,22 @@
+package util
+
+import "strings"
+
+// MergeEnvs merges environment variables in the format "<name>=<value>".
+// If the same environment variable is set multiple times, the last value wins.
+func MergeEnvs(envs ...[]string) []string {
+	merged := []string{}
+	indexes := map[string]int{}
+	for _, es := range envs {
+		for _, env := range es {
+			name, _, _ := strings.Cut(env, "=")
+			if i, ok := indexes[name]; ok {
+				merged[i] = env
+				continue
+			}
+			indexes[name] = len(merged)
+			merged = append(merged, env)
+		}
+	}
+	return merged
+}
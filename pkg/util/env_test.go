@@ -0,0 +1,40 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/util"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeEnvs(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		envs [][]string
+		exp  []string
+	}{
+		{
+			name: "empty",
+			exp:  []string{},
+		},
+		{
+			name: "the last value wins",
+			envs: [][]string{
+				{"FOO=foo", "BAR=bar"},
+				nil,
+				{"BAR=bar2", "BAZ=baz=1"},
+			},
+			exp: []string{"FOO=foo", "BAR=bar2", "BAZ=baz=1"},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(d.exp, util.MergeEnvs(d.envs...)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package util
+
+import "regexp"
+
+var hostPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?)*(:[0-9]{1,5})?$`)
+
+// IsHost returns true if s is a hostname with an optional port such as ghe.example.com:8443.
+// Hosts are joined into file paths, so path separators and ".." must not be accepted.
+func IsHost(s string) bool {
+	return hostPattern.MatchString(s)
+}
@@ -0,0 +1,35 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/pkg/util"
+)
+
+func TestIsHost(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		host string
+		exp  bool
+	}{
+		{host: "ghe.example.com", exp: true},
+		{host: "ghe-01.example.com:8443", exp: true},
+		{host: "127.0.0.1:8080", exp: true},
+		{host: ""},
+		{host: ".."},
+		{host: "../../x"},
+		{host: `ghe.example.com\foo`},
+		{host: "ghe.example.com/foo"},
+		{host: "-ghe.example.com"},
+		{host: "ghe..example.com"},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.host, func(t *testing.T) {
+			t.Parallel()
+			if f := util.IsHost(d.host); f != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, f)
+			}
+		})
+	}
+}
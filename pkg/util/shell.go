@@ -0,0 +1,8 @@
+package util
+
+import "strings"
+
+// QuoteShell quotes a string with single quotes for POSIX shells and fish.
+func QuoteShell(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}